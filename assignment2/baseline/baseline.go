@@ -0,0 +1,75 @@
+package baseline
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"time"
+)
+
+// tracker counts fitness evaluations against the budget and records the best fitness found. Baselines have no
+// population, so for generation limited runs a generation is popSize evaluations, the cost of one GA generation.
+type tracker struct {
+	evaluations int
+	generations int
+	popSize     int
+	function    f.Fitness
+	direction   f.Direction
+
+	evals              int
+	bestFitness        float64
+	bestGenes          []uint16
+	bestFitnessHistory []chart.BestFitness
+}
+
+func newTracker(evaluations int, generations int, popSize int, function f.Fitness, direction f.Direction) *tracker {
+	return &tracker{evaluations: evaluations, generations: generations, popSize: popSize, function: function, direction: direction, bestFitness: direction.Worst()}
+}
+
+// evaluate evaluates the fitness of genes, recording them if they are the best found so far
+func (t *tracker) evaluate(genes []uint16) float64 {
+	fitness := t.function(genes)
+	t.evals++
+	if t.direction.Better(fitness, t.bestFitness) {
+		t.bestFitness = fitness
+		t.bestGenes = append([]uint16(nil), genes...)
+		if len(t.bestFitnessHistory) == 0 {
+			t.bestFitnessHistory = append(t.bestFitnessHistory, chart.BestFitness{X: 0, Fitness: fitness})
+		} else if t.evaluations == 0 {
+			t.bestFitnessHistory = append(t.bestFitnessHistory, chart.BestFitness{X: t.evals / t.popSize, Fitness: fitness})
+		} else {
+			t.bestFitnessHistory = append(t.bestFitnessHistory, chart.BestFitness{X: t.evals, Fitness: fitness})
+		}
+	}
+	return fitness
+}
+
+// remaining is the number of fitness evaluations left in the budget
+func (t *tracker) remaining() int {
+	if t.evaluations != 0 {
+		return t.evaluations - t.evals
+	}
+	return t.generations*t.popSize - t.evals
+}
+
+// RunRandomSearch samples uniformly random solutions until the budget is spent
+func RunRandomSearch(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	t := newTracker(evaluations, generations, popSize, function, direction)
+	genes := make([]uint16, N)
+	for t.remaining() > 0 {
+		randomGenes(genes, r)
+		t.evaluate(genes)
+	}
+
+	return t.bestFitnessHistory, t.bestFitness, t.bestGenes
+}
+
+// randomGenes sets every gene to a uniformly random value
+func randomGenes(genes []uint16, r *rand.Rand) {
+	for i := 0; i < len(genes); i++ {
+		genes[i] = uint16(r.Intn(65536))
+	}
+}
@@ -0,0 +1,55 @@
+package baseline
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// countOnes is minimised by genes with no set bits
+func countOnes(x []uint16) float64 {
+	var ones float64
+	for _, gene := range x {
+		for bit := 0; bit < 16; bit++ {
+			if gene&(1<<bit) != 0 {
+				ones++
+			}
+		}
+	}
+	return ones
+}
+
+func TestTracker_evaluate(t *testing.T) {
+	tr := newTracker(0, 10, 2, countOnes, f.Minimise)
+	tr.evaluate([]uint16{3})
+	tr.evaluate([]uint16{7})
+	tr.evaluate([]uint16{8})
+	tr.evaluate([]uint16{0})
+
+	assert.Equal(t, 4, tr.evals, "Each evaluation should be counted")
+	assert.Equal(t, 0.0, tr.bestFitness, "Best fitness should be tracked")
+	assert.Equal(t, []uint16{0}, tr.bestGenes, "Best genes should be tracked")
+	assert.Equal(t, 3, len(tr.bestFitnessHistory), "Only improvements should be recorded")
+	assert.Equal(t, 2, tr.bestFitnessHistory[2].X, "Generations should be popSize evaluations")
+	assert.Equal(t, 16, tr.remaining(), "Remaining budget should be generations x popSize less evaluations")
+}
+
+func TestRunRandomSearch(t *testing.T) {
+	history, best, genes := RunRandomSearch(1000, 0, 100, f.RastriginN, f.Rastrigin, f.Minimise)
+	assert.Equal(t, f.RastriginN, len(genes), "Best solution should have N genes")
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		assert.LessOrEqual(t, history[i].X, 1000, "Evaluations should not exceed the limit")
+	}
+
+	history, _, _ = RunRandomSearch(0, 10, 100, f.RastriginN, f.Rastrigin, f.Minimise)
+	assert.LessOrEqual(t, history[len(history)-1].X, 10, "Generations should not exceed the limit")
+}
+
+func TestRandomGenes(t *testing.T) {
+	genes := make([]uint16, 100)
+	randomGenes(genes, rand.New(rand.NewSource(0)))
+	assert.NotEqual(t, make([]uint16, 100), genes, "Genes should be randomised")
+}
@@ -0,0 +1,44 @@
+package baseline
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"time"
+)
+
+// RunOnePlusOne runs the (1+1)-EA. Each generation the parent's bits are flipped with probability 1/(16N) to create
+// an offspring, which replaces the parent if it is at least as fit.
+func RunOnePlusOne(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	t := newTracker(evaluations, generations, popSize, function, direction)
+	parent := make([]uint16, N)
+	randomGenes(parent, r)
+	parentFitness := t.evaluate(parent)
+
+	mutationP := 1 / float64(16*N)
+	offspring := make([]uint16, N)
+	for t.remaining() > 0 {
+		copy(offspring, parent)
+		Mutate(offspring, mutationP, r)
+		if fitness := t.evaluate(offspring); !direction.Better(parentFitness, fitness) {
+			parent, offspring = offspring, parent
+			parentFitness = fitness
+		}
+	}
+
+	return t.bestFitnessHistory, t.bestFitness, t.bestGenes
+}
+
+// Mutate flips each bit of the genes with probability mutationP
+func Mutate(genes []uint16, mutationP float64, r *rand.Rand) {
+	for i := 0; i < len(genes); i++ {
+		for bit := 0; bit < 16; bit++ {
+			if r.Float64() < mutationP {
+				genes[i] ^= 1 << bit
+			}
+		}
+	}
+}
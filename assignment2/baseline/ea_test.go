@@ -0,0 +1,28 @@
+package baseline
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestMutate(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	genes := []uint16{0, 0}
+	Mutate(genes, 0, r)
+	assert.Equal(t, []uint16{0, 0}, genes, "No bits should flip with zero probability")
+	Mutate(genes, 1, r)
+	assert.Equal(t, []uint16{65535, 65535}, genes, "Every bit should flip with probability 1")
+}
+
+func TestRunOnePlusOne(t *testing.T) {
+	_, best, _ := RunOnePlusOne(5000, 0, 100, 4, countOnes, f.Minimise)
+	assert.Equal(t, 0.0, best, "(1+1)-EA should solve OneMax")
+
+	history, best, genes := RunOnePlusOne(5000, 0, 100, f.RastriginN, f.Rastrigin, f.Minimise)
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+	}
+}
@@ -0,0 +1,28 @@
+package baseline
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"time"
+)
+
+// RunHillClimb runs random-restart bit-flip hill climbing. From a random solution, improving single bit-flips are
+// taken until no bit-flip improves, then the climb restarts from a new random solution until the budget is spent.
+func RunHillClimb(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, bestImprovement bool) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	t := newTracker(evaluations, generations, popSize, function, direction)
+	vars := localsearch.AllVars(N)
+	genes := make([]uint16, N)
+	for t.remaining() > 0 {
+		randomGenes(genes, r)
+		fitness := t.evaluate(genes)
+		climb := localsearch.BitFlipClimb{Iters: t.remaining(), BestImprovement: bestImprovement}
+		climb.Search(genes, fitness, vars, t.evaluate, direction, r)
+	}
+
+	return t.bestFitnessHistory, t.bestFitness, t.bestGenes
+}
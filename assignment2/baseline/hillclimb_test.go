@@ -0,0 +1,21 @@
+package baseline
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRunHillClimb(t *testing.T) {
+	// Every bit-flip that clears a bit improves, so the climb should reach the optimum
+	_, best, genes := RunHillClimb(2000, 0, 100, 4, countOnes, f.Minimise, false)
+	assert.Equal(t, 0.0, best, "Hill climbing should solve a unimodal problem")
+	assert.Equal(t, []uint16{0, 0, 0, 0}, genes, "Best solution should be the optimum")
+
+	history, best, genes := RunHillClimb(5000, 0, 100, f.RastriginN, f.Rastrigin, f.Minimise, true)
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		assert.LessOrEqual(t, history[i].X, 5000, "Evaluations should not exceed the limit")
+	}
+}
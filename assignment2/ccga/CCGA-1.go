@@ -3,6 +3,7 @@ package ccga
 import (
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
 	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"github.com/jinzhu/copier"
 	"log"
@@ -17,8 +18,32 @@ const (
 	W          = 5   // Scaling Window width
 )
 
-func Run(hillClimb bool, evaluations int, generations int, popSize int, N int, function f.Fitness, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
-	bestFitness := math.MaxFloat64
+func Run(hillClimb bool, evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32, bounds f.Bounds) ([]chart.BestFitness, float64, []uint16) {
+	var ls localsearch.Config
+	if hillClimb {
+		ls = HillClimbConfig(bounds)
+	}
+	return RunMemetic(ls, evaluations, generations, popSize, N, function, direction, mutationP)
+}
+
+// HillClimbConfig gets the local search configuration for CCGA-HC, a stochastic hill climb applied to each
+// subpopulation's elite every generation
+func HillClimbConfig(bounds f.Bounds) localsearch.Config {
+	return localsearch.Config{
+		Searcher:  localsearch.StochasticHillClimb{Iters: 20, StepSize: localsearch.HillClimbStep * bounds.Width(), Bounds: bounds},
+		Frequency: 1,
+	}
+}
+
+// RunMemetic runs CCGA-1 applying the configured local search to the elite of each subpopulation
+func RunMemetic(ls localsearch.Config, evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
+	return RunMemeticUntil(ls, nil, false, evaluations, generations, popSize, N, function, direction, mutationP)
+}
+
+// RunMemeticUntil is RunMemetic, also ending the run early once the stop condition is met (if not nil). With
+// reevaluate set the elite of each subpopulation is re-evaluated every generation, for noisy functions.
+func RunMemeticUntil(ls localsearch.Config, stop common.StopCondition, reevaluate bool, evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
+	bestFitness := direction.Worst()
 	var evals int
 	var fMax float64 // Scaling Window f'max as per https://ieeexplore.ieee.org/document/4075583
 	var bestCoevolution []uint16
@@ -26,39 +51,63 @@ func Run(hillClimb bool, evaluations int, generations int, popSize int, N int, f
 	var bestFitnessHistory []chart.BestFitness
 
 	// Initialise CCGA-1's population
-	species := InitSpecies(N, popSize, time.Now().Unix())
+	species := InitSpecies(N, popSize, time.Now().UnixNano())
 	species.InitCoevolutions()
 	species.EvalFitness(function, 0)
-	species.SortFitness()
-	fitness, _ := species.GetBestFitness()
+	species.SortFitness(direction)
+	fitness, _ := species.GetBestFitness(direction)
 	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: fitness})
-	fMax, _ = species.GetWorstFitness() // Set initial value of f'max
+	fMax, _ = species.GetWorstFitness(direction) // Set initial value of f'max
 
 	if evaluations != 0 {
 		// Run CCGA for N function evaluations
 		for evals < evaluations {
-			species.doGeneration(function, hillClimb, mutationP, 0, &evals, &fMax, &bestFitness, &bestCoevolution, &bestFitnessHistory, &worstFitnessHistory)
+			species.doGeneration(function, direction, &ls, reevaluate, mutationP, 0, &evals, &fMax, &bestFitness, &bestCoevolution, &bestFitnessHistory, &worstFitnessHistory)
+			if stop != nil && stop(evals, bestFitness) {
+				break
+			}
 		}
 	} else if generations != 0 {
 		// Run CCGA for N generations
 		for gen := 0; gen < generations; gen++ {
-			species.doGeneration(function, hillClimb, mutationP, gen, &evals, &fMax, &bestFitness, &bestCoevolution, &bestFitnessHistory, &worstFitnessHistory)
+			species.doGeneration(function, direction, &ls, reevaluate, mutationP, gen, &evals, &fMax, &bestFitness, &bestCoevolution, &bestFitnessHistory, &worstFitnessHistory)
+			if stop != nil && stop(gen+1, bestFitness) {
+				break
+			}
 		}
 	}
 	return bestFitnessHistory, bestFitness, bestCoevolution
 }
 
 // doGeneration performs one generation of CCGA / CCGA-HC. This function should be repeatedly run until some terminating condition is met.
-func (spec Species) doGeneration(fitness f.Fitness, hillClimb bool, mutationP float32, gen int, evals *int, fMax *float64, bestFitness *float64, bestCoevolution *[]uint16, bestFitnessHistory *[]chart.BestFitness, worstFitnessHistory *[]float64) {
+func (spec Species) doGeneration(fitness f.Fitness, direction f.Direction, ls *localsearch.Config, reevaluate bool, mutationP float32, gen int, evals *int, fMax *float64, bestFitness *float64, bestCoevolution *[]uint16, bestFitnessHistory *[]chart.BestFitness, worstFitnessHistory *[]float64) {
 	so := rand.NewSource(time.Now().UnixNano())
 	r := rand.New(so)
+	applyLocalSearch := ls.NextGeneration()
 
 	for s := 0; s < len(spec); s++ {
 		subpop := spec[s]
 
-		// Apply hill climb on elitist (best) individual
-		if hillClimb {
-			*evals += subpop[0].HillClimb(fitness, 20, 5000, r)
+		// Re-evaluate the elite, whose fitness may be a lucky observation of a noisy function or learned by an earlier
+		// Baldwinian search, which its own genes do not have
+		if reevaluate || (applyLocalSearch && ls.Mode != localsearch.Lamarckian) {
+			subpop[0].EvalFitness(fitness, *fMax)
+			*evals += 1
+		}
+
+		// Apply local search (such as CCGA-HC's hill climb) on elitist (best) individual
+		if applyLocalSearch {
+			found, searchEvals := subpop[0].LocalSearch(ls, fitness, direction, *fMax, r)
+			*evals += searchEvals
+			if direction.Better(subpop[0].Fitness, *bestFitness) {
+				*bestFitness = subpop[0].Fitness
+				*bestCoevolution = append([]uint16(nil), found...)
+				if gen != 0 {
+					*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: gen, Fitness: subpop[0].Fitness})
+				} else {
+					*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: *evals, Fitness: subpop[0].Fitness})
+				}
+			}
 		}
 
 		subpop.RouletteSetup()
@@ -66,14 +115,14 @@ func (spec Species) doGeneration(fitness f.Fitness, hillClimb bool, mutationP fl
 		// Apply CCGA normally
 		for i := 1; i < len(subpop); i++ {
 			individual := &subpop[i]
-			individual.CoevolveRoulette(CrossoverP, spec, fitness, r)
+			individual.CoevolveRoulette(CrossoverP, spec, fitness, direction, r)
 			individual.Mutate(mutationP, r)
 			individual.EvalFitness(fitness, *fMax)
 			*evals += 1
 
-			if subpop[i].Fitness < *bestFitness {
+			if direction.Better(subpop[i].Fitness, *bestFitness) {
 				*bestFitness = subpop[i].Fitness
-				*bestCoevolution = subpop[i].Coevolution
+				*bestCoevolution = append([]uint16(nil), subpop[i].Coevolution...)
 				if gen != 0 {
 					*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: gen, Fitness: subpop[i].Fitness})
 				} else {
@@ -82,57 +131,41 @@ func (spec Species) doGeneration(fitness f.Fitness, hillClimb bool, mutationP fl
 			}
 		}
 
-		// Sort sub-population by fittest (smallest) to least fit (largest)
-		subpop.SortFitness()
+		// Sort sub-population from fittest to least fit
+		subpop.SortFitness(direction)
 
 		// Finds individual with worst fitness for updating sliding window
-		worstGenFitness, _ := subpop.GetWorstFitness()
+		worstGenFitness, _ := subpop.GetWorstFitness(direction)
 		*worstFitnessHistory = append(*worstFitnessHistory, worstGenFitness)
-		*fMax = common.CalculateFMax(*worstFitnessHistory, W)
+		*fMax = common.CalculateFMax(*worstFitnessHistory, W, direction)
 	}
 }
 
 // HillClimb performs a stochastic hill climb to better explore the best individual
-// stepSize is a multiplier applied to a random normally distributed offset value selected
-func (individual *Individual) HillClimb(fitness f.Fitness, iters int, stepSize int, r *rand.Rand) int {
-	BestFitness := individual.Fitness
-	BestGene := individual.Gene
-
-	for i := 0; i < iters; i++ {
-		offset := 0
-		// Randomly generate offsets using normal distribution until a valid offset is chosen
-		for offset == 0 && (int(BestGene)+offset > 0) && (int(BestGene)+offset <= 65535) {
-			offset = int(r.NormFloat64() * float64(stepSize))
-		}
-
-		candidate := BestGene
-		// Update individual's gene
-		if offset > 0 {
-			candidate += uint16(offset)
-		} else {
-			candidate -= uint16(math.Abs(float64(offset)))
-		}
-
-		// Evaluate candidate's fitness
-		individual.Coevolution[individual.SpeciesId] = candidate
-		candidateFitness := fitness(individual.Coevolution)
+// stepSize is a multiplier applied to a random normally distributed offset, moving the gene in decoded variable space
+// Return number of fitness evaluations
+func (individual *Individual) HillClimb(fitness f.Fitness, direction f.Direction, bounds f.Bounds, iters int, stepSize float64, r *rand.Rand) int {
+	hillClimb := localsearch.StochasticHillClimb{Iters: iters, StepSize: stepSize, Bounds: bounds}
+	_, evals := individual.LocalSearch(&localsearch.Config{Searcher: hillClimb}, fitness, direction, 0, r)
+	return evals
+}
 
-		// Update hill climber if fitness is improved
-		if candidateFitness < BestFitness {
-			BestGene = candidate
-			BestFitness = candidateFitness
-		}
+// LocalSearch applies a local search to the individual's own gene within its coevolution. The individual's Fitness is
+// updated and scaled against fMax, and with Lamarckian learning its Gene & Coevolution are updated too.
+// Return the coevolution found by the local search and number of fitness evaluations
+func (individual *Individual) LocalSearch(ls *localsearch.Config, fitness f.Fitness, direction f.Direction, fMax float64, r *rand.Rand) ([]uint16, int) {
+	coevolution, searchFitness, evals := ls.Searcher.Search(individual.Coevolution, individual.Fitness, []int{individual.SpeciesId}, fitness, direction, r)
+	if ls.Lamarckian(r) {
+		individual.Coevolution = coevolution
+		individual.Gene = coevolution[individual.SpeciesId]
 	}
-
-	individual.Gene = BestGene
-	individual.Fitness = BestFitness
-
-	// Return iters to increment the function evaluation counter
-	return iters
+	individual.Fitness = searchFitness
+	individual.ScaledFitness = math.Abs(fMax - individual.Fitness)
+	return coevolution, evals
 }
 
 // SelectNewPopulation updates the individuals in the subpopulation using tournament selection
-func (spec Species) SelectNewPopulation() {
+func (spec Species) SelectNewPopulation(direction f.Direction) {
 	// Make deep copy of last generation's subpopulation
 	lastGeneration := Species{}
 	err := copier.CopyWithOption(&lastGeneration, &spec, copier.Option{DeepCopy: true})
@@ -147,7 +180,7 @@ func (spec Species) SelectNewPopulation() {
 	for sp := 0; sp < len(spec); sp++ {
 		for i := 1; i < len(spec[0]); i++ {
 			individualA, individualB := lastGeneration[sp][r.Intn(len(spec[0]))], lastGeneration[sp][r.Intn(len(spec[0]))]
-			if individualA.Fitness > individualB.Fitness {
+			if direction.Better(individualB.Fitness, individualA.Fitness) {
 				spec[sp][i] = individualB
 			} else {
 				spec[sp][i] = individualA
@@ -205,7 +238,18 @@ func (individual *Individual) Mutate(MutationP float32, r *rand.Rand) {
 
 // CoevolveRoulette coevolves an individual with another roulette selected individual from its subpopulation
 // Other parameters are selected from the fittest members of the other subpopulations
-func (individual *Individual) CoevolveRoulette(crossoverP float32, spec Species, fitness f.Fitness, r *rand.Rand) {
+func (individual *Individual) CoevolveRoulette(crossoverP float32, spec Species, fitness f.Fitness, direction f.Direction, r *rand.Rand) {
+	// Collaborate with the current best subcomponents from each other subpopulation
+	context := make([]uint16, len(individual.Coevolution))
+	for N := 0; N < len(context); N++ {
+		context[N] = spec[N][0].Gene
+	}
+	individual.CoevolveContext(crossoverP, context, spec[individual.SpeciesId], fitness, direction, r)
+}
+
+// CoevolveContext coevolves an individual with another roulette selected individual from its own subpopulation (subpop)
+// Other parameters are taken from the context, the collaborators chosen from the other subpopulations
+func (individual *Individual) CoevolveContext(crossoverP float32, context []uint16, subpop Population, fitness f.Fitness, direction f.Direction, r *rand.Rand) {
 	NGenes := len(individual.Coevolution)
 
 	for N := 0; N < NGenes; N++ {
@@ -213,11 +257,11 @@ func (individual *Individual) CoevolveRoulette(crossoverP float32, spec Species,
 		//	1. We're updating the subpop member's own gene:
 		//		-> TwoPointCrossover with its existing gene & roulette-selected gene from the same subpopulation
 		//  2. We're picking genes for the coevolution from other subpopulations:
-		//      -> Select current best subcomponents
+		//      -> Use the collaborator from the context
 
 		if individual.SpeciesId != N {
 			// Coevolution Case 2
-			individual.Coevolution[N] = spec[N][0].Gene
+			individual.Coevolution[N] = context[N]
 		}
 	}
 
@@ -225,14 +269,14 @@ func (individual *Individual) CoevolveRoulette(crossoverP float32, spec Species,
 	// Update subpop member's own gene using two-point crossover
 	if r.Float32() < crossoverP {
 		// Coevolution Case 1
-		offspringA, offspringB := common.TwoPointCrossover(individual.Gene, spec[N].RouletteSelection(r).Gene)
+		offspringA, offspringB := common.TwoPointCrossover(individual.Gene, subpop.RouletteSelection(r).Gene)
 
 		// Pick best offspring
 		individual.Coevolution[N] = offspringA
 		fitnessA := fitness(individual.Coevolution)
 		individual.Coevolution[N] = offspringB
 		fitnessB := fitness(individual.Coevolution)
-		if fitnessA > fitnessB {
+		if direction.Better(fitnessB, fitnessA) {
 			individual.Coevolution[N] = offspringB
 		} else {
 			individual.Coevolution[N] = offspringA
@@ -305,27 +349,27 @@ func (individual *Individual) EvalFitness(fitness f.Fitness, fMax float64) {
 	individual.ScaledFitness = math.Abs(fMax - individual.Fitness)
 }
 
-// SortFitness sorts each subpopulation by fittest (smallest fitness score) to least fit (largest fitness score).
-func (spec Species) SortFitness() {
+// SortFitness sorts each subpopulation from fittest to least fit in the direction.
+func (spec Species) SortFitness(direction f.Direction) {
 	for s := 0; s < len(spec); s++ {
-		spec[s].SortFitness()
+		spec[s].SortFitness(direction)
 	}
 }
 
-// SortFitness sorts the population slice by fittest (smallest fitness score) to least fit (largest fitness score).
-func (subpop Population) SortFitness() {
+// SortFitness sorts the population slice from fittest to least fit in the direction.
+func (subpop Population) SortFitness(direction f.Direction) {
 	sort.Slice(subpop, func(i, j int) bool {
-		return subpop[i].Fitness < subpop[j].Fitness
+		return direction.Better(subpop[i].Fitness, subpop[j].Fitness)
 	})
 }
 
-// GetBestFitness finds the individual with the fittest (smallest) fitness score amongst the species
+// GetBestFitness finds the individual with the fittest fitness score amongst the species
 // Note: Run this after SortFitness so fitnesses are pre-sorted
-func (spec Species) GetBestFitness() (float64, []uint16) {
-	bestFitness := math.MaxFloat64
+func (spec Species) GetBestFitness(direction f.Direction) (float64, []uint16) {
+	bestFitness := direction.Worst()
 	var bestCoevolution []uint16
 	for s := 0; s < len(spec); s++ {
-		if spec[s][0].Fitness < bestFitness {
+		if direction.Better(spec[s][0].Fitness, bestFitness) {
 			bestFitness = spec[s][0].Fitness
 			bestCoevolution = spec[s][0].Coevolution
 		}
@@ -333,12 +377,12 @@ func (spec Species) GetBestFitness() (float64, []uint16) {
 	return bestFitness, bestCoevolution
 }
 
-// GetBestFitness finds the individual with the fittest (smallest) fitness score amongst the subpopulation
+// GetBestFitness finds the individual with the fittest fitness score amongst the subpopulation
 // Note: Run this after SortFitness so fitnesses are pre-sorted
-func (subpop Population) GetBestFitness() (float64, []uint16) {
-	bestFitness := math.MaxFloat64
+func (subpop Population) GetBestFitness(direction f.Direction) (float64, []uint16) {
+	bestFitness := direction.Worst()
 	var bestCoevolution []uint16
-	if subpop[0].Fitness < bestFitness {
+	if direction.Better(subpop[0].Fitness, bestFitness) {
 		bestFitness = subpop[0].Fitness
 		bestCoevolution = subpop[0].Coevolution
 	}
@@ -347,13 +391,13 @@ func (subpop Population) GetBestFitness() (float64, []uint16) {
 
 // GetWorstFitness finds the individual with the least fit score amongst the species
 // Note: Run this after SortFitness so fitnesses are pre-sorted
-func (spec Species) GetWorstFitness() (float64, []uint16) {
+func (spec Species) GetWorstFitness(direction f.Direction) (float64, []uint16) {
 	popSize := len(spec[0])
-	worstFitness := 0.0
+	worstFitness := -direction.Worst()
 	var worstCoevolution []uint16
 
 	for s := 0; s < len(spec); s++ {
-		if spec[s][popSize-1].Fitness > worstFitness {
+		if direction.Better(worstFitness, spec[s][popSize-1].Fitness) {
 			worstFitness = spec[s][popSize-1].Fitness
 			worstCoevolution = spec[s][popSize-1].Coevolution
 		}
@@ -363,13 +407,13 @@ func (spec Species) GetWorstFitness() (float64, []uint16) {
 
 // GetWorstFitness finds the individual with the least fit score amongst the subpopulation
 // Note: Run this after SortFitness so fitnesses are pre-sorted
-func (subpop Population) GetWorstFitness() (float64, []uint16) {
+func (subpop Population) GetWorstFitness(direction f.Direction) (float64, []uint16) {
 	popSize := len(subpop)
-	worstFitness := 0.0
+	worstFitness := -direction.Worst()
 	var worstCoevolution []uint16
 
 	for s := 0; s < len(subpop); s++ {
-		if subpop[popSize-1].Fitness > worstFitness {
+		if direction.Better(worstFitness, subpop[popSize-1].Fitness) {
 			worstFitness = subpop[popSize-1].Fitness
 			worstCoevolution = subpop[popSize-1].Coevolution
 		}
@@ -1,8 +1,11 @@
 package ccga
 
 import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
 	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -63,8 +66,8 @@ func TestSpecies_CoevolveRoulette_OneProbability(t *testing.T) {
 			Individual{1, 0xFFFF, 0, 0, 0.0, []uint16{0x0000, 0xFFFF}},
 		},
 	}
-	input[0][1].CoevolveRoulette(1.0, input, f.TestFunc, r)
-	input[1][1].CoevolveRoulette(1.0, input, f.TestFunc, r)
+	input[0][1].CoevolveRoulette(1.0, input, f.TestFunc, f.Minimise, r)
+	input[1][1].CoevolveRoulette(1.0, input, f.TestFunc, f.Minimise, r)
 
 	expectedGene1 := (input[0][1].Coevolution[0] == 0x0FF0) || (input[0][1].Coevolution[0] == 0xF00F)
 	assert.True(t, expectedGene1, "Genes were not crossed over as expected")
@@ -85,8 +88,8 @@ func TestSpecies_CoevolveRoulette_ZeroProbability(t *testing.T) {
 			Individual{1, 0x0000, 0, 0, 1.0, []uint16{0x0000, 0xFFFF}},
 		},
 	}
-	input[0][1].CoevolveRoulette(0.0, input, f.TestFunc, r)
-	input[1][1].CoevolveRoulette(0.0, input, f.TestFunc, r)
+	input[0][1].CoevolveRoulette(0.0, input, f.TestFunc, f.Minimise, r)
+	input[1][1].CoevolveRoulette(0.0, input, f.TestFunc, f.Minimise, r)
 
 	assert.Equal(t, uint16(0xFFFF), input[0][1].Coevolution[0], "Coevolved genes should not change when crossoverP is 0")
 	assert.Equal(t, uint16(0x0000), input[1][1].Coevolution[1], "Coevolved genes should not change when crossoverP is 0")
@@ -171,7 +174,7 @@ func TestSpecies_SortFitness(t *testing.T) {
 			Individual{1, 3456, 1, 0, 0, []uint16{4444, 4444, 4444}},
 		},
 	}
-	input.SortFitness()
+	input.SortFitness(f.Minimise)
 
 	assert.Equal(t, Individual{0, 9012, 250, 0, 0, []uint16{3333, 3333, 3333}}, input[0][0], "The 0-index does not contain the most fit individual (smallest fitness)")
 	assert.Equal(t, Individual{0, 3456, 2000, 0, 0, []uint16{4444, 4444, 4444}}, input[0][3], "The last index does not contain the last fit individual (largest fitness)")
@@ -195,7 +198,7 @@ func TestSpecies_GetBestFitness(t *testing.T) {
 		},
 	}
 
-	fitness, coevolution := input.GetBestFitness()
+	fitness, coevolution := input.GetBestFitness(f.Minimise)
 
 	assert.Equal(t, input[0][0].Fitness, fitness, "Did not get best fitness")
 	assert.Equal(t, input[0][0].Coevolution, coevolution, "Did not get coevolution associated with best fitness")
@@ -217,8 +220,145 @@ func TestSpecies_GetWorstFitness(t *testing.T) {
 		},
 	}
 
-	fitness, coevolution := input.GetWorstFitness()
+	fitness, coevolution := input.GetWorstFitness(f.Minimise)
 
 	assert.Equal(t, input[1][1].Fitness, fitness, "Did not get worst fitness")
 	assert.Equal(t, input[1][1].Coevolution, coevolution, "Did not get coevolution associated with worst fitness")
 }
+
+func TestSpecies_GetWorstFitness_Negative(t *testing.T) {
+	input := Species{
+		Population{
+			Individual{0, 1234, -2000, 0, 0, []uint16{1111, 1111}},
+			Individual{0, 5678, -1000, 0, 0, []uint16{2222, 2222}},
+		},
+		Population{
+			Individual{1, 2345, -3000, 0, 0, []uint16{3333, 3333}},
+			Individual{1, 6789, -1500, 0, 0, []uint16{4444, 4444}},
+		},
+	}
+
+	fitness, coevolution := input.GetWorstFitness(f.Minimise)
+	assert.Equal(t, input[0][1].Fitness, fitness, "Did not get worst fitness when every fitness is negative")
+	assert.Equal(t, input[0][1].Coevolution, coevolution, "Did not get coevolution associated with worst fitness")
+
+	fitness, _ = input[1].GetWorstFitness(f.Minimise)
+	assert.Equal(t, input[1][1].Fitness, fitness, "Did not get subpopulation's worst fitness when every fitness is negative")
+}
+
+// TestIndividual_LocalSearch ensures local search only changes the individual's own gene
+func TestIndividual_LocalSearch(t *testing.T) {
+	input := Individual{1, 2, 0, 0, 0, []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+	input.EvalFitness(f.Schwefel, 3000)
+	startFitness := input.Fitness
+
+	found, evals := input.LocalSearch(&localsearch.Config{Searcher: localsearch.BitFlipClimb{Iters: 50}}, f.Schwefel, f.Minimise, 3000, r)
+
+	assert.Equal(t, found, input.Coevolution, "Lamarckian local search should write found coevolution back")
+	assert.LessOrEqual(t, evals, 50, "Local search should not exceed its evaluation budget")
+	assert.Less(t, input.Fitness, startFitness, "Local search should improve fitness")
+	assert.Equal(t, input.Coevolution[1], input.Gene, "Individual's gene should be updated from its coevolution")
+	for g := 0; g < len(input.Coevolution); g++ {
+		if g != 1 {
+			assert.Equal(t, uint16(g+1), input.Coevolution[g], "Other species' genes should not change")
+		}
+	}
+}
+
+// TestIndividual_LocalSearch_Baldwinian ensures Baldwinian local search only changes the individual's fitness
+func TestIndividual_LocalSearch_Baldwinian(t *testing.T) {
+	input := Individual{1, 2, 0, 0, 0, []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+	input.EvalFitness(f.Schwefel, 3000)
+	startFitness := input.Fitness
+
+	ls := &localsearch.Config{Searcher: localsearch.BitFlipClimb{Iters: 50}, Mode: localsearch.Baldwinian}
+	found, _ := input.LocalSearch(ls, f.Schwefel, f.Minimise, 3000, r)
+
+	assert.Less(t, input.Fitness, startFitness, "Baldwinian local search should improve fitness")
+	assert.Equal(t, math.Abs(3000-input.Fitness), input.ScaledFitness, "Scaled fitness should be that of the learned fitness")
+	assert.Equal(t, f.Schwefel(found), input.Fitness, "Fitness should be that of the found coevolution")
+	assert.Equal(t, uint16(2), input.Gene, "Baldwinian local search should not change the individual's gene")
+	assert.Equal(t, []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, input.Coevolution, "Baldwinian local search should not change the individual's coevolution")
+}
+
+// doStaleGeneration performs a generation of CCGA-1 on Rastrigin after giving each subpopulation's elite a fitness
+// better than any its own genes can have, as if learned or observed with noise.
+// Return the best fitness and number of fitness evaluations
+func doStaleGeneration(ls *localsearch.Config, reevaluate bool) (float64, int) {
+	species := InitSpecies(f.RastriginN, 10, 0)
+	species.InitCoevolutions()
+	species.EvalFitness(f.Rastrigin, 0)
+	species.SortFitness(f.Minimise)
+	for s := range species {
+		species[s][0].Fitness = -1
+	}
+
+	var evals int
+	var fMax float64
+	bestFitness := math.MaxFloat64
+	var bestCoevolution []uint16
+	var bestFitnessHistory []chart.BestFitness
+	var worstFitnessHistory []float64
+	species.doGeneration(f.Rastrigin, f.Minimise, ls, reevaluate, f.RastriginMutationP, 0, &evals, &fMax, &bestFitness, &bestCoevolution, &bestFitnessHistory, &worstFitnessHistory)
+	return bestFitness, evals
+}
+
+// TestSpecies_doGeneration_Baldwinian ensures fitness learned by Baldwinian local search expires before the next search
+func TestSpecies_doGeneration_Baldwinian(t *testing.T) {
+	ls := &localsearch.Config{Searcher: localsearch.BitFlipClimb{Iters: 1}, Frequency: 1, Mode: localsearch.Baldwinian}
+	bestFitness, evals := doStaleGeneration(ls, false)
+
+	assert.GreaterOrEqual(t, bestFitness, 0.0, "Learned fitness should be re-evaluated before the next search")
+	assert.Equal(t, (1+1+9)*f.RastriginN, evals, "Re-evaluating the elite and its search should use fitness evaluations")
+}
+
+// TestSpecies_doGeneration_Reevaluate ensures elites are re-evaluated every generation when set
+func TestSpecies_doGeneration_Reevaluate(t *testing.T) {
+	_, evals := doStaleGeneration(nil, false)
+	assert.Equal(t, 9*f.RastriginN, evals, "Elites should not be re-evaluated unless set")
+
+	bestFitness, evals := doStaleGeneration(nil, true)
+	assert.GreaterOrEqual(t, bestFitness, 0.0, "Elites' fitness should be re-evaluated")
+	assert.Equal(t, (1+9)*f.RastriginN, evals, "Re-evaluating the elites should use fitness evaluations")
+}
+
+// TestIndividual_HillClimb ensures hill climbing near the upper bound does not wrap the gene around to small values
+func TestIndividual_HillClimb(t *testing.T) {
+	// Fitness improves towards the upper bound
+	upper := func(x []uint16) float64 {
+		return 65535 - float64(x[0])
+	}
+	input := Individual{0, 65000, 535, 0, 0, []uint16{65000}}
+	bounds := f.Bounds{Min: -5.12, Max: 5.12, Policy: f.Clamp}
+
+	evals := input.HillClimb(upper, f.Minimise, bounds, 50, 0.75, r)
+
+	assert.Equal(t, 50, evals, "Hill climb should use iters fitness evaluations")
+	assert.Equal(t, uint16(65535), input.Gene, "Hill climb should reach the upper bound without overflowing")
+	assert.Equal(t, 0.0, input.Fitness, "Hill climb should update fitness")
+}
+
+// TestRunMemeticUntil ensures CCGA-1 ends once the stop condition is met
+func TestRunMemeticUntil(t *testing.T) {
+	var calls []int
+	stop := func(x int, bestFitness float64) bool {
+		calls = append(calls, x)
+		return len(calls) == 2
+	}
+	RunMemeticUntil(localsearch.Config{}, stop, false, 100000, 0, 10, f.RastriginN, f.Rastrigin, f.Minimise, f.RastriginMutationP)
+	assert.Equal(t, 2, len(calls), "Stop condition should end the run when met")
+	assert.Equal(t, 2*9*f.RastriginN, calls[1], "Stop condition should be checked with evaluations used")
+}
+
+func TestRun_BestCoevolution(t *testing.T) {
+	params := f.GearTrain.Params()
+	_, best, coevolution := Run(false, 5000, 0, 20, params.N, params.Function, params.Direction, params.MutationP, f.Bounds{})
+	assert.Equal(t, best, params.Function(coevolution), "Best coevolution should not change after it is found")
+}
+
+func TestRun_Maximise(t *testing.T) {
+	params, _ := f.GetParams("onemax")
+	_, best, coevolution := Run(false, 0, 30, 20, params.N, params.Function, f.Maximise, params.MutationP, f.Bounds{})
+	assert.Equal(t, best, params.Function(coevolution), "Best coevolution should have the best fitness")
+	assert.Greater(t, best, float64(8*f.OneMaxN), "Maximising OneMax should set more than half of the bits")
+}
@@ -0,0 +1,251 @@
+package ccga
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"time"
+)
+
+// DynamicConfig holds the birth and death rules for CCGA with dynamic species creation and extinction.
+// As per Potter & De Jong (2000) 'Cooperative Coevolution: An Architecture for Evolving Coadapted Subcomponents'
+type DynamicConfig struct {
+	InitialSpecies int     // Number of species at the start of the run
+	MaxSpecies     int     // Upper limit on the number of species alive at once
+	StagnationGens int     // Generations without improvement before the collaboration (or a species) is stagnant
+	ImprovementTol float64 // Smallest fitness improvement counted as progress
+	MinAge         int     // Species younger than this many generations are never made extinct
+}
+
+// DefaultDynamicConfig returns a DynamicConfig starting with a single species for an N variable function
+func DefaultDynamicConfig(N int) DynamicConfig {
+	return DynamicConfig{
+		InitialSpecies: 1,
+		MaxSpecies:     2 * N,
+		StagnationGens: 5,
+		ImprovementTol: 1e-6,
+		MinAge:         10,
+	}
+}
+
+// Ecosystem is a growable set of species for CCGA with dynamic species. Each individual's SpeciesId is the variable of
+// the target function it optimises, several species can compete to optimise the same variable, and some variables may
+// not be optimised by any species until one is created for them.
+type Ecosystem struct {
+	Species         Species     // Species currently alive
+	Ages            []int       // Generations each species has been alive for
+	Stagnation      []int       // Generations since each species' elite last improved
+	BestFitness     []float64   // Best elite fitness seen in each species
+	Context         []uint16    // Collaboration of the best individuals for each variable
+	Representatives []int       // Index of species providing each variable in Context, -1 if no species covers it
+	Direction       f.Direction // Direction each species is optimised in
+}
+
+// RunDynamic runs CCGA-1 where the number of species changes over time. Stagnating species are made extinct and new
+// randomly initialised species are created whenever the collaboration stops improving.
+// Also returns the number of species alive over time.
+func RunDynamic(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32, config DynamicConfig) ([]chart.BestFitness, float64, []uint16, []chart.SpeciesCount) {
+	bestFitness := direction.Worst()
+	var evals int
+	var fMax float64 // Scaling Window f'max as per https://ieeexplore.ieee.org/document/4075583
+	var bestCoevolution []uint16
+	var worstFitnessHistory []float64 // Track worst fitness for each generation
+	var bestFitnessHistory []chart.BestFitness
+	var speciesCountHistory []chart.SpeciesCount
+	var stagnantGens int // Generations since the collaboration last improved
+
+	so := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(so)
+
+	// Initialise the ecosystem's species
+	eco := InitEcosystem(N, popSize, config.InitialSpecies, function, direction, r)
+	evals += len(eco.Species) * popSize
+	bestFitness, bestCoevolution = eco.GetBestFitness()
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+	speciesCountHistory = append(speciesCountHistory, chart.SpeciesCount{X: evals, Count: len(eco.Species)})
+	fMax, _ = eco.Species.GetWorstFitness(direction) // Set initial value of f'max
+
+	doGeneration := func(gen int) {
+		lastBest := bestFitness
+		eco.doGeneration(function, mutationP, gen, &evals, &fMax, &bestFitness, &bestCoevolution, &bestFitnessHistory, &worstFitnessHistory, config, r)
+
+		// Birth and death of species when collaboration stagnates
+		if direction.Better(direction.Worsen(bestFitness, config.ImprovementTol), lastBest) {
+			stagnantGens = 0
+		} else {
+			stagnantGens++
+		}
+		if stagnantGens >= config.StagnationGens {
+			eco.RemoveStagnantSpecies(config)
+			if len(eco.Species) < config.MaxSpecies {
+				evals += eco.AddSpecies(eco.SelectNewVariable(), popSize, function, fMax, r)
+			}
+			stagnantGens = 0
+		}
+
+		if gen != 0 {
+			speciesCountHistory = append(speciesCountHistory, chart.SpeciesCount{X: gen, Count: len(eco.Species)})
+		} else {
+			speciesCountHistory = append(speciesCountHistory, chart.SpeciesCount{X: evals, Count: len(eco.Species)})
+		}
+	}
+
+	if evaluations != 0 {
+		// Run CCGA for N function evaluations
+		for evals < evaluations {
+			doGeneration(0)
+		}
+	} else if generations != 0 {
+		// Run CCGA for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen)
+		}
+	}
+	return bestFitnessHistory, bestFitness, bestCoevolution, speciesCountHistory
+}
+
+// InitEcosystem creates an ecosystem with speciesN species for an N variable function. Species are assigned to
+// variables in order, variables without a species keep a random value in the collaboration.
+func InitEcosystem(N int, popSize int, speciesN int, function f.Fitness, direction f.Direction, r *rand.Rand) *Ecosystem {
+	eco := &Ecosystem{
+		Context:         make([]uint16, N),
+		Representatives: make([]int, N),
+		Direction:       direction,
+	}
+	for n := 0; n < N; n++ {
+		eco.Context[n] = uint16(r.Int())
+		eco.Representatives[n] = -1
+	}
+	for s := 0; s < speciesN; s++ {
+		eco.AddSpecies(s%N, popSize, function, 0, r)
+	}
+	return eco
+}
+
+// doGeneration performs one generation of CCGA-1 across each species alive in the ecosystem.
+func (eco *Ecosystem) doGeneration(fitness f.Fitness, mutationP float32, gen int, evals *int, fMax *float64, bestFitness *float64, bestCoevolution *[]uint16, bestFitnessHistory *[]chart.BestFitness, worstFitnessHistory *[]float64, config DynamicConfig, r *rand.Rand) {
+	for s := 0; s < len(eco.Species); s++ {
+		subpop := eco.Species[s]
+		subpop.RouletteSetup()
+
+		// Apply CCGA normally, with collaborators taken from the ecosystem's context
+		for i := 1; i < len(subpop); i++ {
+			individual := &subpop[i]
+			individual.CoevolveContext(CrossoverP, eco.Context, subpop, fitness, eco.Direction, r)
+			individual.Mutate(mutationP, r)
+			individual.EvalFitness(fitness, *fMax)
+			*evals += 1
+
+			if eco.Direction.Better(subpop[i].Fitness, *bestFitness) {
+				*bestFitness = subpop[i].Fitness
+				*bestCoevolution = append([]uint16(nil), subpop[i].Coevolution...)
+				if gen != 0 {
+					*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: gen, Fitness: subpop[i].Fitness})
+				} else {
+					*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: *evals, Fitness: subpop[i].Fitness})
+				}
+			}
+		}
+
+		// Sort sub-population from fittest to least fit
+		subpop.SortFitness(eco.Direction)
+
+		// Update species age and stagnation
+		eco.Ages[s]++
+		if eco.Direction.Better(eco.Direction.Worsen(subpop[0].Fitness, config.ImprovementTol), eco.BestFitness[s]) {
+			eco.BestFitness[s] = subpop[0].Fitness
+			eco.Stagnation[s] = 0
+		} else {
+			eco.Stagnation[s]++
+		}
+		eco.updateRepresentative(subpop[0].SpeciesId)
+
+		// Finds individual with worst fitness for updating sliding window
+		worstGenFitness, _ := subpop.GetWorstFitness(eco.Direction)
+		*worstFitnessHistory = append(*worstFitnessHistory, worstGenFitness)
+		*fMax = common.CalculateFMax(*worstFitnessHistory, W, eco.Direction)
+	}
+}
+
+// AddSpecies creates a new randomly initialised species optimising the given variable, evaluated in collaboration
+// with the ecosystem's context. Returns number of fitness evaluations.
+func (eco *Ecosystem) AddSpecies(variable int, popSize int, fitness f.Fitness, fMax float64, r *rand.Rand) int {
+	pop := make(Population, popSize)
+	for i := 0; i < popSize; i++ {
+		coevolution := make([]uint16, len(eco.Context))
+		copy(coevolution, eco.Context)
+		pop[i] = Individual{variable, uint16(r.Int()), 0.0, 0.0, 0.0, coevolution}
+		pop[i].Coevolution[variable] = pop[i].Gene
+	}
+	pop.EvalFitness(fitness, fMax)
+	pop.SortFitness(eco.Direction)
+
+	eco.Species = append(eco.Species, pop)
+	eco.Ages = append(eco.Ages, 0)
+	eco.Stagnation = append(eco.Stagnation, 0)
+	eco.BestFitness = append(eco.BestFitness, pop[0].Fitness)
+	eco.updateRepresentative(variable)
+	return popSize
+}
+
+// RemoveStagnantSpecies makes extinct any species old enough and stagnant for long enough that is not providing the
+// collaborator for its variable. At least one species is always kept alive.
+func (eco *Ecosystem) RemoveStagnantSpecies(config DynamicConfig) {
+	for s := len(eco.Species) - 1; s >= 0 && len(eco.Species) > 1; s-- {
+		variable := eco.Species[s][0].SpeciesId
+		if eco.Ages[s] >= config.MinAge && eco.Stagnation[s] >= config.StagnationGens && eco.Representatives[variable] != s {
+			eco.Species = append(eco.Species[:s], eco.Species[s+1:]...)
+			eco.Ages = append(eco.Ages[:s], eco.Ages[s+1:]...)
+			eco.Stagnation = append(eco.Stagnation[:s], eco.Stagnation[s+1:]...)
+			eco.BestFitness = append(eco.BestFitness[:s], eco.BestFitness[s+1:]...)
+			// Species after s have moved down one index
+			for v := 0; v < len(eco.Representatives); v++ {
+				if eco.Representatives[v] > s {
+					eco.Representatives[v]--
+				}
+			}
+		}
+	}
+}
+
+// SelectNewVariable picks which variable a newly created species should optimise. Variables without any species are
+// picked first, otherwise the variable whose representative species has stagnated the longest is picked.
+func (eco *Ecosystem) SelectNewVariable() int {
+	variable, mostStagnant := 0, -1
+	for v := 0; v < len(eco.Representatives); v++ {
+		rep := eco.Representatives[v]
+		if rep == -1 {
+			return v
+		}
+		if eco.Stagnation[rep] > mostStagnant {
+			variable, mostStagnant = v, eco.Stagnation[rep]
+		}
+	}
+	return variable
+}
+
+// updateRepresentative picks the species whose elite is fittest amongst those optimising the variable, and places its
+// gene into the context.
+func (eco *Ecosystem) updateRepresentative(variable int) {
+	best := -1
+	for s := 0; s < len(eco.Species); s++ {
+		if eco.Species[s][0].SpeciesId != variable {
+			continue
+		}
+		if best == -1 || eco.Direction.Better(eco.Species[s][0].Fitness, eco.Species[best][0].Fitness) {
+			best = s
+		}
+	}
+	eco.Representatives[variable] = best
+	if best != -1 {
+		eco.Context[variable] = eco.Species[best][0].Gene
+	}
+}
+
+// GetBestFitness finds the fittest elite fitness score amongst the ecosystem's species
+// Note: Species are kept sorted, so each species' elite is at the 0-index
+func (eco *Ecosystem) GetBestFitness() (float64, []uint16) {
+	bestFitness, bestCoevolution := eco.Species.GetBestFitness(eco.Direction)
+	return bestFitness, append([]uint16(nil), bestCoevolution...)
+}
@@ -0,0 +1,89 @@
+package ccga
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestInitEcosystem(t *testing.T) {
+	eco := InitEcosystem(3, 4, 2, f.TestFunc, f.Minimise, rand.New(rand.NewSource(0)))
+
+	assert.Equal(t, 2, len(eco.Species), "InitEcosystem did not create desired number of species")
+	assert.Equal(t, 4, len(eco.Species[0]), "InitEcosystem did not create desired PopSize")
+	assert.Equal(t, []int{0, 1, -1}, eco.Representatives, "Species should be assigned to variables in order")
+	assert.Equal(t, eco.Species[1][0].Gene, eco.Context[1], "Context should hold the representative's gene")
+}
+
+func TestEcosystem_AddSpecies(t *testing.T) {
+	eco := InitEcosystem(2, 3, 1, f.TestFunc, f.Minimise, rand.New(rand.NewSource(0)))
+	evals := eco.AddSpecies(1, 3, f.TestFunc, 0, rand.New(rand.NewSource(1)))
+
+	assert.Equal(t, 3, evals, "AddSpecies should evaluate each new individual")
+	assert.Equal(t, 2, len(eco.Species), "AddSpecies did not add a species")
+	assert.Equal(t, 1, eco.Species[1][0].SpeciesId, "New species should optimise the requested variable")
+	assert.Equal(t, 1, eco.Representatives[1], "New species should represent its uncovered variable")
+	assert.Equal(t, 0, eco.Ages[1], "New species should have age 0")
+}
+
+func TestEcosystem_RemoveStagnantSpecies(t *testing.T) {
+	eco := &Ecosystem{
+		Species: Species{
+			Population{Individual{0, 1, 10, 0, 0, []uint16{1, 2}}},
+			Population{Individual{0, 2, 20, 0, 0, []uint16{2, 2}}},
+			Population{Individual{1, 3, 10, 0, 0, []uint16{1, 3}}},
+		},
+		Ages:            []int{20, 20, 20},
+		Stagnation:      []int{10, 10, 10},
+		BestFitness:     []float64{10, 20, 10},
+		Context:         []uint16{1, 3},
+		Representatives: []int{0, 2},
+	}
+	config := DynamicConfig{StagnationGens: 5, MinAge: 10}
+
+	eco.RemoveStagnantSpecies(config)
+
+	assert.Equal(t, 2, len(eco.Species), "Only the stagnant species not representing its variable should be removed")
+	assert.Equal(t, uint16(3), eco.Species[1][0].Gene, "Wrong species was removed")
+	assert.Equal(t, []int{0, 1}, eco.Representatives, "Representatives should be re-indexed after removal")
+}
+
+func TestEcosystem_RemoveStagnantSpecies_Young(t *testing.T) {
+	eco := &Ecosystem{
+		Species: Species{
+			Population{Individual{0, 1, 10, 0, 0, []uint16{1}}},
+			Population{Individual{0, 2, 20, 0, 0, []uint16{2}}},
+		},
+		Ages:            []int{20, 2},
+		Stagnation:      []int{10, 10},
+		BestFitness:     []float64{10, 20},
+		Context:         []uint16{1},
+		Representatives: []int{0},
+	}
+
+	eco.RemoveStagnantSpecies(DynamicConfig{StagnationGens: 5, MinAge: 10})
+
+	assert.Equal(t, 2, len(eco.Species), "Species younger than MinAge should not be removed")
+}
+
+func TestEcosystem_SelectNewVariable(t *testing.T) {
+	eco := &Ecosystem{Stagnation: []int{3, 8}, Representatives: []int{0, 1, -1}}
+	assert.Equal(t, 2, eco.SelectNewVariable(), "Uncovered variables should be selected first")
+
+	eco.Representatives = []int{0, 1, 0}
+	assert.Equal(t, 1, eco.SelectNewVariable(), "Variable with most stagnant representative should be selected")
+}
+
+func TestRunDynamic(t *testing.T) {
+	config := DefaultDynamicConfig(f.SchwefelN)
+	history, best, coevolution, counts := RunDynamic(0, 30, 10, f.SchwefelN, f.Schwefel, f.Minimise, f.SchwefelMutationP, config)
+
+	assert.Equal(t, f.SchwefelN, len(coevolution), "Best coevolution should assign every variable")
+	assert.InDelta(t, f.Schwefel(coevolution), best, 0.0001, "Best fitness should match best coevolution")
+	assert.Equal(t, best, history[len(history)-1].Fitness, "Fitness history should end with best fitness")
+	assert.Equal(t, 1, counts[0].Count, "Run should start with InitialSpecies species")
+	for _, count := range counts {
+		assert.LessOrEqual(t, count.Count, config.MaxSpecies, "Species count should not exceed MaxSpecies")
+	}
+}
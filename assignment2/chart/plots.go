@@ -2,7 +2,6 @@ package chart
 
 import (
 	"fmt"
-	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/components"
 	"github.com/go-echarts/go-echarts/v2/opts"
@@ -11,21 +10,44 @@ import (
 )
 
 type EvolutionResults struct {
-	Title      string // Title to represent result
-	XLabel     string // Label to give X Axis
-	Iterations int    // Number of function evaluations represented in charts
+	Title      string  // Title to represent result
+	XLabel     string  // Label to give X Axis
+	Iterations int     // Number of function evaluations represented in charts
+	Encoding   string  // Encoding genes were decoded with (binary or gray)
+	YMin       float64 // Y-axis lower limit of charts, 0 to fit the data
+	YMax       float64 // Y-axis upper limit of charts, 0 to fit the data
+	Maximised  bool    // Whether the function was maximised rather than minimised
 
 	GAFitnessHistory []BestFitness // Best fitness over function evaluations for GA
 	BestFitnessGA    float64       // Best Fitness from standard GA
 	BestAssignmentGA []uint16      // Best assignment of genes
+	FeasibilityGA    float64       // Fraction of evaluations that were feasible (constrained functions only)
 
 	CCGAFitnessHistory []BestFitness // Best fitness over function evaluations for CCGA
 	BestFitnessCCGA    float64       // Best Fitness from CCGA-1
 	BestAssignmentCCGA []uint16      // Best assignment of genes
+	FeasibilityCCGA    float64       // Fraction of evaluations that were feasible (constrained functions only)
 
 	CCGAHCFitnessHistory []BestFitness // Best fitness over function evaluations for CCGA-HC
 	BestFitnessCCGAHC    float64       // Best Fitness from CCGA-HC
 	BestAssignmentCCGAHC []uint16      // Best assignment of genes
+	FeasibilityCCGAHC    float64       // Fraction of evaluations that were feasible (constrained functions only)
+
+	Algorithms []AlgorithmResults // Results from any further algorithms being compared, in the order they were run
+}
+
+// AlgorithmResults holds the results of one run of an algorithm other than the standard GA, CCGA-1 and CCGA-HC
+type AlgorithmResults struct {
+	Name           string        // Name of algorithm, used in chart legends and results JSON
+	FitnessHistory []BestFitness // Best fitness over function evaluations
+	BestFitness    float64       // Best Fitness found by the algorithm
+	BestAssignment []uint16      // Best assignment of genes
+	BestValues     []float64     // Best real values, encoded to BestAssignment (real-valued algorithms only)
+
+	SpeciesCountHistory []SpeciesCount // Number of species alive over function evaluations (dynamic CCGA only)
+	OptimaFound         int            // Number of distinct optima located by the final population (niching GA only)
+	Restarts            []int          // Function evaluations (or generations) each restart happened at (restarting algorithms only)
+	Feasibility         float64        // Fraction of evaluations that were feasible (constrained functions only)
 }
 
 type BestFitness struct {
@@ -33,6 +55,11 @@ type BestFitness struct {
 	Fitness float64
 }
 
+type SpeciesCount struct {
+	X     int
+	Count int
+}
+
 // PlotResults plots the average fitness over function evaluations for function evaluated and saves these to a HTML file.
 func PlotResults(output string, res [][]EvolutionResults) {
 	page := components.NewPage()
@@ -41,23 +68,16 @@ func PlotResults(output string, res [][]EvolutionResults) {
 
 	for i := 0; i < len(res); i++ {
 		result := res[i][0]
-		var YMax int
-		// Set Y-axis limits to same as those in paper
-		switch result.Title {
-		case optimisation.RastriginLabel:
-			YMax = 40
-		case optimisation.SchwefelLabel:
-			YMax = 400
-		case optimisation.GriewangkLabel:
-			YMax = 8
-		case optimisation.AckleyLabel:
-			YMax = 16
+		// Set Y-axis limits from the function's metadata, such as those in the paper, leaving unset limits to fit the data
+		var YMin, YMax interface{}
+		if result.YMin != 0 {
+			YMin = result.YMin
+		}
+		if result.YMax != 0 {
+			YMax = result.YMax
 		}
 		// Calculate average result, filling in any gaps in the data
 		yValsGA, yValsCCGA, yValsCCGAHC := averageResults(result.Iterations, res[i])
-		fmt.Println(result.Title, ": Best Average Fitness GA for:", yValsGA[result.Iterations-1])
-		fmt.Println(result.Title, ": Best Average Fitness CCGA:", yValsCCGA[result.Iterations-1])
-		fmt.Println(result.Title, ": Best Average Fitness CCGA-HC:", yValsCCGAHC[result.Iterations-1])
 
 		line := charts.NewLine()
 
@@ -68,11 +88,13 @@ func PlotResults(output string, res [][]EvolutionResults) {
 				Height:    "450px",
 			}),
 			charts.WithTitleOpts(opts.Title{
-				Title: result.Title,
+				Title:    result.Title,
+				Subtitle: subtitle(result),
 			}),
 			charts.WithYAxisOpts(opts.YAxis{
 				Name: "best individual",
 				//Max: int(math.Min(yValsCCGA[0], yValsGA[0])),
+				Min: YMin,
 				Max: YMax,
 			}),
 			charts.WithXAxisOpts(opts.XAxis{
@@ -83,17 +105,54 @@ func PlotResults(output string, res [][]EvolutionResults) {
 			}),
 		)
 
-		line.SetXAxis(xVals).
-			AddSeries("Standard GA", convertLineData(yValsGA)).
-			AddSeries("CCGA-1", convertLineData(yValsCCGA)).
-			AddSeries("CCGA-HC", convertLineData(yValsCCGAHC)).
-			SetSeriesOptions(
-				charts.WithLineChartOpts(opts.LineChart{
-					Smooth: true,
-				}),
-			)
+		line.SetXAxis(xVals)
+		// Add series for the standard GA, CCGA-1 and CCGA-HC, skipping any that were not run so have no fitness history
+		for _, series := range []struct {
+			name    string
+			printed string
+			run     bool
+			yVals   []float64
+		}{
+			{"Standard GA", "GA for", len(result.GAFitnessHistory) > 0, yValsGA},
+			{"CCGA-1", "CCGA", len(result.CCGAFitnessHistory) > 0, yValsCCGA},
+			{"CCGA-HC", "CCGA-HC", len(result.CCGAHCFitnessHistory) > 0, yValsCCGAHC},
+		} {
+			if series.run {
+				fmt.Println(result.Title, ": Best Average Fitness "+series.printed+":", series.yVals[result.Iterations-1])
+				line.AddSeries(series.name, convertLineData(series.yVals))
+			}
+		}
+
+		// Add series for any further algorithms being compared
+		for a := 0; a < len(result.Algorithms); a++ {
+			yVals := averageAlgorithmResults(result.Iterations, a, res[i])
+			fmt.Println(result.Title, ": Best Average Fitness", result.Algorithms[a].Name+":", yVals[result.Iterations-1])
+			if optima := averageOptimaFound(a, res[i]); optima > 0 {
+				fmt.Println(result.Title, ": Average Optima Found", result.Algorithms[a].Name+":", optima)
+			}
+			if restarts := restartMarkPoints(a, res[i], yVals); len(restarts) > 0 {
+				line.AddSeries(result.Algorithms[a].Name, convertLineData(yVals),
+					charts.WithMarkPointNameCoordItemOpts(restarts...),
+					charts.WithMarkPointStyleOpts(opts.MarkPointStyle{Symbol: []string{"pin"}, SymbolSize: 20}))
+			} else {
+				line.AddSeries(result.Algorithms[a].Name, convertLineData(yVals))
+			}
+		}
+
+		line.SetSeriesOptions(
+			charts.WithLineChartOpts(opts.LineChart{
+				Smooth: true,
+			}),
+		)
 
 		page.AddCharts(line)
+
+		// Plot the number of species over time for algorithms that vary it
+		for a := 0; a < len(result.Algorithms); a++ {
+			if len(result.Algorithms[a].SpeciesCountHistory) > 0 {
+				page.AddCharts(plotSpeciesCounts(xVals, a, res[i]))
+			}
+		}
 	}
 
 	f, err := os.Create(output + ".html")
@@ -139,6 +198,106 @@ func averageResults(iterations int, results []EvolutionResults) ([]float64, []fl
 	return yValsGAAveraged, yValsCCGAAveraged, yValsCCGAHCAveraged
 }
 
+// averageAlgorithmResults calculates the average results from several runs of the algorithm at index a of Algorithms
+func averageAlgorithmResults(iterations int, a int, results []EvolutionResults) []float64 {
+	histories := make([][]BestFitness, len(results))
+	for res := 0; res < len(results); res++ {
+		histories[res] = results[res].Algorithms[a].FitnessHistory
+	}
+	return averageHistories(iterations, histories)
+}
+
+// encodingSubtitle describes the encoding genes were decoded with, for chart subtitles
+func encodingSubtitle(encoding string) string {
+	if encoding == "" {
+		return ""
+	}
+	return encoding + "-coded genes"
+}
+
+// subtitle describes the encoding genes were decoded with and whether the function was maximised, for chart subtitles
+func subtitle(result EvolutionResults) string {
+	sub := encodingSubtitle(result.Encoding)
+	if result.Maximised {
+		if sub != "" {
+			sub += ", "
+		}
+		sub += "maximised"
+	}
+	return sub
+}
+
+// restartMarkPoints creates a mark point on the average fitness line at each point any run of the algorithm at index a
+// of Algorithms was restarted
+func restartMarkPoints(a int, results []EvolutionResults, yVals []float64) []opts.MarkPointNameCoordItem {
+	var points []opts.MarkPointNameCoordItem
+	marked := make(map[int]bool)
+	for res := 0; res < len(results); res++ {
+		for _, x := range results[res].Algorithms[a].Restarts {
+			if !marked[x] && x < len(yVals) {
+				marked[x] = true
+				points = append(points, opts.MarkPointNameCoordItem{Name: "Restart", Coordinate: []interface{}{x, yVals[x]}})
+			}
+		}
+	}
+	return points
+}
+
+// averageOptimaFound calculates the average number of distinct optima found by the algorithm at index a of Algorithms
+func averageOptimaFound(a int, results []EvolutionResults) float64 {
+	var sum int
+	for res := 0; res < len(results); res++ {
+		sum += results[res].Algorithms[a].OptimaFound
+	}
+	return float64(sum) / float64(len(results))
+}
+
+// averageHistories calculates the average of several fitness histories, filling in any gaps in the data
+func averageHistories(iterations int, histories [][]BestFitness) []float64 {
+	yValsAveraged := make([]float64, iterations)
+
+	for res := 0; res < len(histories); res++ {
+		yVals := fillMissingPoints(iterations, histories[res])
+		for i := 0; i < iterations; i++ {
+			yValsAveraged[i] += yVals[i] / float64(len(histories))
+		}
+	}
+
+	return yValsAveraged
+}
+
+// plotSpeciesCounts creates a chart of the average number of species alive for the algorithm at index a of Algorithms
+func plotSpeciesCounts(xVals []int, a int, results []EvolutionResults) *charts.Line {
+	result := results[0]
+	histories := make([][]BestFitness, len(results))
+	for res := 0; res < len(results); res++ {
+		for _, count := range results[res].Algorithms[a].SpeciesCountHistory {
+			histories[res] = append(histories[res], BestFitness{X: count.X, Fitness: float64(count.Count)})
+		}
+	}
+
+	line := charts.NewLine()
+	line.SetGlobalOptions(
+		charts.WithInitializationOpts(opts.Initialization{
+			Width:  "625px",
+			Height: "450px",
+		}),
+		charts.WithTitleOpts(opts.Title{
+			Title: result.Title + " (" + result.Algorithms[a].Name + " species)",
+		}),
+		charts.WithYAxisOpts(opts.YAxis{
+			Name: "species",
+		}),
+		charts.WithXAxisOpts(opts.XAxis{
+			Name: result.XLabel,
+		}),
+	)
+	line.SetXAxis(xVals).
+		AddSeries(result.Algorithms[a].Name, convertLineData(averageHistories(result.Iterations, histories)))
+
+	return line
+}
+
 // fillMissingPoints fills in gaps in results data so that scores are properly spaced on plots
 func fillMissingPoints(iterations int, BestFitnessHistory []BestFitness) []float64 {
 	yVals := make([]float64, iterations)
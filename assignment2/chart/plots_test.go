@@ -25,3 +25,27 @@ func TestFillMissingPoints(t *testing.T) {
 	assert.Equal(t, float64(100), yVals[3], "Fitness data should be filled in")
 	assert.Equal(t, float64(80), yVals[9], "Fitness data should be filled in")
 }
+
+func TestRestartMarkPoints(t *testing.T) {
+	results := []EvolutionResults{
+		{Algorithms: []AlgorithmResults{{Restarts: []int{2, 5}}}},
+		{Algorithms: []AlgorithmResults{{Restarts: []int{5, 20}}}},
+	}
+	yVals := []float64{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+	points := restartMarkPoints(0, results, yVals)
+	assert.Equal(t, 2, len(points), "Each restart within the chart should be marked once")
+	assert.Equal(t, []interface{}{2, 7.0}, points[0].Coordinate, "Restart should be marked on the average fitness line")
+	assert.Equal(t, []interface{}{5, 4.0}, points[1].Coordinate, "Restart should be marked on the average fitness line")
+}
+
+func TestEncodingSubtitle(t *testing.T) {
+	assert.Equal(t, "gray-coded genes", encodingSubtitle("gray"), "Subtitle should name the encoding")
+	assert.Equal(t, "", encodingSubtitle(""), "No subtitle without an encoding")
+}
+
+func TestSubtitle(t *testing.T) {
+	assert.Equal(t, "gray-coded genes, maximised", subtitle(EvolutionResults{Encoding: "gray", Maximised: true}), "Subtitle should note maximisation after the encoding")
+	assert.Equal(t, "maximised", subtitle(EvolutionResults{Maximised: true}), "Subtitle should note maximisation")
+	assert.Equal(t, "", subtitle(EvolutionResults{}), "No subtitle when minimised without an encoding")
+}
@@ -0,0 +1,256 @@
+package cmaes
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Strategy holds the state of one run of CMA-ES, as per Hansen's tutorial (arXiv:1604.00772)
+type Strategy struct {
+	N         int
+	Lambda    int         // Offspring per generation
+	Mu        int         // Parents recombined into the new mean
+	Direction f.Direction // Direction offspring are ranked in
+
+	Weights []float64
+	MuEff   float64
+	Cc      float64 // Learning rate of the evolution path for C
+	Cs      float64 // Learning rate of the evolution path for sigma
+	C1      float64 // Learning rate of the rank-one update
+	Cmu     float64 // Learning rate of the rank-mu update
+	Damps   float64 // Damping of sigma's update
+	ChiN    float64 // Expectation of ||N(0,I)||
+
+	Mean   []float64
+	Sigma  float64
+	Sigma0 float64
+	Pc     []float64   // Evolution path for C
+	Ps     []float64   // Evolution path for sigma
+	C      [][]float64 // Covariance matrix
+	B      [][]float64 // Eigenvectors of C, as columns
+	D      []float64   // Square roots of the eigenvalues of C
+
+	Gen         int
+	eigenGen    int
+	BestHistory []float64 // Best fitness of each generation
+}
+
+// DefaultLambda is the default number of offspring per generation for N variables
+func DefaultLambda(N int) int {
+	return 4 + int(3*math.Log(float64(N)))
+}
+
+// NewStrategy initialises CMA-ES around mean with step size sigma, creating lambda offspring per generation ranked in
+// the direction
+func NewStrategy(mean []float64, sigma float64, lambda int, direction f.Direction) *Strategy {
+	n := len(mean)
+	s := &Strategy{N: n, Lambda: lambda, Mu: lambda / 2, Direction: direction, Mean: append([]float64(nil), mean...), Sigma: sigma, Sigma0: sigma}
+
+	// Recombination weights, decreasing log-linearly with rank
+	var sum, sumSq float64
+	s.Weights = make([]float64, s.Mu)
+	for i := 0; i < s.Mu; i++ {
+		s.Weights[i] = math.Log(float64(s.Mu)+0.5) - math.Log(float64(i+1))
+		sum += s.Weights[i]
+	}
+	for i := 0; i < s.Mu; i++ {
+		s.Weights[i] /= sum
+		sumSq += s.Weights[i] * s.Weights[i]
+	}
+	s.MuEff = 1 / sumSq
+
+	nf := float64(n)
+	s.Cc = (4 + s.MuEff/nf) / (nf + 4 + 2*s.MuEff/nf)
+	s.Cs = (s.MuEff + 2) / (nf + s.MuEff + 5)
+	s.C1 = 2 / ((nf+1.3)*(nf+1.3) + s.MuEff)
+	s.Cmu = math.Min(1-s.C1, 2*(s.MuEff-2+1/s.MuEff)/((nf+2)*(nf+2)+s.MuEff))
+	s.Damps = 1 + 2*math.Max(0, math.Sqrt((s.MuEff-1)/(nf+1))-1) + s.Cs
+	s.ChiN = math.Sqrt(nf) * (1 - 1/(4*nf) + 1/(21*nf*nf))
+
+	s.Pc, s.Ps, s.D = make([]float64, n), make([]float64, n), make([]float64, n)
+	s.C, s.B = make([][]float64, n), make([][]float64, n)
+	for i := 0; i < n; i++ {
+		s.C[i], s.B[i] = make([]float64, n), make([]float64, n)
+		s.C[i][i], s.B[i][i], s.D[i] = 1, 1, 1
+	}
+	return s
+}
+
+// Sample creates Lambda offspring from N(Mean, Sigma^2 C), repairing any outside of the bounds
+func (s *Strategy) Sample(bounds f.Bounds, r *rand.Rand) [][]float64 {
+	xs := make([][]float64, s.Lambda)
+	for k := 0; k < s.Lambda; k++ {
+		// x = m + sigma * B * D * z
+		z := make([]float64, s.N)
+		for i := 0; i < s.N; i++ {
+			z[i] = s.D[i] * r.NormFloat64()
+		}
+		xs[k] = make([]float64, s.N)
+		for i := 0; i < s.N; i++ {
+			var bdz float64
+			for j := 0; j < s.N; j++ {
+				bdz += s.B[i][j] * z[j]
+			}
+			xs[k][i] = bounds.Repair(s.Mean[i]+s.Sigma*bdz, r)
+		}
+	}
+	return xs
+}
+
+// Update adapts the mean, evolution paths, covariance matrix and step size from the offspring and their fitness
+func (s *Strategy) Update(xs [][]float64, fitness []float64) {
+	order := make([]int, len(xs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return s.Direction.Better(fitness[order[a]], fitness[order[b]]) })
+	s.Gen++
+	s.BestHistory = append(s.BestHistory, fitness[order[0]])
+
+	// Steps of the selected offspring from the old mean, in units of sigma
+	ys := make([][]float64, s.Mu)
+	yw := make([]float64, s.N)
+	for k := 0; k < s.Mu; k++ {
+		ys[k] = make([]float64, s.N)
+		for i := 0; i < s.N; i++ {
+			ys[k][i] = (xs[order[k]][i] - s.Mean[i]) / s.Sigma
+			yw[i] += s.Weights[k] * ys[k][i]
+		}
+	}
+	for i := 0; i < s.N; i++ {
+		s.Mean[i] += s.Sigma * yw[i]
+	}
+
+	// Cumulation for sigma uses C^(-1/2) * yw = B * D^-1 * B^T * yw
+	invSqrtCyw := s.invSqrtC(yw)
+	var psNorm float64
+	for i := 0; i < s.N; i++ {
+		s.Ps[i] = (1-s.Cs)*s.Ps[i] + math.Sqrt(s.Cs*(2-s.Cs)*s.MuEff)*invSqrtCyw[i]
+		psNorm += s.Ps[i] * s.Ps[i]
+	}
+	psNorm = math.Sqrt(psNorm)
+
+	// Stall the update of pc when the step size is increasing quickly
+	hSig := 0.0
+	if psNorm/math.Sqrt(1-math.Pow(1-s.Cs, float64(2*s.Gen)))/s.ChiN < 1.4+2/(float64(s.N)+1) {
+		hSig = 1
+	}
+	for i := 0; i < s.N; i++ {
+		s.Pc[i] = (1-s.Cc)*s.Pc[i] + hSig*math.Sqrt(s.Cc*(2-s.Cc)*s.MuEff)*yw[i]
+	}
+
+	// Rank-one and rank-mu updates of the covariance matrix
+	for i := 0; i < s.N; i++ {
+		for j := 0; j <= i; j++ {
+			rankMu := 0.0
+			for k := 0; k < s.Mu; k++ {
+				rankMu += s.Weights[k] * ys[k][i] * ys[k][j]
+			}
+			c := (1-s.C1-s.Cmu)*s.C[i][j] +
+				s.C1*(s.Pc[i]*s.Pc[j]+(1-hSig)*s.Cc*(2-s.Cc)*s.C[i][j]) +
+				s.Cmu*rankMu
+			s.C[i][j], s.C[j][i] = c, c
+		}
+	}
+
+	s.Sigma *= math.Exp((s.Cs / s.Damps) * (psNorm/s.ChiN - 1))
+
+	// Decompose C lazily, often enough to be accurate while avoiding O(N^3) work each generation
+	if float64(s.Gen-s.eigenGen) > float64(s.Lambda)/(s.C1+s.Cmu)/float64(s.N)/10 {
+		s.decompose()
+	}
+}
+
+// invSqrtC multiplies y by C^(-1/2)
+func (s *Strategy) invSqrtC(y []float64) []float64 {
+	bty := make([]float64, s.N)
+	for j := 0; j < s.N; j++ {
+		for i := 0; i < s.N; i++ {
+			bty[j] += s.B[i][j] * y[i]
+		}
+		bty[j] /= s.D[j]
+	}
+	out := make([]float64, s.N)
+	for i := 0; i < s.N; i++ {
+		for j := 0; j < s.N; j++ {
+			out[i] += s.B[i][j] * bty[j]
+		}
+	}
+	return out
+}
+
+// decompose updates B and D from the covariance matrix
+func (s *Strategy) decompose() {
+	s.eigenGen = s.Gen
+	values, vectors := Eigen(s.C)
+	s.B = vectors
+	for i := 0; i < s.N; i++ {
+		// Rounding errors may make tiny eigenvalues negative
+		s.D[i] = math.Sqrt(math.Max(values[i], 1e-20))
+	}
+}
+
+// Stop checks the termination criteria of the run: a flat fitness history (TolFun), a tiny step size (TolX), an
+// ill-conditioned covariance matrix (ConditionCov), or no improvement over a long window (Stagnation).
+func (s *Strategy) Stop() bool {
+	window := 10 + int(math.Ceil(30*float64(s.N)/float64(s.Lambda)))
+
+	if s.Gen >= window {
+		recent := s.BestHistory[s.Gen-window:]
+		if maxOf(recent)-minOf(recent) < 1e-12 {
+			return true
+		}
+	}
+
+	tolX := 1e-12 * s.Sigma0
+	small := true
+	for i := 0; i < s.N; i++ {
+		if s.Sigma*math.Sqrt(s.C[i][i]) > tolX || s.Sigma*math.Abs(s.Pc[i]) > tolX {
+			small = false
+			break
+		}
+	}
+	if small {
+		return true
+	}
+
+	if d := maxOf(s.D) / minOf(s.D); d*d > 1e14 {
+		return true
+	}
+
+	// Stagnation when the recent median of the best fitness is no better than an older one
+	stagnation := window + int(0.2*float64(s.Gen))
+	if s.Gen >= 2*stagnation {
+		if !s.Direction.Better(median(s.BestHistory[s.Gen-stagnation:]), median(s.BestHistory[s.Gen-2*stagnation:s.Gen-stagnation])) {
+			return true
+		}
+	}
+	return false
+}
+
+func minOf(values []float64) float64 {
+	m := values[0]
+	for _, v := range values {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func maxOf(values []float64) float64 {
+	m := values[0]
+	for _, v := range values {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	if len(sorted)%2 == 0 {
+		return (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	return sorted[len(sorted)/2]
+}
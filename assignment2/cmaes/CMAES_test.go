@@ -0,0 +1,83 @@
+package cmaes
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// ellipsoid is an ill-conditioned function minimised at the origin
+func ellipsoid(x []float64) float64 {
+	var sum float64
+	for i, v := range x {
+		sum += float64(1+100*i) * v * v
+	}
+	return sum
+}
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(make([]float64, 10), 1, DefaultLambda(10), f.Minimise)
+	assert.Equal(t, 10, s.Lambda, "Default lambda for 10 variables should be 4 + 3ln(10)")
+	assert.Equal(t, 5, s.Mu, "Mu should be half of lambda")
+
+	var sum float64
+	for i, w := range s.Weights {
+		sum += w
+		if i > 0 {
+			assert.Less(t, w, s.Weights[i-1], "Weights should decrease with rank")
+		}
+	}
+	assert.InDelta(t, 1.0, sum, 1e-9, "Weights should sum to 1")
+	assert.True(t, s.MuEff > 1 && s.MuEff < float64(s.Mu), "MuEff should be between 1 and mu")
+}
+
+func TestStrategy_Sample(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -1, Max: 1, Policy: f.Clamp}
+	s := NewStrategy(make([]float64, 5), 10, 20, f.Minimise)
+	for _, x := range s.Sample(bounds, r) {
+		assert.Equal(t, 5, len(x), "Samples should have N variables")
+		for _, v := range x {
+			assert.True(t, v >= -1 && v <= 1, "Samples should be repaired within bounds")
+		}
+	}
+}
+
+func TestStrategy_Update(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -10, Max: 10, Policy: f.Clamp}
+	mean := []float64{3, 3, 3, 3, 3}
+	s := NewStrategy(mean, 1, DefaultLambda(5), f.Minimise)
+
+	for gen := 0; gen < 400 && !s.Stop(); gen++ {
+		xs := s.Sample(bounds, r)
+		fitness := make([]float64, len(xs))
+		for k := range xs {
+			fitness[k] = ellipsoid(xs[k])
+		}
+		s.Update(xs, fitness)
+	}
+	assert.Less(t, ellipsoid(s.Mean), 1e-6, "CMA-ES should converge on the optimum of the ellipsoid")
+	assert.Greater(t, s.C[0][0]/s.C[4][4], 3.0, "Covariance should be wider along less steep variables")
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			assert.Equal(t, s.C[i][j], s.C[j][i], "Covariance matrix should be symmetric")
+		}
+	}
+}
+
+func TestStrategy_Stop(t *testing.T) {
+	s := NewStrategy(make([]float64, 2), 1, 6, f.Minimise)
+	assert.False(t, s.Stop(), "New strategy should not stop")
+
+	s.Sigma = 1e-13
+	assert.True(t, s.Stop(), "Strategy should stop once the step size is tiny")
+
+	s = NewStrategy(make([]float64, 2), 1, 6, f.Minimise)
+	for gen := 0; gen < 50; gen++ {
+		s.Gen++
+		s.BestHistory = append(s.BestHistory, 1)
+	}
+	assert.True(t, s.Stop(), "Strategy should stop once the fitness is flat")
+}
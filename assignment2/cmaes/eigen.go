@@ -0,0 +1,65 @@
+package cmaes
+
+import "math"
+
+// Eigen decomposes a symmetric matrix using the cyclic Jacobi method. It returns the eigenvalues, and the
+// eigenvectors as the columns of a matrix, so that a = vectors * diag(values) * vectors^T. The input is not modified.
+func Eigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	m := make([][]float64, n)
+	vectors := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		m[i] = append([]float64(nil), a[i]...)
+		vectors[i] = make([]float64, n)
+		vectors[i][i] = 1
+	}
+
+	for sweep := 0; sweep < 100; sweep++ {
+		// Stop once the off-diagonal elements are negligible compared to the diagonal
+		var off, diag float64
+		for p := 0; p < n; p++ {
+			diag += m[p][p] * m[p][p]
+			for q := p + 1; q < n; q++ {
+				off += m[p][q] * m[p][q]
+			}
+		}
+		if off <= 1e-30*diag || off == 0 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+				// Rotate rows and columns p & q to zero m[p][q]
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p], m[k][q] = c*mkp-s*mkq, s*mkp+c*mkq
+				}
+				for k := 0; k < n; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k], m[q][k] = c*mpk-s*mqk, s*mpk+c*mqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p], vectors[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for i := 0; i < n; i++ {
+		values[i] = m[i][i]
+	}
+	return values, vectors
+}
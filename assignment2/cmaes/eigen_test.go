@@ -0,0 +1,37 @@
+package cmaes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEigen(t *testing.T) {
+	a := [][]float64{
+		{4, 1, 2},
+		{1, 3, 0},
+		{2, 0, 5},
+	}
+	values, vectors := Eigen(a)
+
+	for k := 0; k < 3; k++ {
+		// Each column of vectors should satisfy A v = lambda v, with v of unit length
+		var norm float64
+		for i := 0; i < 3; i++ {
+			var av float64
+			for j := 0; j < 3; j++ {
+				av += a[i][j] * vectors[j][k]
+			}
+			assert.InDelta(t, values[k]*vectors[i][k], av, 1e-9, "A v should equal lambda v")
+			norm += vectors[i][k] * vectors[i][k]
+		}
+		assert.InDelta(t, 1.0, norm, 1e-9, "Eigenvectors should have unit length")
+	}
+	assert.InDelta(t, 12.0, values[0]+values[1]+values[2], 1e-9, "Eigenvalues should sum to the trace")
+	assert.Equal(t, 4.0, a[0][0], "Input matrix should not be modified")
+}
+
+func TestEigen_Diagonal(t *testing.T) {
+	values, vectors := Eigen([][]float64{{2, 0}, {0, 7}})
+	assert.Equal(t, []float64{2, 7}, values, "Diagonal matrix's eigenvalues are its diagonal")
+	assert.Equal(t, [][]float64{{1, 0}, {0, 1}}, vectors, "Diagonal matrix's eigenvectors are the identity")
+}
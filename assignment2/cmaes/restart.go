@@ -0,0 +1,121 @@
+package cmaes
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Restart sets how CMA-ES is restarted once a run meets its termination criteria
+type Restart int
+
+const (
+	NoRestart Restart = iota // Stop once the first run terminates
+	IPOP                     // Double the population size at every restart, as per DOI 10.1109/CEC.2005.1554902
+	BIPOP                    // Alternate between doubling and small random population sizes, as per DOI 10.1145/1570256.1570333
+)
+
+// Config configures CMA-ES
+type Config struct {
+	Restart    Restart
+	Lambda     int     // Offspring per generation of the first run, 0 for the default of 4 + 3ln(N)
+	Sigma0     float64 // Initial step size, as a fraction of the width of the bounds
+	IncPopSize float64 // Factor the population size grows by at each IPOP restart
+}
+
+// DefaultConfig returns commonly used CMA-ES parameters for the restart strategy
+func DefaultConfig(restart Restart) Config {
+	return Config{Restart: restart, Sigma0: 0.3, IncPopSize: 2}
+}
+
+// GetRestart gets a Restart strategy by name
+func GetRestart(name string) (Restart, error) {
+	switch name {
+	case "none":
+		return NoRestart, nil
+	case "ipop":
+		return IPOP, nil
+	case "bipop":
+		return BIPOP, nil
+	}
+
+	return NoRestart, errors.New("invalid restart strategy passed to GetRestart")
+}
+
+// Run runs CMA-ES on the function's decoded variables, restarting from a random mean whenever a run terminates until
+// the evaluation or generation limit is reached. Generations are counted across every run. Without restarts CMA-ES
+// stops once its first run terminates, which may be before the limit.
+func Run(evaluations int, generations int, N int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config Config) ([]chart.BestFitness, float64, []float64) {
+	bestFitness := direction.Worst()
+	var bestVector []float64
+	var evals, gen int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	defaultLambda := config.Lambda
+	if defaultLambda == 0 {
+		defaultLambda = DefaultLambda(N)
+	}
+	sigma0 := config.Sigma0 * bounds.Width()
+
+	done := func() bool {
+		return (evaluations != 0 && evals >= evaluations) || (evaluations == 0 && gen >= generations)
+	}
+
+	// Budget used by each BIPOP regime, the first run counts towards the large regime
+	var largeEvals, smallEvals int
+	largeLambda := defaultLambda
+	for run := 0; !done() && (run == 0 || config.Restart != NoRestart); run++ {
+		lambda, sigma := defaultLambda, sigma0
+		regimeEvals := &largeEvals
+		switch {
+		case run == 0:
+		case config.Restart == IPOP || (config.Restart == BIPOP && largeEvals <= smallEvals):
+			largeLambda = int(float64(largeLambda) * config.IncPopSize)
+			lambda = largeLambda
+		case config.Restart == BIPOP:
+			// Small population with a smaller initial step size, both drawn at random
+			u := r.Float64()
+			lambda = int(float64(defaultLambda) * math.Pow(0.5*float64(largeLambda)/float64(defaultLambda), u*u))
+			sigma = sigma0 * math.Pow(10, -2*r.Float64())
+			regimeEvals = &smallEvals
+		}
+		if lambda < 2 {
+			lambda = 2 // At least one parent is recombined into the mean
+		}
+
+		mean := make([]float64, N)
+		for i := 0; i < N; i++ {
+			mean[i] = bounds.Min + r.Float64()*bounds.Width()
+		}
+		strategy := NewStrategy(mean, sigma, lambda, direction)
+
+		for !done() && !strategy.Stop() {
+			xs := strategy.Sample(bounds, r)
+			fitness := make([]float64, len(xs))
+			for k := range xs {
+				fitness[k] = function(xs[k])
+				evals++
+				*regimeEvals++
+
+				if direction.Better(fitness[k], bestFitness) {
+					bestFitness, bestVector = fitness[k], xs[k]
+					if evaluations == 0 {
+						bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+					} else {
+						bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+					}
+				}
+			}
+			strategy.Update(xs, fitness)
+			gen++
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestVector
+}
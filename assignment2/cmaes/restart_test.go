@@ -0,0 +1,48 @@
+package cmaes
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetRestart(t *testing.T) {
+	restart, err := GetRestart("bipop")
+	assert.Nil(t, err, "GetRestart should find bipop")
+	assert.Equal(t, BIPOP, restart, "GetRestart returned wrong restart strategy")
+
+	_, err = GetRestart("invalid")
+	assert.NotNil(t, err, "GetRestart should error for unknown restart strategy")
+}
+
+func TestRun(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	for _, restart := range []Restart{NoRestart, IPOP, BIPOP} {
+		history, best, x := Run(20000, 0, f.RastriginN, f.RastriginReal, f.Minimise, bounds, DefaultConfig(restart))
+		assert.Equal(t, f.RastriginN, len(x), "Best solution should have N variables")
+		assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match best solution")
+		assert.Equal(t, best, history[len(history)-1].Fitness, "Last entry of history should be best fitness")
+		for i := 1; i < len(history); i++ {
+			assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+			assert.LessOrEqual(t, history[i].X, 20000+DefaultLambda(f.RastriginN)*64, "Evaluations should stay near the limit")
+		}
+	}
+}
+
+// TestRun_NoRestart ensures CMA-ES without restarts stops once its first run terminates
+func TestRun_NoRestart(t *testing.T) {
+	var calls int
+	function := func(x []float64) float64 {
+		calls++
+		return f.RastriginReal(x)
+	}
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	Run(1000000, 0, f.RastriginN, function, f.Minimise, bounds, DefaultConfig(NoRestart))
+	assert.Less(t, calls, 1000000, "CMA-ES should stop without restarting once its run terminates")
+}
+
+func TestRun_Generations(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	history, _, _ := Run(0, 50, f.RastriginN, f.RastriginReal, f.Minimise, bounds, DefaultConfig(IPOP))
+	assert.Less(t, history[len(history)-1].X, 50, "Generations should be counted across restarts")
+}
@@ -3,18 +3,30 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/baseline"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/ccga"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/cmaes"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/de"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/eda"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/es"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/ga"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/genome"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
 	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/pso"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/restart"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/result"
 	"github.com/cheggaaa/pb"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/stew/slice"
 	"log"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime/pprof"
+	"strings"
 	"sync"
 	"time"
 )
@@ -32,8 +44,124 @@ var rootCmd = &cobra.Command{
 		if cmd.Flags().Changed("cpuprofile") && filepath.Ext(cpuprofile) != ".prof" {
 			return errors.New("cpuprofile file argument must end with .prof extension")
 		}
-		if !(slice.Contains(algorithms, "ga") || slice.Contains(algorithms, "ccga") || slice.Contains(algorithms, "ccgahc")) {
-			return errors.New("at least one algorithm must be configured with -a ga,ccga,ccgahc")
+		if len(algorithms) == 0 {
+			return errors.New("at least one algorithm must be configured with -a " + strings.Join(validAlgorithms, ","))
+		}
+		for _, algorithm := range algorithms {
+			if !slice.Contains(validAlgorithms, algorithm) {
+				return errors.New("unknown algorithm " + algorithm + ", pick from " + strings.Join(validAlgorithms, ","))
+			}
+		}
+		for _, function := range functions {
+			if _, err := f.GetParams(function); err != nil {
+				return errors.New("unknown function " + function + ", pick from rastrigin,schwefel,griewangk,ackley,rosenbrock,sphere,ellipsoid,step,schafferf6,schafferf7,levy,weierstrass,michalewicz,zakharov,lunacek,katsuura,styblinskitang,onemax,geartrain,g01-g13,pressurevessel,weldedbeam,cec2010f1-cec2010f20,cec2013f1-cec2013f15")
+			}
+			if _, err := getParams(function); err != nil {
+				return errors.New("unable to set up function " + function + ": " + err.Error())
+			}
+		}
+		if condition < 1 {
+			return errors.New("condition number must be at least 1")
+		}
+		if _, err := f.GetNoiseModel(noiseModel); err != nil {
+			return errors.New("unknown noise model " + noiseModel + ", pick from additive,multiplicative,outlier")
+		}
+		if samples < 1 {
+			return errors.New("samples must be at least 1")
+		} else if cmd.Flags().Changed("evaluations") && evaluations < samples {
+			return errors.New("evaluations must be at least samples, as every sample counts as an evaluation")
+		}
+		if rollingWindow < 0 {
+			return errors.New("rolling window cannot be negative")
+		}
+		if reevaluateElites {
+			for _, algorithm := range algorithms {
+				if !slice.Contains(reevaluatedAlgorithms, algorithm) {
+					return errors.New("re-evaluating elites is not supported by " + algorithm + ", pick from " + strings.Join(reevaluatedAlgorithms, ","))
+				}
+			}
+		}
+		if handler, err := f.GetConstraintHandler(constraintHandling); err != nil {
+			return errors.New("unknown constraint handling " + constraintHandling + ", pick from static,dynamic,adaptive,deb,stochastic")
+		} else if handler == f.StochasticRanking && (len(algorithms) != 1 || algorithms[0] != "es") {
+			return errors.New("stochastic ranking is only supported by es, pick another constraint handling for other algorithms")
+		}
+		if _, err := ga.GetTopology(topology); err != nil {
+			return errors.New("unknown topology " + topology + ", pick from ring,full,random,torus")
+		}
+		if _, err := ga.GetMigrantSelection(emigrants); err != nil {
+			return errors.New("unknown emigrant selection " + emigrants + ", pick from best,worst,random")
+		}
+		if _, err := ga.GetMigrantSelection(replacement); err != nil {
+			return errors.New("unknown replacement selection " + replacement + ", pick from worst,random")
+		}
+		if islands < 1 {
+			return errors.New("islands must be at least 1")
+		}
+		if migrationInterval < 1 {
+			return errors.New("migration interval must be at least 1")
+		}
+		if _, err := ga.GetNeighbourhood(neighbourhood); err != nil {
+			return errors.New("unknown neighbourhood " + neighbourhood + ", pick from vonneumann,moore,radius")
+		}
+		if _, err := ga.GetUpdatePolicy(update); err != nil {
+			return errors.New("unknown update policy " + update + ", pick from sync,linesweep,randomsweep")
+		}
+		if _, err := f.GetBoundaryPolicy(boundary); err != nil {
+			return errors.New("unknown boundary policy " + boundary + ", pick from clamp,reflect,resample,wrap")
+		}
+		if _, err := f.GetEncoding(encoding); err != nil {
+			return errors.New("unknown encoding " + encoding + ", pick from binary,gray")
+		}
+		if _, err := localsearch.GetSearcher(localSearch, lsIters, f.Bounds{}); err != nil {
+			return errors.New("unknown local search " + localSearch + ", pick from hc,firstbit,bestbit,pattern,neldermead,sa")
+		}
+		if _, err := localsearch.GetMode(lsMode); err != nil {
+			return errors.New("unknown local search mode " + lsMode + ", pick from lamarckian,baldwinian,mixed")
+		}
+		if _, err := de.GetStrategy(deStrategy); err != nil {
+			return errors.New("unknown DE strategy " + deStrategy + ", pick from rand1bin,best1bin,currenttobest1bin,jade")
+		}
+		if _, err := cmaes.GetRestart(cmaesRestart); err != nil {
+			return errors.New("unknown CMA-ES restart strategy " + cmaesRestart + ", pick from none,ipop,bipop")
+		}
+		if cmd.Flags().Changed("cmaes-lambda") && cmaesLambda < 2 {
+			return errors.New("CMA-ES lambda must be at least 2")
+		}
+		if _, err := pso.GetTopology(psoTopology); err != nil {
+			return errors.New("unknown PSO topology " + psoTopology + ", pick from gbest,lbest")
+		}
+		if _, err := es.GetSelection(esSelection); err != nil {
+			return errors.New("unknown ES selection " + esSelection + ", pick from comma,plus")
+		}
+		if _, err := es.GetStepSizes(esSteps); err != nil {
+			return errors.New("unknown ES step sizes " + esSteps + ", pick from single,coordinate")
+		}
+		if _, err := ga.GetAgingScheme(aging); err != nil {
+			return errors.New("unknown aging scheme " + aging + ", pick from linear,fibonacci,polynomial,exponential")
+		}
+		if layers < 1 {
+			return errors.New("layers must be at least 1")
+		}
+		if ageGap < 1 {
+			return errors.New("age gap must be at least 1")
+		}
+		if kind, err := genome.GetKind(genomeKind); err != nil {
+			return errors.New("unknown genome " + genomeKind + ", pick from bits,real,integer")
+		} else if kind == genome.PermutationKind {
+			return errors.New("permutation genomes are not supported by any function, pick from bits,real,integer")
+		}
+		if bitsPerVar < 1 || bitsPerVar > 64 {
+			return errors.New("bits per variable must be from 1 to 64")
+		}
+		if _, err := ga.GetNichingMethod(niching); err != nil {
+			return errors.New("unknown niching method " + niching + ", pick from sharing,clearing,crowding,rts")
+		}
+		if _, err := ga.GetDistanceSpace(nicheSpace); err != nil {
+			return errors.New("unknown niche distance space " + nicheSpace + ", pick from genotype,phenotype")
+		}
+		if nicheRadius < 0 {
+			return errors.New("niche radius cannot be negative")
 		}
 
 		fmt.Println("Starting with algorithms:", algorithms)
@@ -42,22 +170,157 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// validAlgorithms lists the algorithms that can be compared, ga, ccga and ccgahc are the algorithms from the paper
+var validAlgorithms = []string{"ga", "ccga", "ccgahc", "ccgadyn", "gals", "ccgals", "island", "cellular", "de", "decc", "cmaes", "pso", "cpso", "ccpso2", "random", "hillclimb", "oneplusone", "es", "pbil", "cga", "umda", "ccpbil", "alps", "niching", "garestart", "ccgarestart", "ccgahcrestart", "genomega"}
+
+// reevaluatedAlgorithms can re-evaluate their elites with --reevaluate-elites, the GAs already re-evaluate their whole
+// population every generation
+var reevaluatedAlgorithms = []string{"ga", "ccga", "ccgahc", "gals", "ccgals", "island", "de", "decc", "es", "garestart", "ccgarestart", "ccgahcrestart"}
+
 var algorithms []string
+var functions []string
 var evaluations int
 var generations int
 var popSize int
 var repetitions int
 var cpuprofile string
 var output string
+var maxSpecies int
+var stagnationGens int
+var localSearch string
+var lsIters int
+var lsFrequency int
+var lsProbability float64
+var lsMode string
+var lamarckianP float64
+var boundary string
+var encoding string
+var islands int
+var migrationInterval int
+var migrants int
+var topology string
+var emigrants string
+var replacement string
+var neighbourhood string
+var radius int
+var update string
+var deStrategy string
+var deF float64
+var deCR float64
+var groupSize int
+var groupGens int
+var cmaesRestart string
+var cmaesLambda int
+var cmaesSigma float64
+var psoTopology string
+var swarms int
+var bestImprovement bool
+var esMu int
+var esRho int
+var esSelection string
+var esSteps string
+var pbilRate float64
+var layers int
+var ageGap int
+var aging string
+var niching string
+var nicheSpace string
+var nicheRadius float64
+var nicheCapacity int
+var rtsWindow int
+var optimaTolerance float64
+var restartStagnation int
+var genomeKind string
+var bitsPerVar int
+var restartTolerance float64
+var ipop bool
+var maxPopSize int
+var shift bool
+var rotate bool
+var condition float64
+var noise float64
+var noiseModel string
+var samples int
+var rollingWindow int
+var reevaluateElites bool
+var transformSeed int64
+var lsgoData string
+var constraintHandling string
+var penaltyCoefficient float64
+var rankingP float64
 
 func init() {
-	rootCmd.Flags().StringSliceVarP(&algorithms, "algorithms", "a", []string{"ga", "ccga", "ccgahc"}, "Which algorithms to compare (ga,ccga,ccgahc)")
+	rootCmd.Flags().StringSliceVarP(&algorithms, "algorithms", "a", []string{"ga", "ccga", "ccgahc"}, "Which algorithms to compare ("+strings.Join(validAlgorithms, ",")+")")
+	rootCmd.Flags().StringSliceVarP(&functions, "functions", "f", []string{"rastrigin", "schwefel", "griewangk", "ackley"}, "Which optimisation functions to benchmark (rastrigin,schwefel,griewangk,ackley,rosenbrock,sphere,ellipsoid,step,schafferf6,schafferf7,levy,weierstrass,michalewicz,zakharov,lunacek,katsuura,styblinskitang,onemax,geartrain,g01-g13,pressurevessel,weldedbeam,cec2010f1-cec2010f20,cec2013f1-cec2013f15)")
 	rootCmd.Flags().IntVarP(&evaluations, "evaluations", "e", 0, "Function evaluation limit")
 	rootCmd.Flags().IntVarP(&generations, "generations", "g", 0, "Generations limit")
 	rootCmd.Flags().IntVarP(&popSize, "population", "p", 100, "Population size")
 	rootCmd.Flags().IntVarP(&repetitions, "repetitions", "r", 50, "Number of times to repeat experiment")
 	rootCmd.Flags().StringVar(&cpuprofile, "cpuprofile", "", "Profile CPU usage to file (eg: assignment2.prof)")
 	rootCmd.Flags().StringVarP(&output, "output", "o", "", "Name of Fitness Plots and Results data files")
+	rootCmd.Flags().IntVar(&maxSpecies, "max-species", 0, "Maximum number of species for ccgadyn (default 2N)")
+	rootCmd.Flags().IntVar(&stagnationGens, "stagnation", 5, "Generations without improvement before ccgadyn creates or removes species")
+	rootCmd.Flags().StringVar(&localSearch, "localsearch", "hc", "Local search used by gals and ccgals (hc,firstbit,bestbit,pattern,neldermead,sa)")
+	rootCmd.Flags().IntVar(&lsIters, "ls-iters", 20, "Fitness evaluations used by each local search")
+	rootCmd.Flags().IntVar(&lsFrequency, "ls-frequency", 1, "Apply local search every this many generations")
+	rootCmd.Flags().Float64Var(&lsProbability, "ls-probability", 0, "Probability gals applies local search to each non-elite individual")
+	rootCmd.Flags().StringVar(&lsMode, "ls-mode", "lamarckian", "Whether local search results are written back to genes (lamarckian,baldwinian,mixed)")
+	rootCmd.Flags().Float64Var(&lamarckianP, "lamarckian-p", 0.5, "Probability of Lamarckian learning with --ls-mode mixed")
+	rootCmd.Flags().StringVar(&boundary, "boundary", "clamp", "How moves outside of variable bounds are handled (clamp,reflect,resample,wrap)")
+	rootCmd.Flags().StringVar(&encoding, "encoding", "binary", "Encoding of uint16 genes when decoded to variables (binary,gray)")
+	rootCmd.Flags().IntVar(&islands, "islands", 4, "Number of islands for island, each of the population size")
+	rootCmd.Flags().IntVar(&migrationInterval, "migration-interval", 10, "Generations between migrations for island")
+	rootCmd.Flags().IntVar(&migrants, "migrants", 2, "Migrants sent from each island to each destination for island")
+	rootCmd.Flags().StringVar(&topology, "topology", "ring", "Migration topology for island (ring,full,random,torus)")
+	rootCmd.Flags().StringVar(&emigrants, "emigrants", "best", "Which individuals emigrate for island (best,worst,random)")
+	rootCmd.Flags().StringVar(&replacement, "replacement", "worst", "Which individuals immigrants replace for island (worst,random)")
+	rootCmd.Flags().StringVar(&neighbourhood, "neighbourhood", "vonneumann", "Mating neighbourhood for cellular (vonneumann,moore,radius)")
+	rootCmd.Flags().IntVar(&radius, "radius", 2, "Neighbourhood radius for cellular with --neighbourhood radius")
+	rootCmd.Flags().StringVar(&update, "update", "sync", "Grid update order for cellular (sync,linesweep,randomsweep)")
+	rootCmd.Flags().StringVar(&deStrategy, "de-strategy", "rand1bin", "Mutation strategy for de and decc (rand1bin,best1bin,currenttobest1bin,jade)")
+	rootCmd.Flags().Float64Var(&deF, "de-f", 0.5, "Differential weight F for de and decc (initial mean with jade)")
+	rootCmd.Flags().Float64Var(&deCR, "de-cr", 0.9, "Crossover probability CR for de and decc (initial mean with jade)")
+	rootCmd.Flags().IntVar(&groupSize, "group-size", 5, "Variables in each randomly regrouped subcomponent for decc")
+	rootCmd.Flags().IntVar(&groupGens, "group-gens", 5, "DE generations spent on each subcomponent per cycle for decc")
+	rootCmd.Flags().StringVar(&cmaesRestart, "cmaes-restart", "ipop", "Restart strategy for cmaes (none,ipop,bipop)")
+	rootCmd.Flags().IntVar(&cmaesLambda, "cmaes-lambda", 0, "Offspring per generation of the first cmaes run (default 4+3ln(N))")
+	rootCmd.Flags().Float64Var(&cmaesSigma, "cmaes-sigma", 0.3, "Initial cmaes step size, as a fraction of the width of the function's bounds")
+	rootCmd.Flags().StringVar(&psoTopology, "pso-topology", "gbest", "Neighbourhood topology for pso and cpso (gbest,lbest)")
+	rootCmd.Flags().IntVar(&swarms, "swarms", 0, "Number of swarms the variables are split between for cpso (default N, CPSO-S)")
+	rootCmd.Flags().BoolVar(&bestImprovement, "best-improvement", false, "Take the best rather than first improving bit-flip in hillclimb")
+	rootCmd.Flags().IntVar(&esMu, "es-mu", 0, "Parents for es, lambda is the population size (default lambda/7)")
+	rootCmd.Flags().IntVar(&esRho, "es-rho", 0, "Parents recombined into each offspring for es (default mu)")
+	rootCmd.Flags().StringVar(&esSelection, "es-selection", "comma", "Survivor selection for es (comma,plus)")
+	rootCmd.Flags().StringVar(&esSteps, "es-steps", "coordinate", "Self-adapted step sizes for es (single,coordinate)")
+	rootCmd.Flags().Float64Var(&pbilRate, "pbil-rate", 0.1, "Learning rate of the probability vectors of pbil and ccpbil")
+	rootCmd.Flags().IntVar(&layers, "layers", 5, "Number of age layers for alps, each of the population size")
+	rootCmd.Flags().IntVar(&ageGap, "age-gap", 10, "Generations between restarts of the bottom layer for alps, and the unit of layer maximum ages")
+	rootCmd.Flags().StringVar(&aging, "aging", "polynomial", "How layer maximum ages grow for alps (linear,fibonacci,polynomial,exponential)")
+	rootCmd.Flags().StringVar(&niching, "niching", "sharing", "Niching method for niching (sharing,clearing,crowding,rts)")
+	rootCmd.Flags().StringVar(&nicheSpace, "niche-space", "genotype", "Space distances between individuals are measured in for niching (genotype,phenotype)")
+	rootCmd.Flags().Float64Var(&nicheRadius, "niche-radius", 0, "Niche radius for niching, 0 uses a tenth of the largest distance")
+	rootCmd.Flags().IntVar(&nicheCapacity, "niche-capacity", 1, "Individuals in each niche that keep their fitness with clearing")
+	rootCmd.Flags().IntVar(&rtsWindow, "rts-window", 0, "Individuals each offspring is compared with in restricted tournament selection, 0 uses the number of variables")
+	rootCmd.Flags().Float64Var(&optimaTolerance, "optima-tolerance", 1, "Niches within this fitness of the best count as distinct optima found for niching, negative counts every niche")
+	rootCmd.Flags().StringVar(&genomeKind, "genome", "bits", "Genome representation evolved by genomega (bits,real,integer)")
+	rootCmd.Flags().IntVar(&bitsPerVar, "bits", 16, "Bits per variable of genomega's bits genome, from 1 to 64")
+	rootCmd.Flags().IntVar(&restartStagnation, "restart-stagnation", 20, "Generations without improvement before garestart, ccgarestart and ccgahcrestart restart")
+	rootCmd.Flags().Float64Var(&restartTolerance, "restart-tolerance", 0, "Smallest change in best fitness counted as an improvement by the restart stagnation criterion")
+	rootCmd.Flags().BoolVar(&ipop, "ipop", false, "Double the population size at every restart of garestart, ccgarestart and ccgahcrestart")
+	rootCmd.Flags().IntVar(&maxPopSize, "max-pop-size", 0, "Largest population size --ipop may grow to, 0 for no limit")
+	rootCmd.Flags().BoolVar(&shift, "shift", false, "Shift each function's optimum to a seeded random point within its bounds")
+	rootCmd.Flags().BoolVar(&rotate, "rotate", false, "Rotate each function about its optimum by a seeded random orthogonal matrix, so its variables are non-separable")
+	rootCmd.Flags().Float64Var(&condition, "condition", 1, "Scale each function's variables about its optimum so a quadratic function has this condition number, 1 leaves them unscaled")
+	rootCmd.Flags().Float64Var(&noise, "noise", 0, "Standard deviation (scale for outlier noise) of the noise added to each function evaluation")
+	rootCmd.Flags().StringVar(&noiseModel, "noise-model", "additive", "How --noise changes each function evaluation (additive,multiplicative,outlier)")
+	rootCmd.Flags().IntVar(&samples, "samples", 1, "Samples of a noisy function averaged by each evaluation")
+	rootCmd.Flags().IntVar(&rollingWindow, "rolling-window", 0, "Latest evaluations of the same solution to a noisy function averaged, 0 for no rolling average")
+	rootCmd.Flags().BoolVar(&reevaluateElites, "reevaluate-elites", false, "Re-evaluate the elites of es (with plus selection), de, decc and the CCGAs each generation on noisy functions")
+	rootCmd.Flags().Int64Var(&transformSeed, "transform-seed", 1, "Seed of the random shift, rotation and noise of the functions")
+	rootCmd.Flags().StringVar(&lsgoData, "lsgo-data", "", "Directory of CEC LSGO data files (F<n>-xopt.txt, F<n>-p.txt, ...) to load instead of generating them")
+	rootCmd.Flags().StringVar(&constraintHandling, "constraints", "deb", "How the constraints of constrained functions are handled (static,dynamic,adaptive,deb,stochastic), stochastic ranking is for es only")
+	rootCmd.Flags().Float64Var(&penaltyCoefficient, "penalty", 1e6, "Coefficient of the static penalty, and initial coefficient of the adaptive penalty")
+	rootCmd.Flags().Float64Var(&rankingP, "ranking-p", 0.45, "Probability stochastic ranking compares solutions that are not both feasible by fitness alone")
 }
 
 func Execute() {
@@ -78,36 +341,32 @@ func Start() {
 		defer pprof.StopCPUProfile()
 	}
 
+	geneEncoding, _ := f.GetEncoding(encoding)
+	fmt.Println("Decoding genes with", geneEncoding, "encoding")
+
 	// Store results for each iteration of the function
 	var results [][]chart.EvolutionResults
+	var params []f.Params
 
-	fmt.Println("Benchmarking Rastrigin Function...")
-	results = append(results, RunGAs("rastrigin"))
-	fmt.Println("Benchmarking Schwefel Function...")
-	results = append(results, RunGAs("schwefel"))
-	fmt.Println("Benchmarking Griewangk Function...")
-	results = append(results, RunGAs("griewangk"))
-	fmt.Println("Benchmarking Ackley Function...")
-	results = append(results, RunGAs("ackley"))
-	//fmt.Println("Benchmarking Rosenbrock Function...")
-	//results = append(results, RunGAs("rosenbrock"))
+	for _, function := range functions {
+		Params, _ := getParams(function)
+		fmt.Println("Benchmarking " + Params.Label + "...")
+		results = append(results, RunGAs(Params))
+		params = append(params, Params)
+	}
 
 	if output != "" {
 		fmt.Println("Creating Charts")
 		chart.PlotResults(output, results)
 		fmt.Println("Writing Results JSON")
-		result.WriteResults(output, results)
+		result.WriteResults(output, results, params)
 	}
 }
 
-// RunGAs runs the genetic algorithms on an optimisation function and returns the fitness scores over the configured
-// iterations or generations for later plotting.
-func RunGAs(function string) []chart.EvolutionResults {
-	Params, err := f.GetParams(function)
-	if err != nil {
-		panic(err)
-	}
-
+// RunGAs runs the genetic algorithms on an optimisation function with its Params, and returns the fitness scores over
+// the configured iterations or generations for later plotting.
+func RunGAs(Params f.Params) []chart.EvolutionResults {
+	budget, perEvaluation := getBudget(Params)
 	var results []chart.EvolutionResults
 
 	bar := pb.New(repetitions)
@@ -116,68 +375,123 @@ func RunGAs(function string) []chart.EvolutionResults {
 	bar.Start()
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(repetitions)
+	// Seed the noise of every run up front, so runs are reproducible however their goroutines are scheduled
+	noiseSeeds := rand.New(rand.NewSource(transformSeed))
 
 	for i := 0; i < repetitions; i++ {
+		seeds := make(map[string]int64, len(algorithms))
+		for _, algorithm := range algorithms {
+			seeds[algorithm] = noiseSeeds.Int63()
+		}
 		// Run each separate GA repetition in its own goroutine
-		go func() {
+		go func(seeds map[string]int64) {
 
 			var YValsGA, YValsCCGA, YValsCCGAHC []chart.BestFitness
 			var BestFitnessGA, BestFitnessCCGA, BestFitnessCCGAHC float64
 			var BestAssignmentGA, BestAssignmentCCGA, BestAssignmentCCGAHC []uint16
+			var FeasibilityGA, FeasibilityCCGA, FeasibilityCCGAHC float64
 
 			// Start Standard Genetic Algorithm
 			if slice.Contains(algorithms, "ga") {
-				YValsGA, BestFitnessGA, BestAssignmentGA = ga.Run(evaluations, generations, popSize, Params.N, Params.Function, Params.MutationP)
+				run, feasibility := getRunParams(Params, seeds["ga"])
+				YValsGA, BestFitnessGA, BestAssignmentGA = ga.Run(budget, generations, popSize, Params.N, run.Function, run.Direction, Params.MutationP)
+				FeasibilityGA = feasibility.Rate()
 			}
 			// Start CCGA
 			if slice.Contains(algorithms, "ccga") {
-				YValsCCGA, BestFitnessCCGA, BestAssignmentCCGA = ccga.Run(false, evaluations, generations, popSize, Params.N, Params.Function, Params.MutationP)
+				run, feasibility := getRunParams(Params, seeds["ccga"])
+				YValsCCGA, BestFitnessCCGA, BestAssignmentCCGA = ccga.RunMemeticUntil(localsearch.Config{}, nil, getReevaluate(Params), budget, generations, popSize, Params.N, run.Function, run.Direction, Params.MutationP)
+				FeasibilityCCGA = feasibility.Rate()
 			}
 			// Start CCGAHC
 			if slice.Contains(algorithms, "ccgahc") {
-				YValsCCGAHC, BestFitnessCCGAHC, BestAssignmentCCGAHC = ccga.Run(true, evaluations, generations, popSize, Params.N, Params.Function, Params.MutationP)
+				ls := ccga.HillClimbConfig(getBounds(Params))
+				run, feasibility := getRunParams(Params, seeds["ccgahc"])
+				YValsCCGAHC, BestFitnessCCGAHC, BestAssignmentCCGAHC = ccga.RunMemeticUntil(ls, nil, getReevaluate(Params), budget, generations, popSize, Params.N, run.Function, run.Direction, Params.MutationP)
+				FeasibilityCCGAHC = feasibility.Rate()
+			}
+			// Start any further algorithms
+			var others []chart.AlgorithmResults
+			for _, algorithm := range algorithms {
+				if !slice.Contains([]string{"ga", "ccga", "ccgahc"}, algorithm) {
+					run, feasibility := getRunParams(Params, seeds[algorithm])
+					res := RunAlgorithm(algorithm, run, budget)
+					res.Feasibility = feasibility.Rate()
+					countSamples(res.FitnessHistory, perEvaluation)
+					for r := range res.Restarts {
+						res.Restarts[r] *= perEvaluation
+					}
+					for c := range res.SpeciesCountHistory {
+						res.SpeciesCountHistory[c].X *= perEvaluation
+					}
+					others = append(others, res)
+				}
 			}
 
+			countSamples(YValsGA, perEvaluation)
+			countSamples(YValsCCGA, perEvaluation)
+			countSamples(YValsCCGAHC, perEvaluation)
+
+			var result chart.EvolutionResults
 			if evaluations != 0 {
-				results = append(results, chart.EvolutionResults{
+				result = chart.EvolutionResults{
 					Title:      Params.Label,
+					Encoding:   Params.Encoding.String(),
+					YMin:       Params.PlotMin,
+					YMax:       Params.PlotMax,
+					Maximised:  Params.Direction == f.Maximise,
 					XLabel:     "function\nevals",
 					Iterations: evaluations,
 
 					GAFitnessHistory: YValsGA,
 					BestFitnessGA:    BestFitnessGA,
 					BestAssignmentGA: BestAssignmentGA,
+					FeasibilityGA:    FeasibilityGA,
 
 					CCGAFitnessHistory: YValsCCGA,
 					BestFitnessCCGA:    BestFitnessCCGA,
 					BestAssignmentCCGA: BestAssignmentCCGA,
+					FeasibilityCCGA:    FeasibilityCCGA,
 
 					CCGAHCFitnessHistory: YValsCCGAHC,
 					BestFitnessCCGAHC:    BestFitnessCCGAHC,
 					BestAssignmentCCGAHC: BestAssignmentCCGAHC,
-				})
+					FeasibilityCCGAHC:    FeasibilityCCGAHC,
+
+					Algorithms: others,
+				}
 			} else {
-				results = append(results, chart.EvolutionResults{
+				result = chart.EvolutionResults{
 					Title:      Params.Label,
+					Encoding:   Params.Encoding.String(),
+					YMin:       Params.PlotMin,
+					YMax:       Params.PlotMax,
+					Maximised:  Params.Direction == f.Maximise,
 					XLabel:     "gens",
 					Iterations: generations,
 
 					CCGAFitnessHistory: YValsCCGA,
 					BestFitnessCCGA:    BestFitnessCCGA,
 					BestAssignmentCCGA: BestAssignmentCCGA,
+					FeasibilityCCGA:    FeasibilityCCGA,
 
 					GAFitnessHistory: YValsGA,
 					BestFitnessGA:    BestFitnessGA,
 					BestAssignmentGA: BestAssignmentGA,
+					FeasibilityGA:    FeasibilityGA,
 
 					CCGAHCFitnessHistory: YValsCCGAHC,
 					BestFitnessCCGAHC:    BestFitnessCCGAHC,
 					BestAssignmentCCGAHC: BestAssignmentCCGAHC,
-				})
+					FeasibilityCCGAHC:    FeasibilityCCGAHC,
+
+					Algorithms: others,
+				}
 			}
+			results = append(results, result)
 			bar.Increment()
 			waitGroup.Done()
-		}()
+		}(seeds)
 
 		// Sleep 50ms between starting of each goroutine to avoid similar random numbers between runs
 		// (as each goroutine's rand is seeded with the unix time)
@@ -188,3 +502,289 @@ func RunGAs(function string) []chart.EvolutionResults {
 
 	return results
 }
+
+// RunAlgorithm runs one of the algorithms compared alongside the standard GA, CCGA-1 and CCGA-HC on an optimisation
+// function with a limit of budget evaluations (if running for evaluations), and returns its results for later plotting.
+func RunAlgorithm(algorithm string, Params f.Params, budget int) chart.AlgorithmResults {
+	res := chart.AlgorithmResults{Name: algorithm}
+
+	switch algorithm {
+	case "ccgadyn":
+		res.Name = "CCGA-Dynamic"
+		config := ccga.DefaultDynamicConfig(Params.N)
+		if maxSpecies != 0 {
+			config.MaxSpecies = maxSpecies
+		}
+		config.StagnationGens = stagnationGens
+		res.FitnessHistory, res.BestFitness, res.BestAssignment, res.SpeciesCountHistory = ccga.RunDynamic(budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP, config)
+	case "island":
+		res.Name = "Island-GA-" + topology
+		top, _ := ga.GetTopology(topology)
+		emigrantSelection, _ := ga.GetMigrantSelection(emigrants)
+		replacementSelection, _ := ga.GetMigrantSelection(replacement)
+		config := ga.IslandConfig{
+			Islands:           islands,
+			MigrationInterval: migrationInterval,
+			Migrants:          migrants,
+			Topology:          top,
+			Emigrants:         emigrantSelection,
+			Replacement:       replacementSelection,
+		}
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = ga.RunIslands(budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP, config)
+	case "cellular":
+		res.Name = "Cellular-GA-" + neighbourhood + "-" + update
+		neighbours, _ := ga.GetNeighbourhood(neighbourhood)
+		updatePolicy, _ := ga.GetUpdatePolicy(update)
+		config := ga.CellularConfig{Neighbourhood: neighbours, Radius: radius, Update: updatePolicy}
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = ga.RunCellular(budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP, config)
+	case "gals":
+		res.Name = "GA-" + localSearch + "-" + lsMode
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = ga.RunMemetic(getLocalSearchConfig(Params), budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP)
+	case "ccgals":
+		res.Name = "CCGA-" + localSearch + "-" + lsMode
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = ccga.RunMemeticUntil(getLocalSearchConfig(Params), nil, getReevaluate(Params), budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP)
+	case "de":
+		res.Name = "DE-" + deStrategy
+		res.FitnessHistory, res.BestFitness, res.BestValues = de.Run(budget, generations, popSize, Params.N, getRealFunction(Params), Params.Direction, getBounds(Params), getDEConfig(Params))
+	case "decc":
+		res.Name = "DECC-" + deStrategy
+		config := de.DECCConfig{DE: getDEConfig(Params), GroupSize: groupSize, GroupGens: groupGens, Random: true}
+		res.FitnessHistory, res.BestFitness, res.BestValues = de.RunDECC(budget, generations, popSize, Params.N, getRealFunction(Params), Params.Direction, getBounds(Params), config)
+	case "cmaes":
+		res.Name = "CMA-ES-" + cmaesRestart
+		restart, _ := cmaes.GetRestart(cmaesRestart)
+		config := cmaes.DefaultConfig(restart)
+		config.Lambda = cmaesLambda
+		config.Sigma0 = cmaesSigma
+		res.FitnessHistory, res.BestFitness, res.BestValues = cmaes.Run(budget, generations, Params.N, getRealFunction(Params), Params.Direction, getBounds(Params), config)
+	case "pso":
+		res.Name = "PSO-" + psoTopology
+		top, _ := pso.GetTopology(psoTopology)
+		res.FitnessHistory, res.BestFitness, res.BestValues = pso.Run(budget, generations, popSize, Params.N, getRealFunction(Params), Params.Direction, getBounds(Params), pso.DefaultConfig(top))
+	case "cpso":
+		K := swarms
+		if K == 0 {
+			K = Params.N
+		}
+		res.Name = fmt.Sprintf("CPSO-S%d-%s", K, psoTopology)
+		top, _ := pso.GetTopology(psoTopology)
+		res.FitnessHistory, res.BestFitness, res.BestValues = pso.RunCPSO(budget, generations, popSize, Params.N, K, getRealFunction(Params), Params.Direction, getBounds(Params), pso.DefaultConfig(top))
+	case "ccpso2":
+		res.Name = "CCPSO2"
+		res.FitnessHistory, res.BestFitness, res.BestValues = pso.RunCCPSO2(budget, generations, popSize, Params.N, getRealFunction(Params), Params.Direction, getBounds(Params), pso.DefaultCCPSO2Config(Params.N))
+	case "random":
+		res.Name = "Random-Search"
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = baseline.RunRandomSearch(budget, generations, popSize, Params.N, Params.Function, Params.Direction)
+	case "hillclimb":
+		res.Name = "Hill-Climbing"
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = baseline.RunHillClimb(budget, generations, popSize, Params.N, Params.Function, Params.Direction, bestImprovement)
+	case "oneplusone":
+		res.Name = "(1+1)-EA"
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = baseline.RunOnePlusOne(budget, generations, popSize, Params.N, Params.Function, Params.Direction)
+	case "es":
+		selection, _ := es.GetSelection(esSelection)
+		stepSizes, _ := es.GetStepSizes(esSteps)
+		config := es.DefaultConfig(popSize, selection, stepSizes)
+		if esMu != 0 {
+			config.Mu, config.Rho = esMu, esMu
+		}
+		if esRho != 0 {
+			config.Rho = esRho
+		}
+		if Params.Constraints != nil && getConstraintConfig().Handler == f.StochasticRanking {
+			config.Violation, config.RankingP = Params.RealViolation, rankingP
+		}
+		config.Reevaluate = getReevaluate(Params)
+		res.Name = config.Name() + "-" + esSteps
+		res.FitnessHistory, res.BestFitness, res.BestValues = es.Run(budget, generations, Params.N, getRealFunction(Params), Params.Direction, getBounds(Params), config)
+	case "pbil":
+		res.Name = "PBIL"
+		config := eda.DefaultPBILConfig()
+		config.LearningRate = pbilRate
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = eda.RunPBIL(budget, generations, popSize, Params.N, Params.Function, Params.Direction, config)
+	case "cga":
+		res.Name = "cGA"
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = eda.RunCGA(budget, generations, popSize, Params.N, Params.Function, Params.Direction)
+	case "umda":
+		res.Name = "UMDA"
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = eda.RunUMDA(budget, generations, popSize, Params.N, Params.Function, Params.Direction)
+	case "ccpbil":
+		res.Name = "CC-PBIL"
+		config := eda.DefaultPBILConfig()
+		config.LearningRate = pbilRate
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = eda.RunCooperativePBIL(budget, generations, popSize, Params.N, Params.Function, Params.Direction, config)
+	case "alps":
+		res.Name = "ALPS-GA-" + aging
+		scheme, _ := ga.GetAgingScheme(aging)
+		config := ga.ALPSConfig{Layers: layers, AgeGap: ageGap, Aging: scheme}
+		res.FitnessHistory, res.BestFitness, res.BestAssignment = ga.RunALPS(budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP, config)
+	case "garestart", "ccgarestart", "ccgahcrestart":
+		suffix := "-restart"
+		if ipop {
+			suffix = "-IPOP"
+		}
+		res.Name = map[string]string{"garestart": "GA", "ccgarestart": "CCGA-1", "ccgahcrestart": "CCGA-HC"}[algorithm] + suffix
+		config := restart.Config{Stagnation: restartStagnation, Tolerance: restartTolerance, IPOP: ipop, MaxPopSize: maxPopSize}
+		res.FitnessHistory, res.BestFitness, res.BestAssignment, res.Restarts = restart.Run(budget, generations, popSize, getRestartAlgorithm(algorithm, Params), Params.Direction, config)
+	case "genomega":
+		bounds := getBounds(Params)
+		kind, _ := genome.GetKind(genomeKind)
+		res.Name = "GA-" + genomeKind
+		mutationP := 1 / float64(Params.N)
+		if kind == genome.BitsKind {
+			res.Name = fmt.Sprintf("GA-%dbit", bitsPerVar)
+			mutationP = 1 / float64(bitsPerVar*Params.N)
+		}
+		newGenome := func(r *rand.Rand) genome.Genome { return genome.New(kind, Params.N, bitsPerVar, bounds, r) }
+		var best genome.Genome
+		res.FitnessHistory, res.BestFitness, best = ga.RunGenome(budget, generations, popSize, newGenome, genome.Numeric(getRealFunction(Params)), Params.Direction, mutationP)
+		res.BestValues = best.(genome.Vector).Decode()
+	case "niching":
+		res.Name = "Niching-GA-" + niching + "-" + nicheSpace
+		method, _ := ga.GetNichingMethod(niching)
+		space, _ := ga.GetDistanceSpace(nicheSpace)
+		config := ga.NichingConfig{Method: method, Space: space, Bounds: getBounds(Params), Sigma: nicheRadius, Alpha: 1,
+			Capacity: nicheCapacity, Window: rtsWindow, OptimaTolerance: optimaTolerance}
+		if config.Sigma == 0 {
+			config.Sigma = ga.DefaultNicheRadius(space, Params.N, config.Bounds)
+		}
+		if config.Window == 0 {
+			config.Window = Params.N
+		}
+		res.FitnessHistory, res.BestFitness, res.BestAssignment, res.OptimaFound = ga.RunNiching(budget, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP, config)
+	}
+	if res.BestValues != nil {
+		res.BestAssignment = getBounds(Params).EncodeAll(res.BestValues)
+	}
+
+	return res
+}
+
+// getParams gets the parameters of an optimisation function, loading the data files of CEC LSGO functions, shifting,
+// rotating and scaling it and making it noisy as set by the command line
+func getParams(function string) (f.Params, error) {
+	Params, err := f.GetParams(function)
+	if err != nil {
+		return Params, err
+	}
+	if l, err := f.GetLSGO(function); err == nil && lsgoData != "" {
+		if err := l.Load(lsgoData); err != nil {
+			return Params, err
+		}
+		Params = l.Params()
+	}
+
+	var transforms []f.Transform
+	if condition != 1 {
+		transforms = append(transforms, f.NewConditioning(condition, Params.Centre()))
+	}
+	if rotate {
+		transforms = append(transforms, f.NewRotation(Params.Centre(), transformSeed))
+	}
+	if shift {
+		transforms = append(transforms, f.NewShift(Params.Centre(), f.Bounds{Min: Params.ScaleMin, Max: Params.ScaleMax}, transformSeed))
+	}
+	Params, err = Params.Transform(transforms...)
+	if err != nil {
+		return Params, errors.New("its variables have their own bounds, so it cannot be shifted, rotated or scaled")
+	}
+	if noise != 0 {
+		model, _ := f.GetNoiseModel(noiseModel)
+		Params = Params.Noisy(f.NewNoise(model, noise, transformSeed))
+	}
+
+	// Decode genes with the chosen encoding for the whole experiment
+	geneEncoding, _ := f.GetEncoding(encoding)
+	return Params.WithEncoding(geneEncoding), nil
+}
+
+// getBudget gets the limit of evaluations as the algorithms count them, and the samples of the function each of their
+// evaluations takes. Resampling a noisy function takes several samples in each evaluation, every one of which counts
+// towards the evaluation limit. Runs limited by generations count generations rather than evaluations, so take 1.
+func getBudget(Params f.Params) (int, int) {
+	if Params.NoiseFree == nil || samples <= 1 || evaluations == 0 {
+		return evaluations, 1
+	}
+	return evaluations / samples, samples
+}
+
+// countSamples converts the evaluations in a fitness history from evaluations as the algorithm counted them to samples
+// of the function
+func countSamples(history []chart.BestFitness, perEvaluation int) {
+	for i := range history {
+		history[i].X *= perEvaluation
+	}
+}
+
+// getRunParams gets the parameters of a function for one run of an algorithm, with its own noise seeded with seed,
+// rolling averages of a noisy function and constraint handling state, along with the Feasibility of the run's evaluations
+func getRunParams(Params f.Params, seed int64) (f.Params, *f.Feasibility) {
+	return Params.Reseeded(seed).Averaged(f.NoiseStrategy{Samples: samples, Window: rollingWindow}).Constrained(getConstraintConfig())
+}
+
+// getConstraintConfig creates the constraint handling configuration set by the command line flags, a generation being
+// the population size in evaluations
+func getConstraintConfig() f.ConstraintConfig {
+	handler, _ := f.GetConstraintHandler(constraintHandling)
+	config := f.DefaultConstraintConfig(handler, popSize)
+	config.Penalty = penaltyCoefficient
+	config.RankingP = rankingP
+	return config
+}
+
+// getRestartAlgorithm gets the algorithm restarted by garestart, ccgarestart or ccgahcrestart
+func getRestartAlgorithm(algorithm string, Params f.Params) restart.Algorithm {
+	var ls localsearch.Config
+	if algorithm == "ccgahcrestart" {
+		ls = ccga.HillClimbConfig(getBounds(Params))
+	}
+	return func(evaluations int, generations int, popSize int, stop common.StopCondition) ([]chart.BestFitness, float64, []uint16) {
+		if algorithm == "garestart" {
+			return ga.RunMemeticUntil(ls, stop, evaluations, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP)
+		}
+		return ccga.RunMemeticUntil(ls, stop, getReevaluate(Params), evaluations, generations, popSize, Params.N, Params.Function, Params.Direction, Params.MutationP)
+	}
+}
+
+// getRealFunction gets the function real-valued algorithms evaluate directly on real values, or if it has none the
+// function evaluated at the nearest genes
+func getRealFunction(Params f.Params) f.RealFitness {
+	if Params.RealFunction != nil {
+		return Params.RealFunction
+	}
+	return getBounds(Params).Real(Params.Function)
+}
+
+// getBounds gets the bounds of the optimisation function's variables, with the boundary policy set by the command line
+func getBounds(Params f.Params) f.Bounds {
+	policy, _ := f.GetBoundaryPolicy(boundary)
+	return Params.GetBounds(policy)
+}
+
+// getLocalSearchConfig creates the local search configuration set by the command line flags
+func getLocalSearchConfig(Params f.Params) localsearch.Config {
+	searcher, _ := localsearch.GetSearcher(localSearch, lsIters, getBounds(Params))
+	mode, _ := localsearch.GetMode(lsMode)
+	return localsearch.Config{
+		Searcher:    searcher,
+		Frequency:   lsFrequency,
+		Probability: lsProbability,
+		Mode:        mode,
+		LamarckianP: lamarckianP,
+	}
+}
+
+// getDEConfig creates the differential evolution configuration set by the command line flags
+func getDEConfig(Params f.Params) de.Config {
+	strategy, _ := de.GetStrategy(deStrategy)
+	config := de.DefaultConfig(strategy)
+	config.F = deF
+	config.CR = deCR
+	config.Reevaluate = getReevaluate(Params)
+	return config
+}
+
+// getReevaluate gets whether elites are re-evaluated every generation, which is only done for noisy functions
+func getReevaluate(Params f.Params) bool {
+	return reevaluateElites && Params.NoiseFree != nil
+}
@@ -5,6 +5,7 @@ package common
 
 import (
 	"errors"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"math"
 )
 
@@ -90,8 +91,9 @@ func TwoPointCrossoverGA(parentA []uint16, parentB []uint16) ([]uint16, []uint16
 	return offspringA, offspringB, nil
 }
 
-// CalculateFMax finds the new FMax value for Scaling Window calculations
-func CalculateFMax(worstFitnessHistory []float64, W int) float64 {
+// CalculateFMax finds the new FMax value for Scaling Window calculations, the worst fitness in the direction within
+// the window
+func CalculateFMax(worstFitnessHistory []float64, W int, direction f.Direction) float64 {
 	var worstFitnessWindow []float64
 	if len(worstFitnessHistory) < W {
 		worstFitnessWindow = worstFitnessHistory
@@ -100,7 +102,7 @@ func CalculateFMax(worstFitnessHistory []float64, W int) float64 {
 	}
 	fMax := worstFitnessWindow[0]
 	for _, v := range worstFitnessWindow {
-		if v > fMax {
+		if direction.Better(fMax, v) {
 			fMax = v
 		}
 	}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -75,16 +76,19 @@ func TestTwoPointCrossoverGA_FullMask(t *testing.T) {
 
 func TestCalculateFMax(t *testing.T) {
 	fitnesswindow := []float64{123.0, 321.0, 242.0}
-	fMax := CalculateFMax(fitnesswindow, 5)
+	fMax := CalculateFMax(fitnesswindow, 5, f.Minimise)
 	assert.Equal(t, 321.0, fMax, "CalculateFMax did not get largest fitness score")
 
 	fitnesswindow = []float64{123.0, 321.0, 242.0, 452.0, 12.0}
-	fMax = CalculateFMax(fitnesswindow, 5)
+	fMax = CalculateFMax(fitnesswindow, 5, f.Minimise)
 	assert.Equal(t, 452.0, fMax, "CalculateFMax did not get largest fitness score")
 
 	fitnesswindow = []float64{999.0, 123.0, 999.0, 242.0, 452.0, 12.0, 125.0, 124.0}
-	fMax = CalculateFMax(fitnesswindow, 5)
+	fMax = CalculateFMax(fitnesswindow, 5, f.Minimise)
 	assert.Equal(t, 452.0, fMax, "CalculateFMax did not get largest fitness score within window")
+
+	fMax = CalculateFMax(fitnesswindow, 5, f.Maximise)
+	assert.Equal(t, 12.0, fMax, "CalculateFMax did not get smallest fitness score when maximising")
 }
 
 func TestHasBit(t *testing.T) {
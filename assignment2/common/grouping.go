@@ -0,0 +1,28 @@
+package common
+
+import "math/rand"
+
+// Groups splits N variables into groups of groupSize (the last group may be smaller), for cooperative coevolution of
+// subcomponents. Variables are grouped in order, or randomly if shuffle is set.
+func Groups(N int, groupSize int, shuffle bool, r *rand.Rand) [][]int {
+	order := make([]int, N)
+	for i := 0; i < N; i++ {
+		order[i] = i
+	}
+	if shuffle {
+		order = r.Perm(N)
+	}
+	if groupSize < 1 {
+		groupSize = 1
+	}
+
+	var groups [][]int
+	for start := 0; start < N; start += groupSize {
+		end := start + groupSize
+		if end > N {
+			end = N
+		}
+		groups = append(groups, order[start:end])
+	}
+	return groups
+}
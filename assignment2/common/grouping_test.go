@@ -0,0 +1,20 @@
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}, Groups(7, 3, false, r), "Groups should split variables in order")
+
+	groups := Groups(10, 4, true, r)
+	var all []int
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	assert.Equal(t, 3, len(groups), "Groups should make ceil(N/groupSize) groups")
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, all, "Random groups should hold every variable once")
+}
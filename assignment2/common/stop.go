@@ -0,0 +1,23 @@
+package common
+
+import f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+
+// StopCondition is checked after every generation with the progress of the run (function evaluations, or generations
+// when running for a number of generations) and the best fitness found so far. Returning true ends the run early.
+type StopCondition func(x int, bestFitness float64) bool
+
+// Stagnation creates a StopCondition ending a run once the best fitness has not improved by more than tolerance for
+// the given number of consecutive generations
+func Stagnation(generations int, tolerance float64, direction f.Direction) StopCondition {
+	best := direction.Worst()
+	stagnant := 0
+	return func(x int, bestFitness float64) bool {
+		if direction.Better(direction.Worsen(bestFitness, tolerance), best) {
+			best = bestFitness
+			stagnant = 0
+		} else {
+			stagnant++
+		}
+		return stagnant >= generations
+	}
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStagnation(t *testing.T) {
+	stop := Stagnation(2, 0.5, f.Minimise)
+	assert.False(t, stop(1, 10), "First generation improves on no fitness")
+	assert.False(t, stop(2, 9), "Improvement should reset stagnation")
+	assert.False(t, stop(3, 8.8), "One generation improving by less than tolerance is not yet stagnation")
+	assert.True(t, stop(4, 8.8), "Two generations without improvement should stop the run")
+
+	stop = Stagnation(2, 0, f.Minimise)
+	stop(1, 10)
+	stop(2, 10)
+	assert.False(t, stop(3, 9), "Improvement should reset stagnation")
+
+	stop = Stagnation(1, 0.5, f.Maximise)
+	assert.False(t, stop(1, 10), "First generation improves on no fitness")
+	assert.True(t, stop(2, 9), "Smaller fitness should not improve when maximising")
+}
@@ -0,0 +1,314 @@
+package de
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Strategy sets how DE creates mutant vectors
+type Strategy int
+
+const (
+	Rand1Bin          Strategy = iota // v = x_r1 + F(x_r2 - x_r3)
+	Best1Bin                          // v = x_best + F(x_r1 - x_r2)
+	CurrentToBest1Bin                 // v = x_i + F(x_best - x_i) + F(x_r1 - x_r2)
+	JADE                              // current-to-pbest/1 with an archive and adaptive F & CR, as per DOI 10.1109/TEVC.2009.2014613
+)
+
+// Config configures differential evolution
+type Config struct {
+	Strategy Strategy
+	F        float64 // Differential weight (initial mean of F for JADE)
+	CR       float64 // Binomial crossover probability (initial mean of CR for JADE)
+	P        float64 // JADE: mutate towards one of the top P fraction of the population
+	C        float64 // JADE: rate of adaptation of the means of F and CR
+
+	Reevaluate bool // Re-evaluate the best vector each generation (noisy functions)
+}
+
+// DefaultConfig returns commonly used DE parameters for the strategy
+func DefaultConfig(strategy Strategy) Config {
+	return Config{Strategy: strategy, F: 0.5, CR: 0.9, P: 0.05, C: 0.1}
+}
+
+// GetStrategy gets a Strategy by name
+func GetStrategy(name string) (Strategy, error) {
+	switch name {
+	case "rand1bin":
+		return Rand1Bin, nil
+	case "best1bin":
+		return Best1Bin, nil
+	case "currenttobest1bin":
+		return CurrentToBest1Bin, nil
+	case "jade":
+		return JADE, nil
+	}
+
+	return Rand1Bin, errors.New("invalid strategy passed to GetStrategy")
+}
+
+// Population holds real-valued vectors evolved by DE, along with the adaptive parameters used by JADE.
+// Each vector holds a value for every variable, but a generation may only vary some of the variables (see Generation).
+type Population struct {
+	Vectors [][]float64
+	Fitness []float64
+
+	MuF     float64     // JADE: mean of F
+	MuCR    float64     // JADE: mean of CR
+	Archive [][]float64 // JADE: parents recently replaced by their offspring
+}
+
+// Run runs differential evolution on the function's real-valued variables
+func Run(evaluations int, generations int, popSize int, N int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config Config) ([]chart.BestFitness, float64, []float64) {
+	var evals int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise DE's population
+	pop := InitPopulation(N, popSize, bounds, config, r)
+	evals += pop.EvalFitness(function)
+	best := pop.Best(direction)
+	bestFitness, bestVector := pop.Fitness[best], append([]float64(nil), pop.Vectors[best]...)
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	dims := allDims(N)
+	doGeneration := func(gen int) {
+		evals += pop.Generation(dims, function, direction, bounds, config, r)
+		best := pop.Best(direction)
+		if direction.Better(pop.Fitness[best], bestFitness) {
+			bestFitness, bestVector = pop.Fitness[best], append([]float64(nil), pop.Vectors[best]...)
+			if gen != 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+			} else {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+			}
+		}
+	}
+
+	if evaluations != 0 {
+		// Run DE for N function evaluations
+		for evals < evaluations {
+			doGeneration(0)
+		}
+	} else if generations != 0 {
+		// Run DE for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestVector
+}
+
+// InitPopulation creates popSize uniformly random vectors of N variables within the bounds
+func InitPopulation(N int, popSize int, bounds f.Bounds, config Config, r *rand.Rand) *Population {
+	pop := &Population{
+		Vectors: make([][]float64, popSize),
+		Fitness: make([]float64, popSize),
+		MuF:     config.F,
+		MuCR:    config.CR,
+	}
+	for i := 0; i < popSize; i++ {
+		pop.Vectors[i] = make([]float64, N)
+		for n := 0; n < N; n++ {
+			pop.Vectors[i][n] = bounds.Min + r.Float64()*bounds.Width()
+		}
+	}
+	return pop
+}
+
+// EvalFitness evaluates the fitness of every vector
+// Return number of fitness evaluations
+func (pop *Population) EvalFitness(fitness f.RealFitness) int {
+	for i := 0; i < len(pop.Vectors); i++ {
+		pop.Fitness[i] = fitness(pop.Vectors[i])
+	}
+	return len(pop.Vectors)
+}
+
+// Best finds the index of the vector with the best fitness in the direction
+func (pop *Population) Best(direction f.Direction) int {
+	best := 0
+	for i := 1; i < len(pop.Fitness); i++ {
+		if direction.Better(pop.Fitness[i], pop.Fitness[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Generation performs one generation of DE, varying only the variables in dims. Each trial vector replaces its
+// target vector if it is at least as fit. With config.Reevaluate the best vector is re-evaluated first.
+// Return number of fitness evaluations
+func (pop *Population) Generation(dims []int, fitness f.RealFitness, direction f.Direction, bounds f.Bounds, config Config, r *rand.Rand) int {
+	popSize := len(pop.Vectors)
+	var evals int
+	if config.Reevaluate {
+		// The best vector's fitness may be a lucky observation of a noisy function, which would otherwise keep it forever
+		best := pop.Best(direction)
+		pop.Fitness[best] = fitness(pop.Vectors[best])
+		evals++
+	}
+	best := pop.Best(direction)
+	pBest := pop.pBest(config.P, direction)
+
+	var successF, successCR []float64
+	trials := make([][]float64, popSize)
+	trialF := make([]float64, popSize)
+	trialCR := make([]float64, popSize)
+
+	for i := 0; i < popSize; i++ {
+		F, CR := config.F, config.CR
+		if config.Strategy == JADE {
+			F, CR = pop.sampleParameters(r)
+		}
+		trialF[i], trialCR[i] = F, CR
+
+		// Pick distinct random vectors, other than the target vector
+		r1, r2, r3 := pickDistinct(popSize, i, r)
+		x := pop.Vectors
+
+		mutant := make([]float64, len(x[i]))
+		for _, d := range dims {
+			switch config.Strategy {
+			case Rand1Bin:
+				mutant[d] = x[r1][d] + F*(x[r2][d]-x[r3][d])
+			case Best1Bin:
+				mutant[d] = x[best][d] + F*(x[r1][d]-x[r2][d])
+			case CurrentToBest1Bin:
+				mutant[d] = x[i][d] + F*(x[best][d]-x[i][d]) + F*(x[r1][d]-x[r2][d])
+			case JADE:
+				mutant[d] = x[i][d] + F*(x[pBest[r.Intn(len(pBest))]][d]-x[i][d]) + F*(x[r1][d]-pop.archiveOrPopulation(r2, r)[d])
+			}
+		}
+
+		// Binomial crossover, taking at least one variable from the mutant
+		trial := append([]float64(nil), x[i]...)
+		jRand := dims[r.Intn(len(dims))]
+		for _, d := range dims {
+			if d == jRand || r.Float64() < CR {
+				trial[d] = bounds.Repair(mutant[d], r)
+			}
+		}
+		trials[i] = trial
+	}
+
+	// Selection, after every trial vector is made
+	for i := 0; i < popSize; i++ {
+		trialFitness := fitness(trials[i])
+		if !direction.Better(pop.Fitness[i], trialFitness) {
+			if config.Strategy == JADE && direction.Better(trialFitness, pop.Fitness[i]) {
+				pop.Archive = append(pop.Archive, pop.Vectors[i])
+				successF = append(successF, trialF[i])
+				successCR = append(successCR, trialCR[i])
+			}
+			pop.Vectors[i], pop.Fitness[i] = trials[i], trialFitness
+		}
+	}
+
+	if config.Strategy == JADE {
+		pop.adapt(successF, successCR, config.C, r)
+	}
+	return evals + popSize
+}
+
+// sampleParameters samples F from Cauchy(MuF, 0.1) and CR from Normal(MuCR, 0.1) for JADE
+func (pop *Population) sampleParameters(r *rand.Rand) (float64, float64) {
+	F := 0.0
+	for F <= 0 {
+		F = pop.MuF + 0.1*math.Tan(math.Pi*(r.Float64()-0.5))
+	}
+	F = math.Min(F, 1)
+	CR := math.Max(0, math.Min(1, pop.MuCR+0.1*r.NormFloat64()))
+	return F, CR
+}
+
+// adapt updates JADE's means of F and CR from the parameters of successful trial vectors, and trims the archive
+func (pop *Population) adapt(successF []float64, successCR []float64, c float64, r *rand.Rand) {
+	if len(successF) > 0 {
+		// Arithmetic mean of CR, Lehmer mean of F
+		var sumCR, sumF, sumF2 float64
+		for i := 0; i < len(successF); i++ {
+			sumCR += successCR[i]
+			sumF += successF[i]
+			sumF2 += successF[i] * successF[i]
+		}
+		pop.MuCR = (1-c)*pop.MuCR + c*sumCR/float64(len(successCR))
+		pop.MuF = (1-c)*pop.MuF + c*sumF2/sumF
+	}
+
+	// Randomly remove vectors from the archive until it is no larger than the population
+	for len(pop.Archive) > len(pop.Vectors) {
+		i := r.Intn(len(pop.Archive))
+		pop.Archive[i] = pop.Archive[len(pop.Archive)-1]
+		pop.Archive = pop.Archive[:len(pop.Archive)-1]
+	}
+}
+
+// archiveOrPopulation picks a vector from the union of the population and archive for JADE. r2 is a random
+// population index, which is replaced by an archived vector in proportion to the size of the archive.
+func (pop *Population) archiveOrPopulation(r2 int, r *rand.Rand) []float64 {
+	i := r.Intn(len(pop.Vectors) + len(pop.Archive))
+	if i < len(pop.Vectors) {
+		return pop.Vectors[r2]
+	}
+	return pop.Archive[i-len(pop.Vectors)]
+}
+
+// pBest gets the indexes of the fittest p fraction of the population, at least one
+func (pop *Population) pBest(p float64, direction f.Direction) []int {
+	n := int(math.Max(1, math.Round(p*float64(len(pop.Vectors)))))
+	indexes := make([]int, len(pop.Vectors))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	// Partial selection sort is enough for the top n
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < len(indexes); j++ {
+			if direction.Better(pop.Fitness[indexes[j]], pop.Fitness[indexes[i]]) {
+				indexes[i], indexes[j] = indexes[j], indexes[i]
+			}
+		}
+	}
+	return indexes[:n]
+}
+
+// pickDistinct picks 3 distinct indexes below n that are not the target index. With populations smaller than 4 the
+// indexes may repeat.
+func pickDistinct(n int, target int, r *rand.Rand) (int, int, int) {
+	if n < 4 {
+		return r.Intn(n), r.Intn(n), r.Intn(n)
+	}
+	picked := []int{target}
+	for len(picked) < 4 {
+		i := r.Intn(n)
+		if !contains(picked, i) {
+			picked = append(picked, i)
+		}
+	}
+	return picked[1], picked[2], picked[3]
+}
+
+// allDims gets the index of every variable of an N variable vector
+func allDims(N int) []int {
+	dims := make([]int, N)
+	for i := 0; i < N; i++ {
+		dims[i] = i
+	}
+	return dims
+}
+
+func contains(slice []int, value int) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package de
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"time"
+)
+
+// DECCConfig configures DE with cooperative coevolution
+type DECCConfig struct {
+	DE        Config // Subcomponent optimiser
+	GroupSize int    // Number of variables in each subcomponent
+	GroupGens int    // DE generations spent on each subcomponent per cycle
+	Random    bool   // Randomly regroup the variables every cycle (DECC-G random grouping, DOI 10.1016/j.ins.2008.02.017)
+}
+
+// RunDECC runs DE as the subcomponent optimiser of cooperative coevolution. The variables are split into groups, and
+// each group is optimised in turn with the others fixed to the context vector, the best solution found so far.
+// A generation is one cycle through every group.
+func RunDECC(evaluations int, generations int, popSize int, N int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config DECCConfig) ([]chart.BestFitness, float64, []float64) {
+	var evals int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise the population, vectors keep a value for every variable but each group only varies its own
+	pop := InitPopulation(N, popSize, bounds, config.DE, r)
+	evals += pop.EvalFitness(function)
+	best := pop.Best(direction)
+	bestFitness := pop.Fitness[best]
+	context := append([]float64(nil), pop.Vectors[best]...)
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	groups := common.Groups(N, config.GroupSize, false, r)
+
+	doCycle := func(gen int) {
+		if config.Random {
+			groups = common.Groups(N, config.GroupSize, true, r)
+		}
+
+		for _, group := range groups {
+			if evaluations != 0 && evals >= evaluations {
+				return
+			}
+
+			// Evaluate the group's variables of each vector in collaboration with the context vector
+			inContext := func(x []float64) float64 {
+				candidate := append([]float64(nil), context...)
+				for _, d := range group {
+					candidate[d] = x[d]
+				}
+				return function(candidate)
+			}
+
+			// Fitness from the last group is stale as the context has changed since
+			evals += pop.EvalFitness(inContext)
+			for g := 0; g < config.GroupGens && (evaluations == 0 || evals < evaluations); g++ {
+				evals += pop.Generation(group, inContext, direction, bounds, config.DE, r)
+			}
+
+			// Update context vector with the group's best variables
+			best := pop.Best(direction)
+			if direction.Better(pop.Fitness[best], bestFitness) {
+				bestFitness = pop.Fitness[best]
+				for _, d := range group {
+					context[d] = pop.Vectors[best][d]
+				}
+				if gen != 0 {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+				} else {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+				}
+			}
+		}
+	}
+
+	if evaluations != 0 {
+		// Run DECC for N function evaluations
+		for evals < evaluations {
+			doCycle(0)
+		}
+	} else if generations != 0 {
+		// Run DECC for N generations
+		for gen := 0; gen < generations; gen++ {
+			doCycle(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, context
+}
@@ -0,0 +1,36 @@
+package de
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRunDECC(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	config := DECCConfig{DE: DefaultConfig(JADE), GroupSize: 5, GroupGens: 5, Random: true}
+	history, best, x := RunDECC(5000, 0, 20, f.RastriginN, f.RastriginReal, f.Minimise, bounds, config)
+	assert.Equal(t, f.RastriginN, len(x), "Context vector should have N variables")
+	assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match context vector")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+	}
+
+	history, _, _ = RunDECC(0, 3, 20, f.RastriginN, f.RastriginReal, f.Minimise, bounds, config)
+	assert.LessOrEqual(t, history[len(history)-1].X, 2, "Generations should be counted as cycles through groups")
+}
+
+// TestRunDECC_Budget ensures a cycle through the groups stops once the evaluation budget is spent
+func TestRunDECC_Budget(t *testing.T) {
+	var calls int
+	function := func(x []float64) float64 {
+		calls++
+		return f.RastriginReal(x)
+	}
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	config := DECCConfig{DE: DefaultConfig(Rand1Bin), GroupSize: 5, GroupGens: 5, Random: true}
+	RunDECC(500, 0, 20, f.RastriginN, function, f.Minimise, bounds, config)
+
+	// The budget, then at most one more generation of the population
+	assert.LessOrEqual(t, calls, 500+20, "DECC should stop mid-cycle once the evaluation budget is spent")
+}
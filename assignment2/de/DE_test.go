@@ -0,0 +1,145 @@
+package de
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// sphere is minimised at the origin
+func sphere(x []float64) float64 {
+	var sum float64
+	for _, v := range x {
+		sum += v * v
+	}
+	return sum
+}
+
+func TestGetStrategy(t *testing.T) {
+	strategy, err := GetStrategy("jade")
+	assert.Nil(t, err, "GetStrategy should find jade")
+	assert.Equal(t, JADE, strategy, "GetStrategy returned wrong strategy")
+
+	_, err = GetStrategy("invalid")
+	assert.NotNil(t, err, "GetStrategy should error for unknown strategy")
+}
+
+func TestInitPopulation(t *testing.T) {
+	bounds := f.Bounds{Min: -5, Max: 5}
+	pop := InitPopulation(10, 20, bounds, DefaultConfig(JADE), rand.New(rand.NewSource(0)))
+	assert.Equal(t, 20, len(pop.Vectors), "Population should have popSize vectors")
+	assert.Equal(t, 0.5, pop.MuF, "JADE's mean of F should start at config's F")
+	for _, x := range pop.Vectors {
+		assert.Equal(t, 10, len(x), "Vectors should have N variables")
+		for _, v := range x {
+			assert.True(t, v >= -5 && v <= 5, "Variables should be initialised within bounds")
+		}
+	}
+}
+
+func TestPopulation_Generation(t *testing.T) {
+	bounds := f.Bounds{Min: -5, Max: 5, Policy: f.Clamp}
+	for _, strategy := range []Strategy{Rand1Bin, Best1Bin, CurrentToBest1Bin, JADE} {
+		r := rand.New(rand.NewSource(0))
+		config := DefaultConfig(strategy)
+		pop := InitPopulation(5, 20, bounds, config, r)
+		pop.EvalFitness(sphere)
+		initial := pop.Fitness[pop.Best(f.Minimise)]
+
+		for gen := 0; gen < 100; gen++ {
+			evals := pop.Generation(allDims(5), sphere, f.Minimise, bounds, config, r)
+			assert.Equal(t, 20, evals, "Generation should evaluate each trial vector once")
+		}
+		for i, x := range pop.Vectors {
+			assert.Equal(t, sphere(x), pop.Fitness[i], "Fitness should match vector")
+			for _, v := range x {
+				assert.True(t, v >= -5 && v <= 5, "Variables should stay within bounds")
+			}
+		}
+		assert.Less(t, pop.Fitness[pop.Best(f.Minimise)], initial, "DE should improve on the initial population")
+		assert.Less(t, pop.Fitness[pop.Best(f.Minimise)], 0.01, "DE should approach the optimum of the sphere function")
+		assert.LessOrEqual(t, len(pop.Archive), 20, "Archive should be no larger than the population")
+	}
+}
+
+func TestPopulation_Generation_Reevaluate(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -5, Max: 5, Policy: f.Clamp}
+	config := DefaultConfig(Rand1Bin)
+	config.Reevaluate = true
+	pop := InitPopulation(5, 20, bounds, config, r)
+	pop.EvalFitness(sphere)
+	// A lucky evaluation of the best vector is corrected when re-evaluated
+	best := pop.Best(f.Minimise)
+	pop.Fitness[best] = -100
+
+	evals := pop.Generation(allDims(5), sphere, f.Minimise, bounds, config, r)
+	assert.Equal(t, 21, evals, "Generation should evaluate each trial vector and re-evaluate the best vector")
+	for i, x := range pop.Vectors {
+		assert.Equal(t, sphere(x), pop.Fitness[i], "Fitness should match vector")
+	}
+}
+
+// TestPopulation_Generation_Dims ensures variables not being optimised are left unchanged
+func TestPopulation_Generation_Dims(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -5, Max: 5}
+	pop := InitPopulation(4, 10, bounds, DefaultConfig(Rand1Bin), r)
+	pop.EvalFitness(sphere)
+	fixed := make([]float64, 10)
+	for i, x := range pop.Vectors {
+		fixed[i] = x[3]
+	}
+
+	for gen := 0; gen < 10; gen++ {
+		pop.Generation([]int{0, 1, 2}, sphere, f.Minimise, bounds, DefaultConfig(Rand1Bin), r)
+	}
+	for i, x := range pop.Vectors {
+		assert.Contains(t, fixed, x[3], "Variable 3 should only move with its whole vector")
+		assert.Equal(t, sphere(x), pop.Fitness[i], "Fitness should match vector")
+	}
+}
+
+func TestPopulation_pBest(t *testing.T) {
+	pop := Population{Vectors: make([][]float64, 6), Fitness: []float64{5, 1, 4, 0, 3, 2}}
+	assert.Equal(t, []int{3, 1}, pop.pBest(0.3, f.Minimise), "pBest should get the fittest indexes in order")
+	assert.Equal(t, []int{3}, pop.pBest(0, f.Minimise), "pBest should get at least one index")
+}
+
+func TestPopulation_adapt(t *testing.T) {
+	pop := Population{Vectors: make([][]float64, 2), MuF: 0.5, MuCR: 0.5, Archive: make([][]float64, 5)}
+	pop.adapt([]float64{0.2, 0.6}, []float64{0.8, 0.8}, 0.5, rand.New(rand.NewSource(0)))
+	assert.InDelta(t, 0.5*0.5+0.5*0.4/0.8, pop.MuF, 0.0001, "MuF should move towards Lehmer mean of successful F")
+	assert.InDelta(t, 0.65, pop.MuCR, 0.0001, "MuCR should move towards mean of successful CR")
+	assert.Equal(t, 2, len(pop.Archive), "Archive should be trimmed to population size")
+}
+
+func TestPickDistinct(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		r1, r2, r3 := pickDistinct(5, 2, r)
+		assert.ElementsMatch(t, []int{r1, r2, r3, 2}, unique([]int{r1, r2, r3, 2}), "Indexes should be distinct")
+	}
+}
+
+func TestRun(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	history, best, x := Run(2000, 0, 20, f.RastriginN, f.RastriginReal, f.Minimise, bounds, DefaultConfig(Rand1Bin))
+	assert.Equal(t, f.RastriginN, len(x), "Best solution should have N variables")
+	assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match best solution")
+	assert.Equal(t, best, history[len(history)-1].Fitness, "Last entry of history should be best fitness")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+	}
+}
+
+func unique(values []int) []int {
+	var u []int
+	for _, v := range values {
+		if !contains(u, v) {
+			u = append(u, v)
+		}
+	}
+	return u
+}
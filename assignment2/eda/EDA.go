@@ -0,0 +1,227 @@
+package eda
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Model is a probability vector over the bits of the 16-bit encoding, holding the probability each bit is set. Bit b
+// of variable v is at index v*16 + b.
+type Model []float64
+
+// PBILConfig configures population-based incremental learning, as per Baluja (CMU-CS-94-163)
+type PBILConfig struct {
+	LearningRate  float64 // Rate the model moves towards the best sample
+	NegativeRate  float64 // Extra rate the model moves away from the worst sample, where it differs from the best
+	MutationP     float64 // Probability each probability is mutated
+	MutationShift float64 // Rate a mutated probability moves towards a random bit
+}
+
+// DefaultPBILConfig returns Baluja's PBIL parameters
+func DefaultPBILConfig() PBILConfig {
+	return PBILConfig{LearningRate: 0.1, NegativeRate: 0.075, MutationP: 0.02, MutationShift: 0.05}
+}
+
+// NewModel creates a model of N variables where every bit is equally likely to be set or not
+func NewModel(N int) Model {
+	m := make(Model, N*16)
+	for i := range m {
+		m[i] = 0.5
+	}
+	return m
+}
+
+// Sample samples genes from the model
+func (m Model) Sample(r *rand.Rand) []uint16 {
+	genes := make([]uint16, len(m)/16)
+	for i, p := range m {
+		if r.Float64() < p {
+			genes[i/16] |= 1 << uint(i%16)
+		}
+	}
+	return genes
+}
+
+// Learn moves each probability towards the matching bit of the genes at the given rate
+func (m Model) Learn(genes []uint16, rate float64) {
+	for i := range m {
+		m[i] = (1-rate)*m[i] + rate*bit(genes, i)
+	}
+}
+
+// Clamp keeps every probability within [min, max], so no bit is fixed for good
+func (m Model) Clamp(min float64, max float64) {
+	for i := range m {
+		m[i] = math.Max(min, math.Min(max, m[i]))
+	}
+}
+
+// bit gets the i-th bit of the genes as 0 or 1
+func bit(genes []uint16, i int) float64 {
+	return float64(genes[i/16] >> uint(i%16) & 1)
+}
+
+// sampled holds solutions sampled from a model and their fitness, sorted best first
+type sampled struct {
+	genes     [][]uint16
+	fitness   []float64
+	direction f.Direction
+}
+
+// samplePopulation samples popSize solutions from the model, evaluated and sorted by fitness in the direction
+func samplePopulation(m Model, popSize int, function f.Fitness, direction f.Direction, r *rand.Rand) sampled {
+	s := sampled{genes: make([][]uint16, popSize), fitness: make([]float64, popSize), direction: direction}
+	for i := 0; i < popSize; i++ {
+		s.genes[i] = m.Sample(r)
+		s.fitness[i] = function(s.genes[i])
+	}
+	sort.Sort(s)
+	return s
+}
+
+func (s sampled) Len() int           { return len(s.genes) }
+func (s sampled) Less(i, j int) bool { return s.direction.Better(s.fitness[i], s.fitness[j]) }
+func (s sampled) Swap(i, j int) {
+	s.genes[i], s.genes[j] = s.genes[j], s.genes[i]
+	s.fitness[i], s.fitness[j] = s.fitness[j], s.fitness[i]
+}
+
+// run repeatedly calls doGeneration until the evaluation or generation limit is reached. doGeneration returns the
+// fitness evaluations it used and its best solution, the best solution found so far is tracked and returned.
+func run(evaluations int, generations int, direction f.Direction, doGeneration func() (int, float64, []uint16)) ([]chart.BestFitness, float64, []uint16) {
+	var evals int
+	bestFitness := direction.Worst()
+	var bestGenes []uint16
+	var bestFitnessHistory []chart.BestFitness
+
+	record := func(gen int) {
+		genEvals, fitness, genes := doGeneration()
+		evals += genEvals
+		if direction.Better(fitness, bestFitness) {
+			bestFitness, bestGenes = fitness, append([]uint16(nil), genes...)
+			if len(bestFitnessHistory) == 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+			} else if gen != 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+			} else {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+			}
+		}
+	}
+
+	if evaluations != 0 {
+		// Run EDA for N function evaluations
+		for evals < evaluations {
+			record(0)
+		}
+	} else if generations != 0 {
+		// Run EDA for N generations
+		for gen := 0; gen < generations; gen++ {
+			record(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenes
+}
+
+// RunPBIL runs population-based incremental learning. Each generation popSize solutions are sampled from the model,
+// which then learns from the best (and away from the worst) and is mutated.
+func RunPBIL(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, config PBILConfig) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	m := NewModel(N)
+	return run(evaluations, generations, direction, func() (int, float64, []uint16) {
+		pop := samplePopulation(m, popSize, function, direction, r)
+		m.LearnPBIL(pop.genes[0], pop.genes[popSize-1], config, r)
+		return popSize, pop.fitness[0], pop.genes[0]
+	})
+}
+
+// LearnPBIL moves the model towards the best genes, further away from the worst genes where they differ from the best,
+// then mutates the model
+func (m Model) LearnPBIL(best []uint16, worst []uint16, config PBILConfig, r *rand.Rand) {
+	for i := range m {
+		rate := config.LearningRate
+		if bit(best, i) != bit(worst, i) {
+			rate += config.NegativeRate
+		}
+		m[i] = (1-rate)*m[i] + rate*bit(best, i)
+
+		if r.Float64() < config.MutationP {
+			m[i] = (1-config.MutationShift)*m[i] + config.MutationShift*float64(r.Intn(2))
+		}
+	}
+}
+
+// RunCGA runs the compact GA of Harik, Lobo & Goldberg (DOI 10.1109/4235.797971), which simulates a GA with a
+// population of popSize using only the model. Each competition samples two solutions and moves the model by 1/popSize
+// towards the winner where they differ. A generation is popSize/2 competitions, popSize evaluations.
+func RunCGA(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	m := NewModel(N)
+	step := 1 / float64(popSize)
+	return run(evaluations, generations, direction, func() (int, float64, []uint16) {
+		bestFitness := direction.Worst()
+		var bestGenes []uint16
+		for c := 0; c < popSize/2; c++ {
+			winner, loser := m.Sample(r), m.Sample(r)
+			winnerFitness, loserFitness := function(winner), function(loser)
+			if direction.Better(loserFitness, winnerFitness) {
+				winner, loser = loser, winner
+				winnerFitness = loserFitness
+			}
+			m.Compete(winner, loser, step)
+			if direction.Better(winnerFitness, bestFitness) {
+				bestFitness, bestGenes = winnerFitness, winner
+			}
+		}
+		return 2 * (popSize / 2), bestFitness, bestGenes
+	})
+}
+
+// Compete moves the model by step towards the winner's bits that differ from the loser's
+func (m Model) Compete(winner []uint16, loser []uint16, step float64) {
+	for i := range m {
+		if w := bit(winner, i); w != bit(loser, i) {
+			if w == 1 {
+				m[i] = math.Min(1, m[i]+step)
+			} else {
+				m[i] = math.Max(0, m[i]-step)
+			}
+		}
+	}
+}
+
+// RunUMDA runs the univariate marginal distribution algorithm. Each generation popSize solutions are sampled, and the
+// model is rebuilt from the frequency of each bit in the best half. Probabilities are kept within [1/16N, 1-1/16N].
+func RunUMDA(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	m := NewModel(N)
+	margin := 1 / float64(16*N)
+	return run(evaluations, generations, direction, func() (int, float64, []uint16) {
+		pop := samplePopulation(m, popSize, function, direction, r)
+		m.Estimate(pop.genes[:int(math.Max(1, float64(popSize/2)))])
+		m.Clamp(margin, 1-margin)
+		return popSize, pop.fitness[0], pop.genes[0]
+	})
+}
+
+// Estimate sets each probability to the frequency of the bit being set among the selected genes
+func (m Model) Estimate(selected [][]uint16) {
+	for i := range m {
+		var sum float64
+		for _, genes := range selected {
+			sum += bit(genes, i)
+		}
+		m[i] = sum / float64(len(selected))
+	}
+}
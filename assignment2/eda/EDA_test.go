@@ -0,0 +1,103 @@
+package eda
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// countOnes is minimised by genes with no set bits
+func countOnes(x []uint16) float64 {
+	var ones float64
+	for _, gene := range x {
+		for b := 0; b < 16; b++ {
+			if gene&(1<<b) != 0 {
+				ones++
+			}
+		}
+	}
+	return ones
+}
+
+func TestModel_Sample(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	m := NewModel(2)
+	for i := range m {
+		m[i] = 0
+	}
+	m[0], m[17] = 1, 1
+	assert.Equal(t, []uint16{1, 2}, m.Sample(r), "Bits with probability 1 should always be set and 0 never set")
+}
+
+func TestModel_Learn(t *testing.T) {
+	m := NewModel(1)
+	m.Learn([]uint16{1}, 0.5)
+	assert.Equal(t, 0.75, m[0], "Set bit should move probability towards 1")
+	assert.Equal(t, 0.25, m[1], "Unset bit should move probability towards 0")
+}
+
+func TestModel_LearnPBIL(t *testing.T) {
+	m := NewModel(1)
+	config := PBILConfig{LearningRate: 0.1, NegativeRate: 0.1}
+	m.LearnPBIL([]uint16{3}, []uint16{1}, config, rand.New(rand.NewSource(0)))
+	assert.InDelta(t, 0.55, m[0], 1e-9, "Bit shared with worst should learn at the learning rate")
+	assert.InDelta(t, 0.6, m[1], 1e-9, "Bit differing from worst should also learn at the negative rate")
+	assert.InDelta(t, 0.45, m[2], 1e-9, "Unset bit should move towards 0")
+}
+
+func TestModel_Compete(t *testing.T) {
+	m := NewModel(1)
+	m.Compete([]uint16{1}, []uint16{2}, 0.1)
+	assert.InDelta(t, 0.6, m[0], 1e-9, "Probability should move towards winner's set bit")
+	assert.InDelta(t, 0.4, m[1], 1e-9, "Probability should move towards winner's unset bit")
+	assert.Equal(t, 0.5, m[2], "Bits the same in winner and loser should not change")
+}
+
+func TestModel_Estimate(t *testing.T) {
+	m := NewModel(1)
+	m.Estimate([][]uint16{{1}, {3}, {0}, {1}})
+	assert.Equal(t, 0.75, m[0], "Probability should be the frequency of the bit")
+	assert.Equal(t, 0.25, m[1], "Probability should be the frequency of the bit")
+	assert.Equal(t, 0.0, m[2], "Probability should be the frequency of the bit")
+
+	m.Clamp(0.1, 0.9)
+	assert.Equal(t, 0.1, m[2], "Clamp should keep probabilities away from 0")
+}
+
+func TestRunEDAs(t *testing.T) {
+	for name, runEDA := range map[string]func() (float64, []uint16){
+		"PBIL": func() (float64, []uint16) {
+			_, best, genes := RunPBIL(20000, 0, 50, 4, countOnes, f.Minimise, DefaultPBILConfig())
+			return best, genes
+		},
+		"cGA": func() (float64, []uint16) {
+			_, best, genes := RunCGA(20000, 0, 50, 4, countOnes, f.Minimise)
+			return best, genes
+		},
+		"UMDA": func() (float64, []uint16) {
+			_, best, genes := RunUMDA(20000, 0, 50, 4, countOnes, f.Minimise)
+			return best, genes
+		},
+	} {
+		best, genes := runEDA()
+		assert.Equal(t, 0.0, best, name+" should solve OneMax")
+		assert.Equal(t, []uint16{0, 0, 0, 0}, genes, name+" should find the optimum")
+	}
+}
+
+func TestRunPBIL(t *testing.T) {
+	history, best, genes := RunPBIL(0, 50, 50, f.RastriginN, f.Rastrigin, f.Minimise, DefaultPBILConfig())
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+	assert.Equal(t, 0, history[0].X, "First entry of history should be at the start")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		assert.Less(t, history[i].X, 50, "Generations should not exceed the limit")
+	}
+}
+
+func TestRunCGA_Maximise(t *testing.T) {
+	_, best, genes := RunCGA(20000, 0, 50, 4, countOnes, f.Maximise)
+	assert.Equal(t, 64.0, best, "cGA should maximise OneMax")
+	assert.Equal(t, []uint16{0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF}, genes, "cGA should set every bit when maximising")
+}
@@ -0,0 +1,51 @@
+package eda
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"time"
+)
+
+// RunCooperativePBIL runs PBIL with cooperative coevolution. Like CCGA-1 each variable is its own species, but each
+// species is a 16-bit probability vector rather than a population. Genes sampled from a species are evaluated in
+// collaboration with the context vector, which holds the best gene found for every species. A generation is one cycle
+// through every species, popSize evaluations per species.
+func RunCooperativePBIL(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, config PBILConfig) ([]chart.BestFitness, float64, []uint16) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	species := make([]Model, N)
+	context := make([]uint16, N)
+	for v := 0; v < N; v++ {
+		species[v] = NewModel(1)
+		context[v] = species[v].Sample(r)[0]
+	}
+	bestFitness := function(context)
+
+	first := true
+	return run(evaluations, generations, direction, func() (int, float64, []uint16) {
+		evals := 0
+		if first {
+			// Count the evaluation of the initial context vector
+			evals, first = 1, false
+		}
+		for v := 0; v < N; v++ {
+			inContext := func(genes []uint16) float64 {
+				candidate := append([]uint16(nil), context...)
+				candidate[v] = genes[0]
+				return function(candidate)
+			}
+			pop := samplePopulation(species[v], popSize, inContext, direction, r)
+			evals += popSize
+			species[v].LearnPBIL(pop.genes[0], pop.genes[popSize-1], config, r)
+
+			// Update context vector with the species' best gene, if it improves on the context
+			if direction.Better(pop.fitness[0], bestFitness) {
+				bestFitness = pop.fitness[0]
+				context[v] = pop.genes[0][0]
+			}
+		}
+		return evals, bestFitness, context
+	})
+}
@@ -0,0 +1,19 @@
+package eda
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRunCooperativePBIL(t *testing.T) {
+	_, best, genes := RunCooperativePBIL(20000, 0, 20, 4, countOnes, f.Minimise, DefaultPBILConfig())
+	assert.Equal(t, 0.0, best, "Cooperative PBIL should solve OneMax")
+	assert.Equal(t, []uint16{0, 0, 0, 0}, genes, "Context vector should be the optimum")
+
+	history, best, genes := RunCooperativePBIL(5000, 0, 20, f.RastriginN, f.Rastrigin, f.Minimise, DefaultPBILConfig())
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match context vector")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+	}
+}
@@ -0,0 +1,291 @@
+package es
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Selection sets which individuals the next generation's parents are selected from
+type Selection int
+
+const (
+	Comma Selection = iota // (mu/rho,lambda): the best mu of the lambda offspring, parents always die
+	Plus                   // (mu/rho+lambda): the best mu of the parents and offspring together, an elitist strategy
+)
+
+// StepSizes sets how many mutation step sizes each individual self-adapts
+type StepSizes int
+
+const (
+	Single        StepSizes = iota // One step size shared by every variable, isotropic mutation
+	PerCoordinate                  // One step size per variable, axis-parallel mutation ellipsoids
+)
+
+// Config configures an evolution strategy as per Beyer & Schwefel (DOI 10.1023/A:1015059928466)
+type Config struct {
+	Mu        int // Parents
+	Rho       int // Parents recombined to create each offspring
+	Lambda    int // Offspring per generation
+	Selection Selection
+	StepSizes StepSizes
+	Sigma0    float64 // Initial step size, as a fraction of the width of the bounds
+
+	Violation f.RealFitness // Total violation of the constraints, stochastically ranking individuals if set (constrained functions only)
+	RankingP  float64       // Probability of ranking individuals that are not both feasible by fitness rather than violation
+
+	Reevaluate bool // Re-evaluate the parents competing with the offspring of Plus selection each generation (noisy functions)
+}
+
+// DefaultConfig returns a config creating lambda offspring from lambda/7 parents with intermediate recombination of
+// every parent
+func DefaultConfig(lambda int, selection Selection, stepSizes StepSizes) Config {
+	mu := int(math.Max(1, float64(lambda/7)))
+	return Config{Mu: mu, Rho: mu, Lambda: lambda, Selection: selection, StepSizes: stepSizes, Sigma0: 0.3}
+}
+
+// Name gets the name of the strategy in (mu/rho,lambda)-ES notation
+func (c Config) Name() string {
+	sep := ","
+	if c.Selection == Plus {
+		sep = "+"
+	}
+	return fmt.Sprintf("(%d/%d%s%d)-ES", c.Mu, c.Rho, sep, c.Lambda)
+}
+
+// GetSelection gets a Selection by name
+func GetSelection(name string) (Selection, error) {
+	switch name {
+	case "comma":
+		return Comma, nil
+	case "plus":
+		return Plus, nil
+	}
+
+	return Comma, errors.New("invalid selection passed to GetSelection")
+}
+
+// GetStepSizes gets a StepSizes by name
+func GetStepSizes(name string) (StepSizes, error) {
+	switch name {
+	case "single":
+		return Single, nil
+	case "coordinate":
+		return PerCoordinate, nil
+	}
+
+	return Single, errors.New("invalid step sizes passed to GetStepSizes")
+}
+
+// Individual holds an ES individual's decoded variables along with its self-adapted step sizes
+type Individual struct {
+	X         []float64
+	Sigma     []float64 // Step sizes, either one or one per variable
+	Fitness   float64
+	Violation float64 // Total violation of the constraints, 0 if feasible or unconstrained
+}
+
+// Population keeps the individuals of an ES
+type Population []Individual
+
+// Run runs the evolution strategy on the function's decoded variables. With config.Violation set individuals are
+// stochastically ranked as in Runarsson & Yao's SRES (DOI 10.1109/4235.873238), and the best solution is the best by
+// Deb's feasibility rules.
+func Run(evaluations int, generations int, N int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config Config) ([]chart.BestFitness, float64, []float64) {
+	var evals int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise parents
+	parents := InitPopulation(N, config, bounds, r)
+	evals += parents.EvalFitness(function)
+	parents.EvalViolation(config.Violation)
+	parents.Sort(direction)
+	best := parents[parents.Best(direction)]
+	bestFitness, bestViolation, bestX := best.Fitness, best.Violation, best.X
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	doGeneration := func(gen int) {
+		if config.Reevaluate && config.Selection == Plus {
+			// A parent's fitness may be a lucky observation of a noisy function, which would otherwise keep it forever
+			evals += parents.EvalFitness(function)
+		}
+		offspring := parents.Offspring(config, bounds, r)
+		evals += offspring.EvalFitness(function)
+		offspring.EvalViolation(config.Violation)
+		parents = parents.Select(offspring, config, direction, r)
+		best := parents[parents.Best(direction)]
+		if f.FeasibilityBetter(direction, best.Fitness, best.Violation, bestFitness, bestViolation) {
+			bestFitness, bestViolation, bestX = best.Fitness, best.Violation, best.X
+			if gen != 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+			} else {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+			}
+		}
+	}
+
+	if evaluations != 0 {
+		// Run ES for N function evaluations
+		for evals < evaluations {
+			doGeneration(0)
+		}
+	} else if generations != 0 {
+		// Run ES for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestX
+}
+
+// InitPopulation creates Mu parents at uniformly random points within the bounds, with the initial step sizes
+func InitPopulation(N int, config Config, bounds f.Bounds, r *rand.Rand) Population {
+	sigmas := 1
+	if config.StepSizes == PerCoordinate {
+		sigmas = N
+	}
+
+	pop := make(Population, config.Mu)
+	for i := 0; i < config.Mu; i++ {
+		pop[i] = Individual{X: make([]float64, N), Sigma: make([]float64, sigmas)}
+		for n := 0; n < N; n++ {
+			pop[i].X[n] = bounds.Min + r.Float64()*bounds.Width()
+		}
+		for n := 0; n < sigmas; n++ {
+			pop[i].Sigma[n] = config.Sigma0 * bounds.Width()
+		}
+	}
+	return pop
+}
+
+// EvalFitness evaluates the fitness of every individual
+// Return number of fitness evaluations
+func (pop Population) EvalFitness(fitness f.RealFitness) int {
+	for i := 0; i < len(pop); i++ {
+		pop[i].Fitness = fitness(pop[i].X)
+	}
+	return len(pop)
+}
+
+// EvalViolation evaluates the total violation of the constraints by every individual, if constrained
+func (pop Population) EvalViolation(violation f.RealFitness) {
+	if violation == nil {
+		return
+	}
+	for i := 0; i < len(pop); i++ {
+		pop[i].Violation = violation(pop[i].X)
+	}
+}
+
+// Sort sorts the population by fitness in the direction, the best individual first
+func (pop Population) Sort(direction f.Direction) {
+	sort.SliceStable(pop, func(i, j int) bool { return direction.Better(pop[i].Fitness, pop[j].Fitness) })
+}
+
+// Rank orders the population by stochastic ranking of their fitness and violation
+func (pop Population) Rank(rankingP float64, direction f.Direction, r *rand.Rand) {
+	fitness, violation := make([]float64, len(pop)), make([]float64, len(pop))
+	for i := range pop {
+		fitness[i], violation[i] = pop[i].Fitness, pop[i].Violation
+	}
+	ranked := make(Population, len(pop))
+	for i, index := range f.StochasticRank(fitness, violation, rankingP, direction, r) {
+		ranked[i] = pop[index]
+	}
+	copy(pop, ranked)
+}
+
+// Best gets the index of the best individual by Deb's feasibility rules, which is the fittest when unconstrained
+func (pop Population) Best(direction f.Direction) int {
+	best := 0
+	for i := 1; i < len(pop); i++ {
+		if f.FeasibilityBetter(direction, pop[i].Fitness, pop[i].Violation, pop[best].Fitness, pop[best].Violation) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Offspring creates Lambda offspring, each by intermediate recombination of Rho random parents followed by
+// self-adaptive mutation
+func (pop Population) Offspring(config Config, bounds f.Bounds, r *rand.Rand) Population {
+	offspring := make(Population, config.Lambda)
+	for k := 0; k < config.Lambda; k++ {
+		offspring[k] = pop.Recombine(config.Rho, r)
+		offspring[k].Mutate(bounds, r)
+	}
+	return offspring
+}
+
+// Recombine creates an individual at the centroid of rho randomly picked parents, with the mean of their step sizes
+func (pop Population) Recombine(rho int, r *rand.Rand) Individual {
+	if rho > len(pop) {
+		rho = len(pop)
+	}
+	child := Individual{X: make([]float64, len(pop[0].X)), Sigma: make([]float64, len(pop[0].Sigma))}
+	for _, p := range r.Perm(len(pop))[:rho] {
+		for n := range child.X {
+			child.X[n] += pop[p].X[n] / float64(rho)
+		}
+		for n := range child.Sigma {
+			child.Sigma[n] += pop[p].Sigma[n] / float64(rho)
+		}
+	}
+	return child
+}
+
+// Mutate self-adapts the step sizes with log-normal mutation, then mutates the variables by normally distributed
+// offsets scaled by the new step sizes. Variables leaving the bounds are repaired.
+func (ind *Individual) Mutate(bounds f.Bounds, r *rand.Rand) {
+	N := float64(len(ind.X))
+	minSigma := 1e-12 * bounds.Width()
+
+	if len(ind.Sigma) == 1 {
+		tau := 1 / math.Sqrt(N)
+		ind.Sigma[0] = math.Max(minSigma, ind.Sigma[0]*math.Exp(tau*r.NormFloat64()))
+	} else {
+		// Global learning rate shared by every coordinate, and a coordinate-wise learning rate
+		tauGlobal, tauLocal := 1/math.Sqrt(2*N), 1/math.Sqrt(2*math.Sqrt(N))
+		global := tauGlobal * r.NormFloat64()
+		for n := range ind.Sigma {
+			ind.Sigma[n] = math.Max(minSigma, ind.Sigma[n]*math.Exp(global+tauLocal*r.NormFloat64()))
+		}
+	}
+
+	for n := range ind.X {
+		sigma := ind.Sigma[0]
+		if len(ind.Sigma) > 1 {
+			sigma = ind.Sigma[n]
+		}
+		ind.X[n] = bounds.Repair(ind.X[n]+sigma*r.NormFloat64(), r)
+	}
+}
+
+// Select selects the next generation's Mu parents, sorted by fitness (or stochastically ranked if constrained), from
+// the offspring (Comma) or the parents and offspring together (Plus)
+func (pop Population) Select(offspring Population, config Config, direction f.Direction, r *rand.Rand) Population {
+	pool := append(Population(nil), offspring...)
+	if config.Selection == Plus {
+		pool = append(pool, pop...)
+	}
+	if config.Violation != nil {
+		pool.Rank(config.RankingP, direction, r)
+	} else {
+		pool.Sort(direction)
+	}
+
+	mu := config.Mu
+	if mu > len(pool) {
+		mu = len(pool)
+	}
+	return pool[:mu]
+}
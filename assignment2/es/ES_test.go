@@ -0,0 +1,118 @@
+package es
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGetSelection(t *testing.T) {
+	selection, err := GetSelection("plus")
+	assert.Nil(t, err, "GetSelection should find plus")
+	assert.Equal(t, Plus, selection, "GetSelection returned wrong selection")
+
+	_, err = GetSelection("invalid")
+	assert.NotNil(t, err, "GetSelection should error for unknown selection")
+}
+
+func TestGetStepSizes(t *testing.T) {
+	stepSizes, err := GetStepSizes("coordinate")
+	assert.Nil(t, err, "GetStepSizes should find coordinate")
+	assert.Equal(t, PerCoordinate, stepSizes, "GetStepSizes returned wrong step sizes")
+
+	_, err = GetStepSizes("invalid")
+	assert.NotNil(t, err, "GetStepSizes should error for unknown step sizes")
+}
+
+func TestConfig_Name(t *testing.T) {
+	assert.Equal(t, "(14/14,100)-ES", DefaultConfig(100, Comma, Single).Name(), "Name should use comma notation")
+	assert.Equal(t, "(1/1+7)-ES", DefaultConfig(7, Plus, Single).Name(), "Name should use plus notation")
+}
+
+func TestInitPopulation(t *testing.T) {
+	bounds := f.Bounds{Min: -5, Max: 5}
+	pop := InitPopulation(10, DefaultConfig(70, Comma, PerCoordinate), bounds, rand.New(rand.NewSource(0)))
+	assert.Equal(t, 10, len(pop), "Population should have mu parents")
+	assert.Equal(t, 10, len(pop[0].Sigma), "Per-coordinate step sizes should have one per variable")
+	assert.Equal(t, 3.0, pop[0].Sigma[0], "Step sizes should start at Sigma0 of the bounds width")
+
+	pop = InitPopulation(10, DefaultConfig(70, Comma, Single), bounds, rand.New(rand.NewSource(0)))
+	assert.Equal(t, 1, len(pop[0].Sigma), "Single step size should have one step size")
+}
+
+func TestPopulation_Recombine(t *testing.T) {
+	pop := Population{
+		{X: []float64{0, 2}, Sigma: []float64{1}},
+		{X: []float64{2, 4}, Sigma: []float64{3}},
+	}
+	child := pop.Recombine(2, rand.New(rand.NewSource(0)))
+	assert.Equal(t, []float64{1, 3}, child.X, "Child should be at the centroid of the parents")
+	assert.Equal(t, []float64{2}, child.Sigma, "Child should have the mean step size of the parents")
+	assert.Equal(t, []float64{0, 2}, pop[0].X, "Parents should not be modified")
+}
+
+func TestIndividual_Mutate(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -1, Max: 1, Policy: f.Reflect}
+	ind := Individual{X: []float64{0, 0, 0}, Sigma: []float64{0.5, 0.5, 0.5}}
+	for i := 0; i < 100; i++ {
+		ind.Mutate(bounds, r)
+		for _, x := range ind.X {
+			assert.True(t, x >= -1 && x <= 1, "Mutated variables should be repaired within bounds")
+		}
+	}
+	assert.NotEqual(t, ind.Sigma[0], ind.Sigma[1], "Per-coordinate step sizes should adapt separately")
+}
+
+func TestPopulation_Select(t *testing.T) {
+	parents := Population{{Fitness: 1}, {Fitness: 5}}
+	offspring := Population{{Fitness: 3}, {Fitness: 2}, {Fitness: 4}}
+
+	comma := parents.Select(offspring, Config{Mu: 2, Selection: Comma}, f.Minimise, nil)
+	assert.Equal(t, Population{{Fitness: 2}, {Fitness: 3}}, comma, "Comma selection should only select offspring")
+
+	plus := parents.Select(offspring, Config{Mu: 2, Selection: Plus}, f.Minimise, nil)
+	assert.Equal(t, Population{{Fitness: 1}, {Fitness: 2}}, plus, "Plus selection should keep the best parents")
+}
+
+func TestPopulation_Select_Ranked(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	parents := Population{{Fitness: 1, Violation: 3}}
+	offspring := Population{{Fitness: 0, Violation: 2}, {Fitness: 5}, {Fitness: 4}}
+
+	ranked := parents.Select(offspring, Config{Mu: 2, Selection: Plus, Violation: f.RastriginReal, RankingP: 0}, f.Minimise, r)
+	assert.Equal(t, Population{{Fitness: 4}, {Fitness: 5}}, ranked, "Ranking by violation alone should select the feasible individuals")
+	ranked = parents.Select(offspring, Config{Mu: 2, Selection: Plus, Violation: f.RastriginReal, RankingP: 1}, f.Minimise, r)
+	assert.Equal(t, Population{{Fitness: 0, Violation: 2}, {Fitness: 1, Violation: 3}}, ranked, "Ranking by fitness alone should ignore violation")
+}
+
+func TestPopulation_Best(t *testing.T) {
+	pop := Population{{Fitness: 0, Violation: 2}, {Fitness: 5}, {Fitness: 4}, {Fitness: -1, Violation: 1}}
+	assert.Equal(t, 2, pop.Best(f.Minimise), "Best individual should be the fittest feasible individual")
+}
+
+func TestRun_Constrained(t *testing.T) {
+	params := f.G04.Params()
+	bounds := params.GetBounds(f.Clamp)
+	config := DefaultConfig(100, Plus, PerCoordinate)
+	config.Violation, config.RankingP = params.RealViolation, 0.45
+	_, best, x := Run(20000, 0, params.N, params.RealFunction, params.Direction, bounds, config)
+	assert.Equal(t, 0.0, params.RealViolation(x), "Best solution should be feasible")
+	assert.Equal(t, params.RealFunction(x), best, "Best fitness should match best solution")
+}
+
+func TestRun(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	for _, selection := range []Selection{Comma, Plus} {
+		for _, stepSizes := range []StepSizes{Single, PerCoordinate} {
+			history, best, x := Run(5000, 0, f.RastriginN, f.RastriginReal, f.Minimise, bounds, DefaultConfig(35, selection, stepSizes))
+			assert.Equal(t, f.RastriginN, len(x), "Best solution should have N variables")
+			assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match best solution")
+			assert.Less(t, best, history[0].Fitness, "ES should improve on the initial parents")
+			for i := 1; i < len(history); i++ {
+				assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package ga
 import (
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
 	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"math"
 	"math/rand"
@@ -15,8 +16,19 @@ const (
 	W          = 5   // Scaling Window width
 )
 
-func Run(evaluations int, generations int, popSize int, N int, function f.Fitness, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
-	bestFitness := math.MaxFloat64
+func Run(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
+	return RunMemetic(localsearch.Config{}, evaluations, generations, popSize, N, function, direction, mutationP)
+}
+
+// RunMemetic runs the GA applying the configured local search to the elite, and to other individuals with probability
+// ls.Probability
+func RunMemetic(ls localsearch.Config, evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
+	return RunMemeticUntil(ls, nil, evaluations, generations, popSize, N, function, direction, mutationP)
+}
+
+// RunMemeticUntil is RunMemetic, also ending the run early once the stop condition is met (if not nil)
+func RunMemeticUntil(ls localsearch.Config, stop common.StopCondition, evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32) ([]chart.BestFitness, float64, []uint16) {
+	bestFitness := direction.Worst()
 	var evals int
 	var fMax float64 // Scaling Window f'max as per https://ieeexplore.ieee.org/document/4075583
 	var bestGenes []uint16
@@ -24,21 +36,27 @@ func Run(evaluations int, generations int, popSize int, N int, function f.Fitnes
 	var bestFitnessHistory []chart.BestFitness
 
 	// Initialise GA's population
-	population := InitPopulation(N, popSize, time.Now().Unix())
+	population := InitPopulation(N, popSize, time.Now().UnixNano())
 	population.EvalFitness(function, 0)
-	population.SortFitness()
+	population.SortFitness(direction)
 	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: population[0].Fitness})
 	fMax = population[len(population)-1].Fitness // Set initial value of f'max
 
 	if evaluations != 0 {
 		// Run GA for N function evaluations
 		for evals < evaluations {
-			population.doGeneration(function, mutationP, 0, &evals, &fMax, &bestFitness, &bestGenes, &bestFitnessHistory, &worstFitnessHistory)
+			population.doGeneration(function, direction, &ls, mutationP, 0, &evals, &fMax, &bestFitness, &bestGenes, &bestFitnessHistory, &worstFitnessHistory)
+			if stop != nil && stop(evals, bestFitness) {
+				break
+			}
 		}
 	} else if generations != 0 {
 		// Run GA for N generations
 		for gen := 0; gen < generations; gen++ {
-			population.doGeneration(function, mutationP, gen, &evals, &fMax, &bestFitness, &bestGenes, &bestFitnessHistory, &worstFitnessHistory)
+			population.doGeneration(function, direction, &ls, mutationP, gen, &evals, &fMax, &bestFitness, &bestGenes, &bestFitnessHistory, &worstFitnessHistory)
+			if stop != nil && stop(gen+1, bestFitness) {
+				break
+			}
 		}
 	}
 
@@ -46,22 +64,36 @@ func Run(evaluations int, generations int, popSize int, N int, function f.Fitnes
 }
 
 // doGeneration performs one generation of the GA. This function should be repeatedly run until some terminating condition is met.
-func (pop Population) doGeneration(function f.Fitness, mutationP float32, gen int, evals *int, fMax *float64, bestFitness *float64, bestGenes *[]uint16, bestFitnessHistory *[]chart.BestFitness, worstFitnessHistory *[]float64) {
+func (pop Population) doGeneration(function f.Fitness, direction f.Direction, ls *localsearch.Config, mutationP float32, gen int, evals *int, fMax *float64, bestFitness *float64, bestGenes *[]uint16, bestFitnessHistory *[]chart.BestFitness, worstFitnessHistory *[]float64) {
 	// Perform two-point crossover for each individual
-	pop.Crossover(CrossoverP, function)
+	pop.Crossover(CrossoverP, function, direction)
 	// Mutate each individual's genes
 	pop.Mutate(mutationP)
 	// Re-evaluates individual fitness
 	*evals += pop.EvalFitness(function, *fMax)
-	// Sort the population's individuals by fittest (smallest) to least fit (largest)
-	pop.SortFitness()
+	// Sort the population's individuals from fittest to least fit
+	pop.SortFitness(direction)
+	// Apply local search to the elite, and to other individuals with probability ls.Probability
+	var lsBestFitness = direction.Worst()
+	var lsBestGenes []uint16
+	if ls.NextGeneration() {
+		var lsEvals int
+		lsEvals, lsBestGenes, lsBestFitness = pop.LocalSearch(ls, function, direction, *fMax)
+		*evals += lsEvals
+		pop.SortFitness(direction)
+	}
 	// Finds individual with best fitness & genes in this generation
 	bestGenFitness, bestGenGene := pop[0].Fitness, pop[0].Genes
+	if !direction.Better(bestGenFitness, lsBestFitness) {
+		// With Baldwinian learning the fittest genes are only known to the local search
+		bestGenFitness, bestGenGene = lsBestFitness, lsBestGenes
+	}
 	worstGenFitness := pop[len(pop)-1].Fitness
 
-	if bestGenFitness < *bestFitness {
+	if direction.Better(bestGenFitness, *bestFitness) {
 		*bestFitness = bestGenFitness
-		*bestGenes = bestGenGene
+		// Copy the genes, as the population's are changed in place by later generations
+		*bestGenes = append([]uint16(nil), bestGenGene...)
 		if gen != 0 {
 			*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestGenFitness})
 		} else {
@@ -70,7 +102,36 @@ func (pop Population) doGeneration(function f.Fitness, mutationP float32, gen in
 
 	}
 	*worstFitnessHistory = append(*worstFitnessHistory, worstGenFitness)
-	*fMax = common.CalculateFMax(*worstFitnessHistory, W)
+	*fMax = common.CalculateFMax(*worstFitnessHistory, W, direction)
+}
+
+// LocalSearch applies local search to the 0-index (elite) individual, and to each other individual with probability
+// ls.Probability, updating their Fitness & ScaledFitness scores. With Lamarckian learning their genes are updated too.
+// Return number of fitness evaluations, and the best genes found by local search with their fitness
+func (pop Population) LocalSearch(ls *localsearch.Config, fitness f.Fitness, direction f.Direction, fMax float64) (int, []uint16, float64) {
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	evals := 0
+	bestFitness := direction.Worst()
+	var bestGenes []uint16
+	vars := localsearch.AllVars(len(pop[0].Genes))
+	for i := 0; i < len(pop); i++ {
+		if i == 0 || r.Float64() < ls.Probability {
+			genes, searchFitness, searchEvals := ls.Searcher.Search(pop[i].Genes, pop[i].Fitness, vars, fitness, direction, r)
+			if ls.Lamarckian(r) {
+				pop[i].Genes = genes
+			}
+			pop[i].Fitness = searchFitness
+			pop[i].ScaledFitness = math.Abs(fMax - pop[i].Fitness)
+			evals += searchEvals
+
+			if direction.Better(searchFitness, bestFitness) {
+				bestFitness, bestGenes = searchFitness, genes
+			}
+		}
+	}
+	return evals, bestGenes, bestFitness
 }
 
 // Mutate performs bit-flip mutation on each of the individual's genes
@@ -79,32 +140,31 @@ func (pop Population) Mutate(MutationP float32) {
 	r := rand.New(s)
 
 	for i := 1; i < len(pop); i++ {
-		individual := pop[i]
-
-		mutatedGenes := individual.Genes
-
-		// Mutate each of the individual's genes
-		for g := 0; g < len(mutatedGenes); g++ {
-			// Mutate each of the 16 bits in the gene
-			for b := 0; b < 16; b++ {
-				// P probability of mutation
-				if r.Float32() < MutationP {
-					// Perform bit-flip
-					if common.HasBit(mutatedGenes[g], uint(b)) {
-						mutatedGenes[g] = common.ClearBit(mutatedGenes[g], uint(b))
-					} else {
-						mutatedGenes[g] = common.SetBit(mutatedGenes[g], uint(b))
-					}
+		mutateGenes(pop[i].Genes, MutationP, r)
+	}
+}
+
+// mutateGenes performs bit-flip mutation on each bit of the genes with probability MutationP
+func mutateGenes(genes []uint16, MutationP float32, r *rand.Rand) {
+	// Mutate each of the individual's genes
+	for g := 0; g < len(genes); g++ {
+		// Mutate each of the 16 bits in the gene
+		for b := 0; b < 16; b++ {
+			// P probability of mutation
+			if r.Float32() < MutationP {
+				// Perform bit-flip
+				if common.HasBit(genes[g], uint(b)) {
+					genes[g] = common.ClearBit(genes[g], uint(b))
+				} else {
+					genes[g] = common.SetBit(genes[g], uint(b))
 				}
 			}
 		}
-		// Replace individual's old genes with mutated ones
-		pop[i].Genes = mutatedGenes
 	}
 }
 
 // Crossover performs Two Point Crossover with another roulette selected individual.
-func (pop Population) Crossover(crossoverP float32, fitness f.Fitness) {
+func (pop Population) Crossover(crossoverP float32, fitness f.Fitness, direction f.Direction) {
 	s := rand.NewSource(time.Now().UnixNano())
 	r := rand.New(s)
 
@@ -122,7 +182,7 @@ func (pop Population) Crossover(crossoverP float32, fitness f.Fitness) {
 
 			// Pick best offspring
 			fitnessA, fitnessB := fitness(offspringA), fitness(offspringB)
-			if fitnessA > fitnessB {
+			if direction.Better(fitnessB, fitnessA) {
 				pop[i].Genes = offspringB
 			} else {
 				pop[i].Genes = offspringA
@@ -152,22 +212,27 @@ func (pop Population) RouletteSetup() {
 // RouletteSelection uses a roulette approach to apply higher selective pressure for individuals with better fitness
 // Adapted from: https://stackoverflow.com/a/177278/6008271
 func (pop Population) RouletteSelection(r *rand.Rand) Individual {
+	return pop[pop.RouletteIndex(r)]
+}
+
+// RouletteIndex is RouletteSelection returning the index of the selected individual
+func (pop Population) RouletteIndex(r *rand.Rand) int {
 	// Todo: Use binary search here, instead of linear search.
 	number := r.Float64()
 	for p := 0; p < len(pop); p++ {
 		if p == 0 {
 			// First entry on roulette wheel, range 0.0 - Select Probability
 			if number < pop[p].SelectProbability {
-				return pop[p]
+				return p
 			}
 		} else {
 			// SelectProbability greater than last individual, but within this individual's probability range
 			if number > pop[p-1].SelectProbability && number < pop[p].SelectProbability {
-				return pop[p]
+				return p
 			}
 		}
 	}
-	return pop[0]
+	return 0
 }
 
 // EvalFitness checks the fitness of an individual's genes and updates its Fitness & ScaledFitness scores.
@@ -181,9 +246,9 @@ func (pop Population) EvalFitness(fitness f.Fitness, fMax float64) int {
 	return len(pop)
 }
 
-// SortFitness sorts the population slice by fittest (smallest fitness score) to least fit (largest fitness score).
-func (pop Population) SortFitness() {
+// SortFitness sorts the population slice from fittest to least fit in the direction
+func (pop Population) SortFitness(direction f.Direction) {
 	sort.Slice(pop, func(i, j int) bool {
-		return pop[i].Fitness < pop[j].Fitness
+		return direction.Better(pop[i].Fitness, pop[j].Fitness)
 	})
 }
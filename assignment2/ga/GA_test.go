@@ -2,6 +2,7 @@ package ga
 
 import (
 	"fmt"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
 	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
@@ -55,7 +56,7 @@ func TestPopulation_Crossover_OneProbability(t *testing.T) {
 	}
 
 	// Crossover with 100% probability
-	input.Crossover(1.0, f.TestFunc)
+	input.Crossover(1.0, f.TestFunc, f.Minimise)
 
 	assert.Equal(t, []uint16{0xf00f, 0xf00f, 0xf00f, 0xf00f}, input[1].Genes, "Genes were not crossed over as expected")
 }
@@ -69,7 +70,7 @@ func TestPopulation_Crossover_ZeroProbability(t *testing.T) {
 	}
 
 	// Crossover with 100% probability
-	input.Crossover(0.0, f.TestFunc)
+	input.Crossover(0.0, f.TestFunc, f.Minimise)
 
 	assert.Equal(t, uint16(0xFFFF), input[1].Genes[0], "Genes were modified when they shouldn't")
 	assert.Equal(t, uint16(0x0000), input[1].Genes[1], "Genes were modified when they shouldn't")
@@ -85,7 +86,7 @@ func TestPopulation_Crossover_Elitist(t *testing.T) {
 	}
 
 	// Crossover with 100% probability
-	input.Crossover(1.0, f.TestFunc)
+	input.Crossover(1.0, f.TestFunc, f.Minimise)
 
 	assert.Equal(t, uint16(0x0000), input[0].Genes[0], "Genes for 0-index individual should remain unchanged")
 }
@@ -139,6 +140,17 @@ func TestPopulation_RouletteSelection(t *testing.T) {
 	}
 }
 
+func TestPopulation_RouletteIndex(t *testing.T) {
+	input := Population{
+		Individual{[]uint16{0}, 0, 0, 0.0},
+		Individual{[]uint16{1}, 0, 0, 1.0},
+	}
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 1, input.RouletteIndex(r), "Roulette index should select the only individual with probability")
+	}
+}
+
 func TestPopulation_EvalFitness(t *testing.T) {
 	input := Population{
 		Individual{[]uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0, 0, 0},
@@ -165,8 +177,79 @@ func TestPopulation_SortFitness(t *testing.T) {
 		Individual{[]uint16{37889}, 2345, 0, 0},
 	}
 
-	input.SortFitness()
+	input.SortFitness(f.Minimise)
 
 	assert.Equal(t, expected, input, "Population was not correctly sorted")
 
 }
+
+// TestPopulation_LocalSearch ensures local search is always applied to the elite, and not to others with 0 probability
+func TestPopulation_LocalSearch(t *testing.T) {
+	input := Population{
+		Individual{[]uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0, 0, 0},
+		Individual{[]uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0, 0, 0},
+	}
+	input.EvalFitness(f.Schwefel, 3000)
+	startFitness := input[0].Fitness
+
+	ls := &localsearch.Config{Searcher: localsearch.BitFlipClimb{Iters: 50}, Frequency: 1, Probability: 0}
+	evals, bestGenes, bestFitness := input.LocalSearch(ls, f.Schwefel, f.Minimise, 3000)
+
+	assert.Equal(t, bestGenes, input[0].Genes, "Lamarckian local search should write best genes back")
+	assert.Equal(t, bestFitness, input[0].Fitness, "Local search should return best fitness found")
+	assert.Equal(t, 50, evals, "Local search should use its evaluation budget on the elite")
+	assert.Less(t, input[0].Fitness, startFitness, "Local search should improve the elite")
+	assert.Equal(t, f.Schwefel(input[0].Genes), input[0].Fitness, "Elite fitness should match its genes after local search")
+	assert.InDelta(t, 3000-input[0].Fitness, input[0].ScaledFitness, 0.01, "Elite scaled fitness should be updated")
+	assert.Equal(t, startFitness, input[1].Fitness, "Local search should not be applied to other individuals with probability 0")
+}
+
+// TestPopulation_LocalSearch_Baldwinian ensures Baldwinian local search updates fitness but not genes
+func TestPopulation_LocalSearch_Baldwinian(t *testing.T) {
+	input := Population{
+		Individual{[]uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0, 0, 0},
+	}
+	input.EvalFitness(f.Schwefel, 3000)
+	startFitness := input[0].Fitness
+
+	ls := &localsearch.Config{Searcher: localsearch.BitFlipClimb{Iters: 50}, Frequency: 1, Mode: localsearch.Baldwinian}
+	_, bestGenes, bestFitness := input.LocalSearch(ls, f.Schwefel, f.Minimise, 3000)
+
+	assert.Less(t, input[0].Fitness, startFitness, "Baldwinian local search should improve fitness used for selection")
+	assert.Equal(t, []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, input[0].Genes, "Baldwinian local search should not change genes")
+	assert.Equal(t, f.Schwefel(bestGenes), bestFitness, "Best genes found should match best fitness")
+}
+
+// TestRunMemeticUntil ensures the GA ends once the stop condition is met
+func TestRunMemeticUntil(t *testing.T) {
+	var calls []int
+	stop := func(x int, bestFitness float64) bool {
+		calls = append(calls, x)
+		return len(calls) == 3
+	}
+	RunMemeticUntil(localsearch.Config{}, stop, 0, 100, 10, f.RastriginN, f.Rastrigin, f.Minimise, f.RastriginMutationP)
+	assert.Equal(t, []int{1, 2, 3}, calls, "Stop condition should be checked with generations run, ending the run when met")
+}
+
+func TestRun_BestGenes(t *testing.T) {
+	// Coarse fitness often ties, so the best genes found are soon sorted behind others and mutated
+	coarse := func(genes []uint16) float64 {
+		var sum float64
+		for _, gene := range genes {
+			sum += float64(gene >> 14)
+		}
+		return sum
+	}
+	_, best, genes := Run(20000, 0, 100, 10, coarse, f.Minimise, 0.1)
+	assert.Equal(t, best, coarse(genes), "Best genes should not change after they are found")
+}
+
+func TestRun_Maximise(t *testing.T) {
+	params, _ := f.GetParams("onemax")
+	history, best, genes := Run(0, 50, 50, f.OneMaxN, params.Function, f.Maximise, f.OneMaxMutationP)
+	assert.Equal(t, best, params.Function(genes), "Best genes should have the best fitness")
+	for i := 1; i < len(history); i++ {
+		assert.GreaterOrEqual(t, history[i].Fitness, history[i-1].Fitness, "Best fitness should never decrease when maximising")
+	}
+	assert.Greater(t, best, float64(8*f.OneMaxN), "Maximising OneMax should set more than half of the bits")
+}
@@ -0,0 +1,274 @@
+package ga
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// AgingScheme sets how the maximum age of each layer grows, as multiples of the age gap
+type AgingScheme int
+
+const (
+	LinearAging      AgingScheme = iota // 1, 2, 3, 4, 5, ...
+	FibonacciAging                      // 1, 2, 3, 5, 8, ...
+	PolynomialAging                     // 1, 2, 4, 9, 16, ...
+	ExponentialAging                    // 1, 2, 4, 8, 16, ...
+)
+
+// ALPSConfig configures the Age-Layered Population Structure GA
+type ALPSConfig struct {
+	Layers int // Number of age layers, each of the population size
+	AgeGap int // Generations between random restarts of the bottom layer, and the unit of each layer's maximum age
+	Aging  AgingScheme
+}
+
+// Layer is one age layer of ALPS. Ages[i] is the age of Pop[i], the number of generations its oldest genetic material
+// has been evolving for.
+type Layer struct {
+	Pop    Population
+	Ages   []int
+	MaxAge int // Oldest age allowed in the layer, older individuals move up a layer. The top layer has no limit (-1)
+
+	direction f.Direction
+
+	fMax                float64
+	worstFitnessHistory []float64
+}
+
+// GetAgingScheme gets an AgingScheme by name
+func GetAgingScheme(name string) (AgingScheme, error) {
+	switch name {
+	case "linear":
+		return LinearAging, nil
+	case "fibonacci":
+		return FibonacciAging, nil
+	case "polynomial":
+		return PolynomialAging, nil
+	case "exponential":
+		return ExponentialAging, nil
+	}
+
+	return LinearAging, errors.New("invalid aging scheme passed to GetAgingScheme")
+}
+
+// MaxAges gets the maximum age of each layer, the top layer has no limit (-1)
+func (config ALPSConfig) MaxAges() []int {
+	maxAges := make([]int, config.Layers)
+	fibA, fibB := 1, 2
+	for l := 0; l < config.Layers; l++ {
+		var multiple int
+		switch config.Aging {
+		case LinearAging:
+			multiple = l + 1
+		case FibonacciAging:
+			multiple = fibA
+			fibA, fibB = fibB, fibA+fibB
+		case PolynomialAging:
+			multiple = int(math.Max(float64(l+1), float64(l*l)))
+		case ExponentialAging:
+			multiple = 1 << uint(l)
+		}
+		maxAges[l] = config.AgeGap * multiple
+	}
+	maxAges[config.Layers-1] = -1
+	return maxAges
+}
+
+// RunALPS runs the Age-Layered Population Structure GA as per Hornby (DOI 10.1145/1143997.1144142). Each layer only
+// holds individuals up to its maximum age and breeds from itself and the layer below, individuals too old for their
+// layer move up, replacing the worst of the layer above if fitter. Every AgeGap generations the bottom layer is
+// restarted with random individuals, continually feeding new genetic material into the layers above.
+func RunALPS(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32, config ALPSConfig) ([]chart.BestFitness, float64, []uint16) {
+	bestFitness := direction.Worst()
+	var evals int
+	var bestGenes []uint16
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise every layer with random individuals of age 0
+	maxAges := config.MaxAges()
+	layers := make([]*Layer, config.Layers)
+	for l := 0; l < config.Layers; l++ {
+		layers[l] = &Layer{MaxAge: maxAges[l], direction: direction}
+		evals += layers[l].Restart(N, popSize, function, r)
+	}
+	for _, layer := range layers {
+		if best, _ := layer.Pop.bestWorst(direction); direction.Better(layer.Pop[best].Fitness, bestFitness) {
+			bestFitness, bestGenes = layer.Pop[best].Fitness, layer.Pop[best].Genes
+		}
+	}
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	doGeneration := func(gen int, count int) {
+		// Breed from the top layer down, so individuals moving up are not moved again in the same generation
+		for l := config.Layers - 1; l >= 0; l-- {
+			var below, above *Layer
+			if l > 0 {
+				below = layers[l-1]
+			}
+			if l < config.Layers-1 {
+				above = layers[l+1]
+			}
+			evals += layers[l].Breed(below, above, popSize, function, mutationP, r)
+		}
+
+		// Periodically restart the bottom layer
+		if count%config.AgeGap == 0 {
+			evals += layers[0].Restart(N, popSize, function, r)
+		}
+
+		// Finds individual with best fitness & genes in this generation
+		for _, layer := range layers {
+			if best, _ := layer.Pop.bestWorst(direction); direction.Better(layer.Pop[best].Fitness, bestFitness) {
+				bestFitness, bestGenes = layer.Pop[best].Fitness, append([]uint16(nil), layer.Pop[best].Genes...)
+				if gen != 0 {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+				} else {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+				}
+			}
+		}
+	}
+
+	if evaluations != 0 {
+		// Run ALPS for N function evaluations
+		for count := 1; evals < evaluations; count++ {
+			doGeneration(0, count)
+		}
+	} else if generations != 0 {
+		// Run ALPS for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen, gen+1)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenes
+}
+
+// pool gets the individuals of this layer and the layer below (if not nil) along with their ages, for selecting
+// parents. Each layer scales fitness by its own f'max, so the pool is rescaled against the worse of the two.
+func (layer *Layer) pool(below *Layer) (Population, []int) {
+	pool := append(Population(nil), layer.Pop...)
+	poolAges := append([]int(nil), layer.Ages...)
+	fMax := layer.fMax
+	if below != nil {
+		pool = append(pool, below.Pop...)
+		poolAges = append(poolAges, below.Ages...)
+		if layer.direction.Better(fMax, below.fMax) {
+			fMax = below.fMax
+		}
+	}
+	for i := range pool {
+		pool[i].ScaledFitness = math.Abs(fMax - pool[i].Fitness)
+	}
+	return pool, poolAges
+}
+
+// Restart replaces the layer with popSize random individuals of age 0
+// Return number of fitness evaluations
+func (layer *Layer) Restart(N int, popSize int, function f.Fitness, r *rand.Rand) int {
+	layer.Pop = make(Population, popSize)
+	layer.Ages = make([]int, popSize)
+	for i := 0; i < popSize; i++ {
+		genes := make([]uint16, N)
+		for n := 0; n < N; n++ {
+			genes[n] = uint16(r.Intn(65536))
+		}
+		layer.Pop[i] = Individual{Genes: genes}
+	}
+	layer.worstFitnessHistory = nil
+	evals := layer.Pop.EvalFitness(function, 0)
+	layer.updateFMax()
+	return evals
+}
+
+// Breed replaces the layer with a new generation bred from itself and the layer below, keeping its elite if it is not
+// too old. Offspring inherit the age of their oldest parent, plus one generation. Offspring too old for the layer are
+// offered to the layer above instead.
+// Return number of fitness evaluations
+func (layer *Layer) Breed(below *Layer, above *Layer, popSize int, function f.Fitness, mutationP float32, r *rand.Rand) int {
+	evals := 0
+
+	// Parents are selected from this layer and the layer below
+	pool, poolAges := layer.pool(below)
+	pool.RouletteSetup()
+
+	next := make(Population, 0, popSize)
+	nextAges := make([]int, 0, popSize)
+	if best, _ := layer.Pop.bestWorst(layer.direction); layer.fits(layer.Ages[best] + 1) {
+		next = append(next, layer.Pop[best])
+		nextAges = append(nextAges, layer.Ages[best]+1)
+	} else if above != nil {
+		above.Offer(layer.Pop[best], layer.Ages[best]+1)
+	}
+
+	for attempts := 0; len(next) < popSize && attempts < 4*popSize; attempts++ {
+		a, b := pool.RouletteIndex(r), pool.RouletteIndex(r)
+		genes := append([]uint16(nil), pool[a].Genes...)
+		age := poolAges[a]
+		if r.Float32() < CrossoverP {
+			// Perform two-point crossover, keeping one of the offspring at random
+			for g := 0; g < len(genes); g++ {
+				offspringA, offspringB := common.TwoPointCrossover(genes[g], pool[b].Genes[g])
+				genes[g] = offspringA
+				if r.Intn(2) == 1 {
+					genes[g] = offspringB
+				}
+			}
+			if poolAges[b] > age {
+				age = poolAges[b]
+			}
+		}
+		mutateGenes(genes, mutationP, r)
+
+		offspring := Individual{Genes: genes, Fitness: function(genes)}
+		evals++
+		if layer.fits(age + 1) {
+			next = append(next, offspring)
+			nextAges = append(nextAges, age+1)
+		} else if above != nil {
+			above.Offer(offspring, age+1)
+		}
+	}
+
+	// Should every attempt be too old, fill the layer with selected parents aged to the layer's maximum age
+	for len(next) < popSize {
+		p := pool.RouletteIndex(r)
+		next = append(next, Individual{Genes: append([]uint16(nil), pool[p].Genes...), Fitness: pool[p].Fitness})
+		nextAges = append(nextAges, int(math.Min(float64(poolAges[p]+1), float64(layer.MaxAge))))
+	}
+
+	layer.Pop, layer.Ages = next, nextAges
+	layer.updateFMax()
+	return evals
+}
+
+// Offer replaces the layer's worst individual with ind if ind is at least as fit
+func (layer *Layer) Offer(ind Individual, age int) {
+	if _, worst := layer.Pop.bestWorst(layer.direction); !layer.direction.Better(layer.Pop[worst].Fitness, ind.Fitness) {
+		ind.ScaledFitness = math.Abs(layer.fMax - ind.Fitness)
+		layer.Pop[worst], layer.Ages[worst] = ind, age
+	}
+}
+
+// fits checks whether an individual of the given age may be in the layer
+func (layer *Layer) fits(age int) bool {
+	return layer.MaxAge < 0 || age <= layer.MaxAge
+}
+
+// updateFMax updates the layer's scaling window f'max from its worst fitness, and rescales its individuals
+func (layer *Layer) updateFMax() {
+	_, worst := layer.Pop.bestWorst(layer.direction)
+	layer.worstFitnessHistory = append(layer.worstFitnessHistory, layer.Pop[worst].Fitness)
+	layer.fMax = common.CalculateFMax(layer.worstFitnessHistory, W, layer.direction)
+	for i := range layer.Pop {
+		layer.Pop[i].ScaledFitness = math.Abs(layer.fMax - layer.Pop[i].Fitness)
+	}
+}
@@ -0,0 +1,98 @@
+package ga
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGetAgingScheme(t *testing.T) {
+	scheme, err := GetAgingScheme("fibonacci")
+	assert.Nil(t, err, "GetAgingScheme should find fibonacci")
+	assert.Equal(t, FibonacciAging, scheme, "GetAgingScheme returned wrong aging scheme")
+
+	_, err = GetAgingScheme("invalid")
+	assert.NotNil(t, err, "GetAgingScheme should error for unknown aging scheme")
+}
+
+func TestALPSConfig_MaxAges(t *testing.T) {
+	config := ALPSConfig{Layers: 6, AgeGap: 10}
+	config.Aging = LinearAging
+	assert.Equal(t, []int{10, 20, 30, 40, 50, -1}, config.MaxAges(), "Linear aging scheme max ages")
+	config.Aging = FibonacciAging
+	assert.Equal(t, []int{10, 20, 30, 50, 80, -1}, config.MaxAges(), "Fibonacci aging scheme max ages")
+	config.Aging = PolynomialAging
+	assert.Equal(t, []int{10, 20, 40, 90, 160, -1}, config.MaxAges(), "Polynomial aging scheme max ages")
+	config.Aging = ExponentialAging
+	assert.Equal(t, []int{10, 20, 40, 80, 160, -1}, config.MaxAges(), "Exponential aging scheme max ages")
+}
+
+func TestLayer_Restart(t *testing.T) {
+	layer := Layer{MaxAge: 10, Ages: []int{5}}
+	evals := layer.Restart(3, 4, f.TestFunc, rand.New(rand.NewSource(0)))
+	assert.Equal(t, 4, evals, "Each new individual should be evaluated")
+	assert.Equal(t, 4, len(layer.Pop), "Layer should have popSize individuals")
+	assert.Equal(t, []int{0, 0, 0, 0}, layer.Ages, "New individuals should have age 0")
+}
+
+func TestLayer_Offer(t *testing.T) {
+	layer := Layer{
+		Pop:  Population{Individual{[]uint16{1}, 1, 0, 0}, Individual{[]uint16{5}, 5, 0, 0}},
+		Ages: []int{3, 4},
+	}
+	layer.Offer(Individual{[]uint16{9}, 9, 0, 0}, 20)
+	assert.Equal(t, uint16(5), layer.Pop[1].Genes[0], "Less fit individual should not replace the worst")
+
+	layer.Offer(Individual{[]uint16{2}, 2, 0, 0}, 20)
+	assert.Equal(t, uint16(2), layer.Pop[1].Genes[0], "Fitter individual should replace the worst")
+	assert.Equal(t, 20, layer.Ages[1], "Moved individual should keep its age")
+}
+
+func TestLayer_Breed(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	below := &Layer{MaxAge: 2}
+	below.Restart(f.RastriginN, 10, f.Rastrigin, r)
+	layer := &Layer{MaxAge: 4}
+	layer.Restart(f.RastriginN, 10, f.Rastrigin, r)
+	above := &Layer{MaxAge: -1}
+	above.Restart(f.RastriginN, 10, f.Rastrigin, r)
+	for i := range layer.Ages {
+		layer.Ages[i] = 4
+	}
+
+	layer.Breed(below, above, 10, f.Rastrigin, 0.01, r)
+	assert.Equal(t, 10, len(layer.Pop), "Layer should be refilled to popSize")
+	for i, age := range layer.Ages {
+		assert.LessOrEqual(t, age, 4, "Individuals should not be older than the layer's maximum age")
+		assert.Equal(t, f.Rastrigin(layer.Pop[i].Genes), layer.Pop[i].Fitness, "Offspring should be evaluated")
+	}
+	assert.Contains(t, above.Ages, 5, "Offspring too old for the layer should move up")
+}
+
+// TestLayer_pool ensures parents from both layers are scaled against the same f'max
+func TestLayer_pool(t *testing.T) {
+	below := &Layer{Pop: Population{{Fitness: 1}, {Fitness: 50}}, Ages: []int{0, 1}, fMax: 50}
+	layer := &Layer{Pop: Population{{Fitness: 5}, {Fitness: 10}}, Ages: []int{2, 3}, fMax: 10}
+
+	pool, ages := layer.pool(below)
+	assert.Equal(t, []int{2, 3, 0, 1}, ages, "Pool should hold the ages of both layers")
+	assert.Equal(t, []float64{45, 40, 49, 0}, []float64{pool[0].ScaledFitness, pool[1].ScaledFitness, pool[2].ScaledFitness, pool[3].ScaledFitness}, "Pool should be scaled against the larger f'max")
+	assert.Equal(t, 0.0, layer.Pop[0].ScaledFitness, "The layer's own individuals should not be rescaled")
+
+	pool, _ = layer.pool(nil)
+	assert.Equal(t, 2, len(pool), "Bottom layer should only select from itself")
+	assert.Equal(t, 5.0, pool[0].ScaledFitness, "Bottom layer should be scaled against its own f'max")
+}
+
+func TestRunALPS(t *testing.T) {
+	config := ALPSConfig{Layers: 4, AgeGap: 5, Aging: PolynomialAging}
+	history, best, genes := RunALPS(10000, 0, 20, f.RastriginN, f.Rastrigin, f.Minimise, f.RastriginMutationP, config)
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+	}
+
+	history, _, _ = RunALPS(0, 30, 20, f.RastriginN, f.Rastrigin, f.Minimise, f.RastriginMutationP, config)
+	assert.Less(t, history[len(history)-1].X, 30, "Generations should not exceed the limit")
+}
@@ -0,0 +1,241 @@
+package ga
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Neighbourhood sets which cells of the grid an individual may select mates from
+type Neighbourhood int
+
+const (
+	VonNeumann Neighbourhood = iota // The 4 cells directly above, below, left and right
+	Moore                           // The 8 surrounding cells, including diagonals
+	Radius                          // Every cell within a Manhattan distance of CellularConfig.Radius
+)
+
+// UpdatePolicy sets the order cells of the grid are updated in each generation
+type UpdatePolicy int
+
+const (
+	Synchronous UpdatePolicy = iota // Every cell is updated at once from the previous generation's grid
+	LineSweep                       // Cells are updated one at a time in order, seeing earlier updates immediately
+	RandomSweep                     // Cells are updated one at a time in a new random order each generation
+)
+
+// CellularConfig configures the cellular (diffusion) GA
+type CellularConfig struct {
+	Neighbourhood Neighbourhood
+	Radius        int // Radius of the neighbourhood, used with the Radius neighbourhood
+	Update        UpdatePolicy
+}
+
+// Grid holds the cellular GA's population on a 2D torus of Rows x Cols, where the individual at row, col is at index
+// row*Cols + col of Pop. Unlike the panmictic GA, the population is never sorted so individuals keep their place.
+type Grid struct {
+	Pop  Population
+	Rows int
+	Cols int
+}
+
+// GetNeighbourhood gets a Neighbourhood by name
+func GetNeighbourhood(name string) (Neighbourhood, error) {
+	switch name {
+	case "vonneumann":
+		return VonNeumann, nil
+	case "moore":
+		return Moore, nil
+	case "radius":
+		return Radius, nil
+	}
+
+	return VonNeumann, errors.New("invalid neighbourhood passed to GetNeighbourhood")
+}
+
+// GetUpdatePolicy gets an UpdatePolicy by name
+func GetUpdatePolicy(name string) (UpdatePolicy, error) {
+	switch name {
+	case "sync":
+		return Synchronous, nil
+	case "linesweep":
+		return LineSweep, nil
+	case "randomsweep":
+		return RandomSweep, nil
+	}
+
+	return Synchronous, errors.New("invalid update policy passed to GetUpdatePolicy")
+}
+
+// RunCellular runs the cellular GA, where individuals live on a 2D torus and only mate with and replace individuals in
+// their neighbourhood.
+func RunCellular(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32, config CellularConfig) ([]chart.BestFitness, float64, []uint16) {
+	bestFitness := direction.Worst()
+	var evals int
+	var fMax float64 // Scaling Window f'max as per https://ieeexplore.ieee.org/document/4075583
+	var bestGenes []uint16
+	var worstFitnessHistory []float64 // Track worst fitness for each generation
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise cellular GA's grid
+	rows, cols := GridShape(popSize)
+	grid := Grid{Pop: InitPopulation(N, popSize, r.Int63()), Rows: rows, Cols: cols}
+	grid.Pop.EvalFitness(function, 0)
+	bestIdx, worstIdx := grid.Pop.bestWorst(direction)
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: grid.Pop[bestIdx].Fitness})
+	fMax = grid.Pop[worstIdx].Fitness // Set initial value of f'max
+
+	if evaluations != 0 {
+		// Run cellular GA for N function evaluations
+		for evals < evaluations {
+			grid.doGeneration(function, direction, mutationP, config, 0, &evals, &fMax, &bestFitness, &bestGenes, &bestFitnessHistory, &worstFitnessHistory, r)
+		}
+	} else if generations != 0 {
+		// Run cellular GA for N generations
+		for gen := 0; gen < generations; gen++ {
+			grid.doGeneration(function, direction, mutationP, config, gen, &evals, &fMax, &bestFitness, &bestGenes, &bestFitnessHistory, &worstFitnessHistory, r)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenes
+}
+
+// doGeneration performs one generation of the cellular GA, updating every cell of the grid once.
+func (grid Grid) doGeneration(function f.Fitness, direction f.Direction, mutationP float32, config CellularConfig, gen int, evals *int, fMax *float64, bestFitness *float64, bestGenes *[]uint16, bestFitnessHistory *[]chart.BestFitness, worstFitnessHistory *[]float64, r *rand.Rand) {
+	switch config.Update {
+	case Synchronous:
+		// Offspring are bred from the previous generation's grid, then replace their parents all at once
+		next := make(Population, len(grid.Pop))
+		for i := 0; i < len(grid.Pop); i++ {
+			var breedEvals int
+			next[i], breedEvals = grid.breed(i, function, direction, mutationP, config, *fMax, r)
+			*evals += breedEvals
+		}
+		copy(grid.Pop, next)
+	case LineSweep:
+		for i := 0; i < len(grid.Pop); i++ {
+			var breedEvals int
+			grid.Pop[i], breedEvals = grid.breed(i, function, direction, mutationP, config, *fMax, r)
+			*evals += breedEvals
+		}
+	case RandomSweep:
+		for _, i := range r.Perm(len(grid.Pop)) {
+			var breedEvals int
+			grid.Pop[i], breedEvals = grid.breed(i, function, direction, mutationP, config, *fMax, r)
+			*evals += breedEvals
+		}
+	}
+
+	// Finds individual with best fitness & genes in this generation
+	bestIdx, worstIdx := grid.Pop.bestWorst(direction)
+	if direction.Better(grid.Pop[bestIdx].Fitness, *bestFitness) {
+		*bestFitness = grid.Pop[bestIdx].Fitness
+		*bestGenes = append([]uint16(nil), grid.Pop[bestIdx].Genes...)
+		if gen != 0 {
+			*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: gen, Fitness: *bestFitness})
+		} else {
+			*bestFitnessHistory = append(*bestFitnessHistory, chart.BestFitness{X: *evals, Fitness: *bestFitness})
+		}
+	}
+	*worstFitnessHistory = append(*worstFitnessHistory, grid.Pop[worstIdx].Fitness)
+	*fMax = common.CalculateFMax(*worstFitnessHistory, W, direction)
+}
+
+// breed creates an offspring for cell i by two-point crossover with a roulette selected mate from its neighbourhood,
+// followed by mutation. The offspring replaces the individual in cell i only if it is at least as fit.
+// Return the individual for cell i and number of fitness evaluations
+func (grid Grid) breed(i int, function f.Fitness, direction f.Direction, mutationP float32, config CellularConfig, fMax float64, r *rand.Rand) (Individual, int) {
+	current := grid.Pop[i]
+	evals := 1
+	genes := append([]uint16(nil), current.Genes...)
+
+	if r.Float32() < CrossoverP {
+		// Select mate from neighbourhood using roulette selection
+		neighbours := grid.Neighbours(i, config)
+		local := make(Population, len(neighbours))
+		for n, idx := range neighbours {
+			local[n] = grid.Pop[idx]
+		}
+		local.RouletteSetup()
+		mateGenes := local.RouletteSelection(r).Genes
+
+		// Perform two-point crossover, picking best offspring
+		offspringA, offspringB := make([]uint16, len(genes)), make([]uint16, len(genes))
+		for g := 0; g < len(genes); g++ {
+			offspringA[g], offspringB[g] = common.TwoPointCrossover(genes[g], mateGenes[g])
+		}
+		evals += 2
+		if direction.Better(function(offspringB), function(offspringA)) {
+			genes = offspringB
+		} else {
+			genes = offspringA
+		}
+	}
+	mutateGenes(genes, mutationP, r)
+
+	// Local replacement, only if offspring is at least as fit
+	offspring := Individual{Genes: genes, Fitness: function(genes)}
+	offspring.ScaledFitness = math.Abs(fMax - offspring.Fitness)
+	if !direction.Better(current.Fitness, offspring.Fitness) {
+		return offspring, evals
+	}
+	current.ScaledFitness = math.Abs(fMax - current.Fitness)
+	return current, evals
+}
+
+// Neighbours gets the indexes of cells in the neighbourhood of cell i, not including cell i itself.
+func (grid Grid) Neighbours(i int, config CellularConfig) []int {
+	row, col := i/grid.Cols, i%grid.Cols
+
+	radius := 1
+	if config.Neighbourhood == Radius {
+		radius = config.Radius
+	}
+
+	var neighbours []int
+	for dr := -radius; dr <= radius; dr++ {
+		for dc := -radius; dc <= radius; dc++ {
+			if dr == 0 && dc == 0 {
+				continue
+			}
+			// Moore neighbourhood includes diagonals, others are limited by Manhattan distance
+			if config.Neighbourhood != Moore && abs(dr)+abs(dc) > radius {
+				continue
+			}
+			// Wrap around edges of the torus
+			n := ((row+dr+grid.Rows*radius)%grid.Rows)*grid.Cols + (col+dc+grid.Cols*radius)%grid.Cols
+			if n != i && !containsInt(neighbours, n) {
+				neighbours = append(neighbours, n)
+			}
+		}
+	}
+	return neighbours
+}
+
+// bestWorst finds the indexes of the individuals with the best and worst fitness scores in the direction
+func (pop Population) bestWorst(direction f.Direction) (int, int) {
+	best, worst := 0, 0
+	for i := 1; i < len(pop); i++ {
+		if direction.Better(pop[i].Fitness, pop[best].Fitness) {
+			best = i
+		}
+		if direction.Better(pop[worst].Fitness, pop[i].Fitness) {
+			worst = i
+		}
+	}
+	return best, worst
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
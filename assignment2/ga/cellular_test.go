@@ -0,0 +1,69 @@
+package ga
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGridShape(t *testing.T) {
+	rows, cols := GridShape(100)
+	assert.Equal(t, 10, rows, "GridShape should find square grid")
+	assert.Equal(t, 10, cols, "GridShape should find square grid")
+
+	rows, cols = GridShape(12)
+	assert.Equal(t, 12, rows*cols, "GridShape should hold exactly n cells")
+	assert.Equal(t, 3, rows, "GridShape should find most square grid")
+}
+
+func TestGrid_Neighbours(t *testing.T) {
+	grid := Grid{Rows: 5, Cols: 5}
+
+	// Cell 0 is in the corner, so its neighbourhood wraps around the torus
+	assert.ElementsMatch(t, []int{20, 5, 4, 1}, grid.Neighbours(0, CellularConfig{Neighbourhood: VonNeumann}), "Von Neumann neighbourhood should be the 4 adjacent cells")
+	assert.ElementsMatch(t, []int{24, 20, 21, 4, 1, 9, 5, 6}, grid.Neighbours(0, CellularConfig{Neighbourhood: Moore}), "Moore neighbourhood should be the 8 surrounding cells")
+	assert.Equal(t, 12, len(grid.Neighbours(12, CellularConfig{Neighbourhood: Radius, Radius: 2})), "Radius 2 neighbourhood should have 12 cells")
+	assert.Equal(t, 4, len(grid.Neighbours(12, CellularConfig{Neighbourhood: Radius, Radius: 1})), "Radius 1 neighbourhood should match Von Neumann")
+}
+
+// TestGrid_Breed_Replacement ensures an offspring only replaces its parent cell if it is at least as fit
+func TestGrid_Breed_Replacement(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	grid := Grid{Pop: InitPopulation(f.SchwefelN, 9, 0), Rows: 3, Cols: 3}
+	grid.Pop.EvalFitness(f.Schwefel, 0)
+
+	for i := 0; i < len(grid.Pop); i++ {
+		var calls int
+		counted := func(genes []uint16) float64 {
+			calls++
+			return f.Schwefel(genes)
+		}
+		offspring, evals := grid.breed(i, counted, f.Minimise, 0.1, CellularConfig{Neighbourhood: Moore}, 0, r)
+		assert.Equal(t, calls, evals, "Every fitness evaluation should be counted")
+		assert.LessOrEqual(t, offspring.Fitness, grid.Pop[i].Fitness, "Offspring replacing a cell should be at least as fit")
+		assert.Equal(t, f.Schwefel(offspring.Genes), offspring.Fitness, "Offspring fitness should match its genes")
+	}
+}
+
+func TestPopulation_bestWorst(t *testing.T) {
+	input := Population{
+		Individual{[]uint16{0}, 5, 0, 0},
+		Individual{[]uint16{1}, -1, 0, 0},
+		Individual{[]uint16{2}, 9, 0, 0},
+	}
+	best, worst := input.bestWorst(f.Minimise)
+	assert.Equal(t, 1, best, "bestWorst did not find fittest individual")
+	assert.Equal(t, 2, worst, "bestWorst did not find least fit individual")
+}
+
+func TestRunCellular(t *testing.T) {
+	for _, update := range []UpdatePolicy{Synchronous, LineSweep, RandomSweep} {
+		config := CellularConfig{Neighbourhood: VonNeumann, Update: update}
+		history, best, genes := RunCellular(0, 10, 16, f.SchwefelN, f.Schwefel, f.Minimise, f.SchwefelMutationP, config)
+
+		assert.Equal(t, f.SchwefelN, len(genes), "Best genes should assign every variable")
+		assert.InDelta(t, f.Schwefel(genes), best, 0.0001, "Best fitness should match best genes")
+		assert.Equal(t, best, history[len(history)-1].Fitness, "Fitness history should end with best fitness")
+	}
+}
@@ -0,0 +1,150 @@
+package ga
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/genome"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// GenomeIndividual is an Individual of the GA holding any genome.Genome rather than uint16 genes
+type GenomeIndividual struct {
+	Genome            genome.Genome
+	Fitness           float64
+	ScaledFitness     float64
+	SelectProbability float64
+}
+
+// GenomePopulation keeps the individuals of a GA evolving genomes
+type GenomePopulation []GenomeIndividual
+
+// RunGenome runs the standard GA on any genome representation, such as variables of more than 16 bits, real or integer
+// vectors, or permutations. newGenome creates the random genomes of the initial population. The GA is the same as Run:
+// two-point (or the genome's own) crossover with a roulette selected partner keeping the fittest offspring, mutation
+// of every individual but the elite, and scaling window roulette selection. It is the only algorithm evolving genomes.
+func RunGenome(evaluations int, generations int, popSize int, newGenome func(r *rand.Rand) genome.Genome, fitness genome.Fitness, direction f.Direction, mutationP float64) ([]chart.BestFitness, float64, genome.Genome) {
+	var bestFitness float64
+	var evals int
+	var fMax float64 // Scaling Window f'max as per https://ieeexplore.ieee.org/document/4075583
+	var bestGenome genome.Genome
+	var worstFitnessHistory []float64 // Track worst fitness for each generation
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise GA's population
+	population := make(GenomePopulation, popSize)
+	for i := range population {
+		population[i] = GenomeIndividual{Genome: newGenome(r)}
+	}
+	population.EvalFitness(fitness, 0)
+	population.SortFitness(direction)
+	bestFitness, bestGenome = population[0].Fitness, population[0].Genome.Copy()
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+	fMax = population[len(population)-1].Fitness // Set initial value of f'max
+
+	doGeneration := func(gen int) {
+		population.Crossover(CrossoverP, fitness, direction, r)
+		population.Mutate(mutationP, r)
+		evals += population.EvalFitness(fitness, fMax)
+		population.SortFitness(direction)
+
+		// Finds individual with best fitness & genome in this generation
+		if direction.Better(population[0].Fitness, bestFitness) {
+			bestFitness, bestGenome = population[0].Fitness, population[0].Genome.Copy()
+			if gen != 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+			} else {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+			}
+		}
+		worstFitnessHistory = append(worstFitnessHistory, population[len(population)-1].Fitness)
+		fMax = common.CalculateFMax(worstFitnessHistory, W, direction)
+	}
+
+	if evaluations != 0 {
+		// Run GA for N function evaluations
+		for evals < evaluations {
+			doGeneration(0)
+		}
+	} else if generations != 0 {
+		// Run GA for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenome
+}
+
+// Crossover recombines each individual but the elite with a roulette selected individual, with probability crossoverP,
+// keeping the fittest of the two offspring
+func (pop GenomePopulation) Crossover(crossoverP float32, fitness genome.Fitness, direction f.Direction, r *rand.Rand) {
+	pop.RouletteSetup()
+
+	for i := 1; i < len(pop); i++ {
+		if r.Float32() < crossoverP {
+			offspringA, offspringB := pop[i].Genome.Crossover(pop[pop.RouletteIndex(r)].Genome, r)
+			if direction.Better(fitness(offspringB), fitness(offspringA)) {
+				pop[i].Genome = offspringB
+			} else {
+				pop[i].Genome = offspringA
+			}
+		}
+	}
+}
+
+// Mutate mutates the genome of each individual but the elite
+func (pop GenomePopulation) Mutate(mutationP float64, r *rand.Rand) {
+	for i := 1; i < len(pop); i++ {
+		pop[i].Genome.Mutate(mutationP, r)
+	}
+}
+
+// RouletteSetup calculates population selection probabilities from ScaledFitness scores, required before using
+// RouletteIndex
+func (pop GenomePopulation) RouletteSetup() {
+	var fitnessSum float64
+	for i := 0; i < len(pop); i++ {
+		fitnessSum += pop[i].ScaledFitness
+	}
+
+	var accumulatedProbability float64
+	for i := 0; i < len(pop); i++ {
+		accumulatedProbability += pop[i].ScaledFitness / fitnessSum
+		pop[i].SelectProbability = accumulatedProbability
+	}
+}
+
+// RouletteIndex selects an individual with probability proportional to its ScaledFitness, returning its index
+func (pop GenomePopulation) RouletteIndex(r *rand.Rand) int {
+	number := r.Float64()
+	for p := 0; p < len(pop); p++ {
+		if number < pop[p].SelectProbability {
+			return p
+		}
+	}
+	return 0
+}
+
+// EvalFitness evaluates each individual's genome and updates its Fitness & ScaledFitness scores.
+// Return number of fitness evaluations
+func (pop GenomePopulation) EvalFitness(fitness genome.Fitness, fMax float64) int {
+	for i := 0; i < len(pop); i++ {
+		pop[i].Fitness = fitness(pop[i].Genome)
+		pop[i].ScaledFitness = math.Abs(fMax - pop[i].Fitness)
+	}
+	return len(pop)
+}
+
+// SortFitness sorts the population from fittest to least fit in the direction
+func (pop GenomePopulation) SortFitness(direction f.Direction) {
+	sort.Slice(pop, func(i, j int) bool {
+		return direction.Better(pop[i].Fitness, pop[j].Fitness)
+	})
+}
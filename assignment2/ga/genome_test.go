@@ -0,0 +1,55 @@
+package ga
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/genome"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGenomePopulation_RouletteSetup(t *testing.T) {
+	pop := GenomePopulation{{ScaledFitness: 1}, {ScaledFitness: 3}}
+	pop.RouletteSetup()
+	assert.Equal(t, 0.25, pop[0].SelectProbability, "Selection probabilities should be cumulative")
+	assert.Equal(t, 1.0, pop[1].SelectProbability, "Selection probabilities should be cumulative")
+	assert.Equal(t, 1, pop.RouletteIndex(rand.New(rand.NewSource(0))), "Fitter individual should usually be selected")
+}
+
+func TestGenomePopulation_Mutate_Elitist(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	pop := GenomePopulation{{Genome: &genome.Bits{Values: []uint64{0}, Width: 8}}, {Genome: &genome.Bits{Values: []uint64{0}, Width: 8}}}
+	pop.Mutate(1, r)
+	assert.Equal(t, uint64(0), pop[0].Genome.(*genome.Bits).Values[0], "Elite should not be mutated")
+	assert.Equal(t, uint64(0xFF), pop[1].Genome.(*genome.Bits).Values[0], "Other individuals should be mutated")
+}
+
+func TestRunGenome(t *testing.T) {
+	bounds := f.Bounds{Min: f.RastriginMin, Max: f.RastriginMax}
+	fitness := genome.Numeric(f.RastriginReal)
+	for _, kind := range []genome.Kind{genome.BitsKind, genome.RealKind, genome.IntegerKind} {
+		newGenome := func(r *rand.Rand) genome.Genome { return genome.New(kind, 5, 32, bounds, r) }
+		history, best, g := RunGenome(5000, 0, 20, newGenome, fitness, f.Minimise, 1.0/(32*5))
+		assert.Equal(t, fitness(g), best, "Best fitness should match best genome")
+		for i := 1; i < len(history); i++ {
+			assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		}
+	}
+}
+
+func TestRunGenome_Permutation(t *testing.T) {
+	// Count the elements out of place, so the identity permutation is optimal
+	fitness := genome.Ordering(func(order []int) float64 {
+		var misplaced float64
+		for i, element := range order {
+			if i != element {
+				misplaced++
+			}
+		}
+		return misplaced
+	})
+	newGenome := func(r *rand.Rand) genome.Genome { return genome.NewPermutation(8, r) }
+	_, best, g := RunGenome(0, 200, 30, newGenome, fitness, f.Minimise, 1.0/8)
+	assert.Equal(t, fitness(g), best, "Best fitness should match best genome")
+	assert.Less(t, best, 6.0, "GA should order most of the permutation")
+}
@@ -0,0 +1,299 @@
+package ga
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Topology sets which islands migrants are sent to
+type Topology int
+
+const (
+	Ring           Topology = iota // Migrants are sent to the next island in a ring
+	FullyConnected                 // Migrants are sent to every other island
+	RandomTopology                 // Migrants are sent to one randomly chosen island at each migration
+	Torus                          // Islands are arranged on a 2D torus, migrants are sent to the 4 neighbouring islands
+)
+
+// MigrantSelection sets how individuals are chosen to emigrate from, or be replaced by immigrants on, an island
+type MigrantSelection int
+
+const (
+	SelectBest   MigrantSelection = iota // Choose the fittest individuals
+	SelectWorst                          // Choose the least fit individuals
+	SelectRandom                         // Choose random individuals
+)
+
+// IslandConfig configures the island model GA
+type IslandConfig struct {
+	Islands           int              // Number of islands (subpopulations), each of popSize
+	MigrationInterval int              // Migrate every MigrationInterval generations
+	Migrants          int              // Number of migrants sent from each island to each of its destinations
+	Topology          Topology         // Which islands migrants are sent to
+	Emigrants         MigrantSelection // Which individuals emigrate
+	Replacement       MigrantSelection // Which individuals are replaced by immigrants (SelectWorst or SelectRandom)
+}
+
+// island holds the state of one subpopulation of the island model
+type island struct {
+	pop                 Population
+	evals               int
+	fMax                float64
+	bestFitness         float64
+	bestGenes           []uint16
+	bestFitnessHistory  []chart.BestFitness
+	worstFitnessHistory []float64
+}
+
+// GetTopology gets a Topology by name
+func GetTopology(name string) (Topology, error) {
+	switch name {
+	case "ring":
+		return Ring, nil
+	case "full":
+		return FullyConnected, nil
+	case "random":
+		return RandomTopology, nil
+	case "torus":
+		return Torus, nil
+	}
+
+	return Ring, errors.New("invalid topology passed to GetTopology")
+}
+
+// GetMigrantSelection gets a MigrantSelection by name
+func GetMigrantSelection(name string) (MigrantSelection, error) {
+	switch name {
+	case "best":
+		return SelectBest, nil
+	case "worst":
+		return SelectWorst, nil
+	case "random":
+		return SelectRandom, nil
+	}
+
+	return SelectBest, errors.New("invalid migrant selection passed to GetMigrantSelection")
+}
+
+// RunIslands runs the island model GA, where several GA populations evolve concurrently and exchange migrants every
+// config.MigrationInterval generations. Function evaluations and generations are counted across all islands, and islands
+// stop evolving once the shared evaluation budget is spent.
+func RunIslands(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32, config IslandConfig) ([]chart.BestFitness, float64, []uint16) {
+	bestFitness := direction.Worst()
+	var evals int
+	var bestGenes []uint16
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// At least one island and one generation per epoch are needed to make progress
+	if config.Islands < 1 {
+		config.Islands = 1
+	}
+	if config.MigrationInterval < 1 {
+		config.MigrationInterval = 1
+	}
+
+	// Initialise each island's population
+	islands := make([]*island, config.Islands)
+	for i := 0; i < config.Islands; i++ {
+		pop := InitPopulation(N, popSize, time.Now().UnixNano()+int64(i))
+		evals += pop.EvalFitness(function, 0)
+		pop.SortFitness(direction)
+		islands[i] = &island{
+			pop:         pop,
+			fMax:        pop[len(pop)-1].Fitness, // Set initial value of f'max
+			bestFitness: pop[0].Fitness,
+			bestGenes:   pop[0].Genes,
+		}
+		if direction.Better(pop[0].Fitness, bestFitness) {
+			bestFitness, bestGenes = pop[0].Fitness, append([]uint16(nil), pop[0].Genes...)
+		}
+	}
+	if evaluations == 0 {
+		bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+	} else {
+		bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+	}
+
+	// Evolve each island in its own goroutine for one migration interval (epoch), then migrate
+	gen := 0
+	spent := int64(evals) // Evaluations used by all islands, shared so islands stop mid-epoch once the budget is spent
+	for (evaluations != 0 && evals < evaluations) || (evaluations == 0 && gen < generations) {
+		epoch := config.MigrationInterval
+		if evaluations == 0 && gen+epoch > generations {
+			epoch = generations - gen
+		}
+
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(len(islands))
+		for i := 0; i < len(islands); i++ {
+			go func(isl *island) {
+				isl.evals = 0
+				for g := 0; g < epoch; g++ {
+					if evaluations != 0 && atomic.LoadInt64(&spent) >= int64(evaluations) {
+						break
+					}
+					genEvals := isl.evals
+					isl.pop.doGeneration(function, direction, nil, mutationP, 0, &isl.evals, &isl.fMax, &isl.bestFitness, &isl.bestGenes, &isl.bestFitnessHistory, &isl.worstFitnessHistory)
+					atomic.AddInt64(&spent, int64(isl.evals-genEvals))
+				}
+				waitGroup.Done()
+			}(islands[i])
+		}
+		waitGroup.Wait()
+		gen += epoch
+
+		// Update best fitness across all islands
+		for i := 0; i < len(islands); i++ {
+			evals += islands[i].evals
+			if direction.Better(islands[i].bestFitness, bestFitness) {
+				bestFitness, bestGenes = islands[i].bestFitness, append([]uint16(nil), islands[i].bestGenes...)
+				if evaluations == 0 {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+				} else {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+				}
+			}
+		}
+
+		Migrate(islandPopulations(islands), config, direction, r)
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenes
+}
+
+// islandPopulations gets the population of each island
+func islandPopulations(islands []*island) []Population {
+	pops := make([]Population, len(islands))
+	for i := 0; i < len(islands); i++ {
+		pops[i] = islands[i].pop
+	}
+	return pops
+}
+
+// Migrate sends migrants between the islands' populations, which must be sorted by fitness. Emigrants are all chosen
+// before any immigrants arrive, and the elite (0-index) individual of each island is never replaced.
+func Migrate(pops []Population, config IslandConfig, direction f.Direction, r *rand.Rand) {
+	// Choose emigrants from each island
+	emigrants := make([][]Individual, len(pops))
+	for i := 0; i < len(pops); i++ {
+		for _, idx := range selectMigrants(len(pops[i]), config.Migrants, config.Emigrants, 0, r) {
+			emigrant := pops[i][idx]
+			emigrant.Genes = append([]uint16(nil), emigrant.Genes...)
+			emigrants[i] = append(emigrants[i], emigrant)
+		}
+	}
+
+	// Gather immigrants arriving on each island
+	immigrants := make([][]Individual, len(pops))
+	for i := 0; i < len(pops); i++ {
+		for _, dest := range Destinations(i, len(pops), config.Topology, r) {
+			immigrants[dest] = append(immigrants[dest], emigrants[i]...)
+		}
+	}
+
+	// Replace individuals with immigrants
+	for i := 0; i < len(pops); i++ {
+		arrivals := immigrants[i]
+		if len(arrivals) > len(pops[i])-1 {
+			arrivals = arrivals[:len(pops[i])-1]
+		}
+		for m, idx := range selectMigrants(len(pops[i]), len(arrivals), config.Replacement, 1, r) {
+			pops[i][idx] = arrivals[m]
+		}
+		pops[i].SortFitness(direction)
+	}
+}
+
+// selectMigrants chooses n distinct indexes from a sorted population of popSize, never choosing indexes below min
+func selectMigrants(popSize int, n int, selection MigrantSelection, min int, r *rand.Rand) []int {
+	if n > popSize-min {
+		n = popSize - min
+	}
+	indexes := make([]int, n)
+	switch selection {
+	case SelectBest:
+		for m := 0; m < n; m++ {
+			indexes[m] = min + m
+		}
+	case SelectWorst:
+		for m := 0; m < n; m++ {
+			indexes[m] = popSize - 1 - m
+		}
+	case SelectRandom:
+		perm := r.Perm(popSize - min)
+		for m := 0; m < n; m++ {
+			indexes[m] = min + perm[m]
+		}
+	}
+	return indexes
+}
+
+// Destinations gets the islands that island i sends migrants to, out of n islands
+func Destinations(i int, n int, topology Topology, r *rand.Rand) []int {
+	if n < 2 {
+		return nil
+	}
+
+	switch topology {
+	case FullyConnected:
+		var dests []int
+		for j := 0; j < n; j++ {
+			if j != i {
+				dests = append(dests, j)
+			}
+		}
+		return dests
+	case RandomTopology:
+		dest := r.Intn(n - 1)
+		if dest >= i {
+			dest++
+		}
+		return []int{dest}
+	case Torus:
+		rows, cols := GridShape(n)
+		row, col := i/cols, i%cols
+		neighbours := []int{
+			((row+rows-1)%rows)*cols + col, // Up
+			((row+1)%rows)*cols + col,      // Down
+			row*cols + (col+cols-1)%cols,   // Left
+			row*cols + (col+1)%cols,        // Right
+		}
+		// Small grids wrap around onto the same neighbour (or the island itself) more than once
+		var dests []int
+		for _, neighbour := range neighbours {
+			if neighbour != i && !containsInt(dests, neighbour) {
+				dests = append(dests, neighbour)
+			}
+		}
+		return dests
+	}
+	return []int{(i + 1) % n}
+}
+
+// containsInt checks if the slice contains the value
+func containsInt(slice []int, value int) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GridShape finds the most square grid of rows x cols holding exactly n cells
+func GridShape(n int) (int, int) {
+	rows := int(math.Sqrt(float64(n)))
+	for n%rows != 0 {
+		rows--
+	}
+	return rows, n / rows
+}
@@ -0,0 +1,121 @@
+package ga
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetTopology(t *testing.T) {
+	topology, err := GetTopology("torus")
+	assert.Nil(t, err, "GetTopology should find torus")
+	assert.Equal(t, Torus, topology, "GetTopology returned wrong topology")
+
+	_, err = GetTopology("invalid")
+	assert.NotNil(t, err, "GetTopology should error for unknown topology")
+}
+
+func TestDestinations(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	assert.Equal(t, []int{0}, Destinations(3, 4, Ring, r), "Ring should send to next island, wrapping around")
+	assert.Equal(t, []int{0, 1, 3}, Destinations(2, 4, FullyConnected, r), "Fully connected should send to every other island")
+
+	// Islands 0-5 on a 2x3 torus, island 4 is at row 1, column 1
+	assert.ElementsMatch(t, []int{1, 3, 5}, Destinations(4, 6, Torus, r), "Torus should send to neighbouring islands")
+	// Islands 0-8 on a 3x3 torus, island 0 wraps around to the last row and column
+	assert.ElementsMatch(t, []int{6, 3, 2, 1}, Destinations(0, 9, Torus, r), "Torus should wrap around grid edges")
+
+	for i := 0; i < 100; i++ {
+		dests := Destinations(1, 4, RandomTopology, r)
+		assert.Equal(t, 1, len(dests), "Random topology should send to one island")
+		assert.NotEqual(t, 1, dests[0], "Random topology should not send to own island")
+	}
+
+	assert.Nil(t, Destinations(0, 1, Ring, r), "A single island should not send migrants")
+}
+
+func TestMigrate(t *testing.T) {
+	pops := []Population{
+		{
+			Individual{[]uint16{1}, 1, 0, 0},
+			Individual{[]uint16{2}, 2, 0, 0},
+			Individual{[]uint16{3}, 3, 0, 0},
+		},
+		{
+			Individual{[]uint16{10}, 10, 0, 0},
+			Individual{[]uint16{20}, 20, 0, 0},
+			Individual{[]uint16{30}, 30, 0, 0},
+		},
+	}
+	config := IslandConfig{Migrants: 1, Topology: Ring, Emigrants: SelectBest, Replacement: SelectWorst}
+
+	Migrate(pops, config, f.Minimise, rand.New(rand.NewSource(0)))
+
+	assert.Equal(t, Population{
+		Individual{[]uint16{1}, 1, 0, 0},
+		Individual{[]uint16{2}, 2, 0, 0},
+		Individual{[]uint16{10}, 10, 0, 0},
+	}, pops[0], "Best individual of island 1 should replace worst of island 0")
+	assert.Equal(t, Population{
+		Individual{[]uint16{1}, 1, 0, 0},
+		Individual{[]uint16{10}, 10, 0, 0},
+		Individual{[]uint16{20}, 20, 0, 0},
+	}, pops[1], "Best individual of island 0 should replace worst of island 1")
+
+	// Migrants should be copies, not sharing genes with their source island
+	pops[1][0].Genes[0] = 99
+	assert.Equal(t, uint16(1), pops[0][0].Genes[0], "Migrant genes should be copied")
+}
+
+// TestMigrate_KeepsElite ensures more immigrants than the population can hold never replace the elite
+func TestMigrate_KeepsElite(t *testing.T) {
+	pops := []Population{
+		{Individual{[]uint16{1}, 1, 0, 0}, Individual{[]uint16{2}, 2, 0, 0}},
+		{Individual{[]uint16{10}, 10, 0, 0}, Individual{[]uint16{20}, 20, 0, 0}},
+		{Individual{[]uint16{30}, 30, 0, 0}, Individual{[]uint16{40}, 40, 0, 0}},
+	}
+	config := IslandConfig{Migrants: 2, Topology: FullyConnected, Emigrants: SelectBest, Replacement: SelectRandom}
+
+	Migrate(pops, config, f.Minimise, rand.New(rand.NewSource(0)))
+
+	assert.Equal(t, 2, len(pops[2]), "Migration should not change population size")
+	assert.Equal(t, uint16(1), pops[2][0].Genes[0], "Immigrant should replace non-elite individual")
+	assert.Equal(t, uint16(30), pops[2][1].Genes[0], "Elite should not be replaced")
+}
+
+func TestRunIslands(t *testing.T) {
+	config := IslandConfig{Islands: 4, MigrationInterval: 5, Migrants: 2, Topology: Ring, Emigrants: SelectBest, Replacement: SelectWorst}
+	history, best, genes := RunIslands(0, 20, 10, f.SchwefelN, f.Schwefel, f.Minimise, f.SchwefelMutationP, config)
+
+	assert.Equal(t, f.SchwefelN, len(genes), "Best genes should assign every variable")
+	assert.InDelta(t, f.Schwefel(genes), best, 0.0001, "Best fitness should match best genes")
+	assert.Equal(t, best, history[len(history)-1].Fitness, "Fitness history should end with best fitness")
+}
+
+// TestRunIslands_Budget ensures islands stop evolving mid-epoch once the evaluation budget is spent
+func TestRunIslands_Budget(t *testing.T) {
+	var calls int64
+	function := func(genes []uint16) float64 {
+		atomic.AddInt64(&calls, 1)
+		return f.Schwefel(genes)
+	}
+	config := IslandConfig{Islands: 4, MigrationInterval: 50, Migrants: 2, Topology: Ring, Emigrants: SelectBest, Replacement: SelectWorst}
+	RunIslands(100, 0, 10, f.SchwefelN, function, f.Minimise, f.SchwefelMutationP, config)
+
+	// Initial populations, the rest of the budget, then at most one more generation on each island. Crossover also
+	// evaluates up to two offspring for each individual, which the budget does not count.
+	assert.LessOrEqual(t, calls, int64(4*10+3*(100-4*10+4*10)), "Islands should stop once the evaluation budget is spent")
+}
+
+// TestRunIslands_Guard ensures too few islands or too short a migration interval still make progress
+func TestRunIslands_Guard(t *testing.T) {
+	config := IslandConfig{Islands: 0, MigrationInterval: 0, Migrants: 2, Topology: Torus, Emigrants: SelectBest, Replacement: SelectWorst}
+	history, best, genes := RunIslands(100, 0, 10, f.SchwefelN, f.Schwefel, f.Minimise, f.SchwefelMutationP, config)
+
+	assert.Equal(t, f.SchwefelN, len(genes), "Best genes should assign every variable")
+	assert.Equal(t, 10, history[0].X, "Initial population should count against the evaluation budget")
+	assert.Equal(t, best, history[len(history)-1].Fitness, "Fitness history should end with best fitness")
+}
@@ -0,0 +1,315 @@
+package ga
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// NichingMethod sets how the GA maintains individuals on several optima at once
+type NichingMethod int
+
+const (
+	Sharing               NichingMethod = iota // Fitness sharing, dividing scaled fitness by the crowdedness of each niche
+	Clearing                                   // Clearing, only the best Capacity individuals of each niche keep their scaled fitness
+	DeterministicCrowding                      // Offspring replace the closest of their two parents, if at least as fit
+	RestrictedTournament                       // Offspring replace the closest of Window random individuals, if at least as fit
+)
+
+// DistanceSpace sets where the distance between individuals is measured
+type DistanceSpace int
+
+const (
+	Genotype  DistanceSpace = iota // Hamming distance between the bits of the genes
+	Phenotype                      // Euclidean distance between the decoded variables
+)
+
+// NichingConfig configures the niching GA
+type NichingConfig struct {
+	Method   NichingMethod
+	Space    DistanceSpace
+	Bounds   f.Bounds // Bounds used to decode genes for Phenotype distances
+	Sigma    float64  // Niche radius, individuals closer than Sigma share a niche
+	Alpha    float64  // Sharing: shape of the sharing function, 1 is triangular
+	Capacity int      // Clearing: individuals in each niche that keep their fitness
+	Window   int      // RestrictedTournament: individuals compared with each offspring
+
+	// Niche seeds within OptimaTolerance of the best fitness count as located optima, negative counts every seed
+	OptimaTolerance float64
+}
+
+// GetNichingMethod gets a NichingMethod by name
+func GetNichingMethod(name string) (NichingMethod, error) {
+	switch name {
+	case "sharing":
+		return Sharing, nil
+	case "clearing":
+		return Clearing, nil
+	case "crowding":
+		return DeterministicCrowding, nil
+	case "rts":
+		return RestrictedTournament, nil
+	}
+
+	return Sharing, errors.New("invalid niching method passed to GetNichingMethod")
+}
+
+// GetDistanceSpace gets a DistanceSpace by name
+func GetDistanceSpace(name string) (DistanceSpace, error) {
+	switch name {
+	case "genotype":
+		return Genotype, nil
+	case "phenotype":
+		return Phenotype, nil
+	}
+
+	return Genotype, errors.New("invalid distance space passed to GetDistanceSpace")
+}
+
+// DefaultNicheRadius gets a niche radius of a tenth of the largest possible distance between two individuals
+func DefaultNicheRadius(space DistanceSpace, N int, bounds f.Bounds) float64 {
+	if space == Phenotype {
+		return 0.1 * bounds.Width() * math.Sqrt(float64(N))
+	}
+	return 0.1 * float64(16*N)
+}
+
+// Distance measures the distance between two individuals' genes in the configured space
+func (config NichingConfig) Distance(a []uint16, b []uint16) float64 {
+	if config.Space == Phenotype {
+		var sum float64
+		for i := 0; i < len(a); i++ {
+			d := config.Bounds.Decode(a[i]) - config.Bounds.Decode(b[i])
+			sum += d * d
+		}
+		return math.Sqrt(sum)
+	}
+
+	var hamming int
+	for i := 0; i < len(a); i++ {
+		hamming += bits.OnesCount16(a[i] ^ b[i])
+	}
+	return float64(hamming)
+}
+
+// RunNiching runs the GA with a niching method, returning the number of distinct optima located by the final
+// population along with the usual results
+func RunNiching(evaluations int, generations int, popSize int, N int, function f.Fitness, direction f.Direction, mutationP float32, config NichingConfig) ([]chart.BestFitness, float64, []uint16, int) {
+	bestFitness := direction.Worst()
+	var evals int
+	var fMax float64 // Scaling Window f'max as per https://ieeexplore.ieee.org/document/4075583
+	var bestGenes []uint16
+	var worstFitnessHistory []float64 // Track worst fitness for each generation
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise GA's population
+	population := InitPopulation(N, popSize, r.Int63())
+	evals += population.EvalFitness(function, 0)
+	population.SortFitness(direction)
+	bestFitness, bestGenes = population[0].Fitness, append([]uint16(nil), population[0].Genes...)
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+	fMax = population[len(population)-1].Fitness // Set initial value of f'max
+	population.Niche(config, fMax)
+
+	doGeneration := func(gen int) {
+		switch config.Method {
+		case Sharing, Clearing:
+			// Standard GA generation, with roulette selection on the niched scaled fitness
+			population.Crossover(CrossoverP, function, direction)
+			population.Mutate(mutationP)
+			evals += population.EvalFitness(function, fMax)
+		case DeterministicCrowding:
+			evals += population.Crowd(function, direction, mutationP, config, r)
+		case RestrictedTournament:
+			evals += population.RestrictedTournament(function, direction, mutationP, config, r)
+		}
+		population.SortFitness(direction)
+
+		// Finds individual with best fitness & genes in this generation
+		if direction.Better(population[0].Fitness, bestFitness) {
+			bestFitness = population[0].Fitness
+			bestGenes = append([]uint16(nil), population[0].Genes...)
+			if gen != 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+			} else {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+			}
+		}
+		worstFitnessHistory = append(worstFitnessHistory, population[len(population)-1].Fitness)
+		fMax = common.CalculateFMax(worstFitnessHistory, W, direction)
+		population.Niche(config, fMax)
+	}
+
+	if evaluations != 0 {
+		// Run niching GA for N function evaluations
+		for evals < evaluations {
+			doGeneration(0)
+		}
+	} else if generations != 0 {
+		// Run niching GA for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenes, population.DistinctOptima(config, direction)
+}
+
+// Niche rescales the population's ScaledFitness with fitness sharing or clearing, other methods are left unscaled.
+// The population must be sorted by fitness.
+func (pop Population) Niche(config NichingConfig, fMax float64) {
+	for i := range pop {
+		pop[i].ScaledFitness = math.Abs(fMax - pop[i].Fitness)
+	}
+	switch config.Method {
+	case Sharing:
+		pop.Share(config)
+	case Clearing:
+		pop.Clear(config)
+	}
+}
+
+// Share divides each individual's ScaledFitness by its niche count, the sum of the sharing function
+// sh(d) = 1 - (d/Sigma)^Alpha over every individual within Sigma of it (including itself)
+func (pop Population) Share(config NichingConfig) {
+	nicheCounts := make([]float64, len(pop))
+	for i := range pop {
+		for j := range pop {
+			if d := config.Distance(pop[i].Genes, pop[j].Genes); d < config.Sigma {
+				nicheCounts[i] += 1 - math.Pow(d/config.Sigma, config.Alpha)
+			}
+		}
+	}
+	for i := range pop {
+		pop[i].ScaledFitness /= nicheCounts[i]
+	}
+}
+
+// Clear sets the ScaledFitness of every individual to zero, other than the best Capacity individuals within Sigma of
+// each niche's best individual. The population must be sorted by fitness.
+func (pop Population) Clear(config NichingConfig) {
+	cleared := make([]bool, len(pop))
+	for i := range pop {
+		if cleared[i] {
+			continue
+		}
+		// Individual i is the best of a new niche
+		winners := 1
+		for j := i + 1; j < len(pop); j++ {
+			if !cleared[j] && config.Distance(pop[i].Genes, pop[j].Genes) < config.Sigma {
+				if winners < config.Capacity {
+					winners++
+				} else {
+					cleared[j] = true
+					pop[j].ScaledFitness = 0
+				}
+			}
+		}
+	}
+}
+
+// Crowd performs a generation of deterministic crowding. Parents are paired at random, and each pair's two offspring
+// replace the closest parent if they are at least as fit.
+// Return number of fitness evaluations
+func (pop Population) Crowd(function f.Fitness, direction f.Direction, mutationP float32, config NichingConfig, r *rand.Rand) int {
+	evals := 0
+	order := r.Perm(len(pop))
+	for k := 0; k+1 < len(order); k += 2 {
+		a, b := order[k], order[k+1]
+		childA, childB := pop.breedPair(a, b, function, mutationP, r)
+		evals += 2
+
+		// Pair offspring with the parents they are closest to overall
+		if config.Distance(pop[a].Genes, childA.Genes)+config.Distance(pop[b].Genes, childB.Genes) >
+			config.Distance(pop[a].Genes, childB.Genes)+config.Distance(pop[b].Genes, childA.Genes) {
+			childA, childB = childB, childA
+		}
+		if !direction.Better(pop[a].Fitness, childA.Fitness) {
+			pop[a] = childA
+		}
+		if !direction.Better(pop[b].Fitness, childB.Fitness) {
+			pop[b] = childB
+		}
+	}
+	return evals
+}
+
+// RestrictedTournament performs a generation of restricted tournament selection as per Harik (ICGA 1995). Random pairs
+// of parents breed, and each offspring replaces the closest of Window randomly picked individuals if at least as fit.
+// Return number of fitness evaluations
+func (pop Population) RestrictedTournament(function f.Fitness, direction f.Direction, mutationP float32, config NichingConfig, r *rand.Rand) int {
+	evals := 0
+	for k := 0; k < len(pop)/2; k++ {
+		childA, childB := pop.breedPair(r.Intn(len(pop)), r.Intn(len(pop)), function, mutationP, r)
+		evals += 2
+
+		for _, child := range []Individual{childA, childB} {
+			closest := -1
+			closestDistance := math.MaxFloat64
+			for w := 0; w < config.Window; w++ {
+				i := r.Intn(len(pop))
+				if d := config.Distance(pop[i].Genes, child.Genes); d < closestDistance {
+					closest, closestDistance = i, d
+				}
+			}
+			if closest >= 0 && !direction.Better(pop[closest].Fitness, child.Fitness) {
+				pop[closest] = child
+			}
+		}
+	}
+	return evals
+}
+
+// breedPair creates two offspring from the individuals at a and b by two-point crossover with probability CrossoverP,
+// followed by mutation, and evaluates them
+func (pop Population) breedPair(a int, b int, function f.Fitness, mutationP float32, r *rand.Rand) (Individual, Individual) {
+	genesA := append([]uint16(nil), pop[a].Genes...)
+	genesB := append([]uint16(nil), pop[b].Genes...)
+	if r.Float32() < CrossoverP {
+		for g := 0; g < len(genesA); g++ {
+			genesA[g], genesB[g] = common.TwoPointCrossover(genesA[g], genesB[g])
+		}
+	}
+	mutateGenes(genesA, mutationP, r)
+	mutateGenes(genesB, mutationP, r)
+	return Individual{Genes: genesA, Fitness: function(genesA)}, Individual{Genes: genesB, Fitness: function(genesB)}
+}
+
+// DistinctOptima counts the distinct optima located by the population. Taking individuals from fittest to least fit,
+// each individual further than Sigma from every fitter seed becomes the seed of a new niche. Seeds within
+// OptimaTolerance of the best fitness are counted, or every seed if OptimaTolerance is negative.
+func (pop Population) DistinctOptima(config NichingConfig, direction f.Direction) int {
+	sorted := append(Population(nil), pop...)
+	sort.SliceStable(sorted, func(i, j int) bool { return direction.Better(sorted[i].Fitness, sorted[j].Fitness) })
+
+	var seeds []Individual
+	for _, ind := range sorted {
+		isSeed := true
+		for _, seed := range seeds {
+			if config.Distance(seed.Genes, ind.Genes) < config.Sigma {
+				isSeed = false
+				break
+			}
+		}
+		if isSeed {
+			seeds = append(seeds, ind)
+		}
+	}
+
+	optima := 0
+	for _, seed := range seeds {
+		if config.OptimaTolerance < 0 || math.Abs(seed.Fitness-sorted[0].Fitness) <= config.OptimaTolerance {
+			optima++
+		}
+	}
+	return optima
+}
@@ -0,0 +1,119 @@
+package ga
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGetNichingMethod(t *testing.T) {
+	method, err := GetNichingMethod("rts")
+	assert.Nil(t, err, "GetNichingMethod should find rts")
+	assert.Equal(t, RestrictedTournament, method, "GetNichingMethod returned wrong niching method")
+
+	_, err = GetNichingMethod("invalid")
+	assert.NotNil(t, err, "GetNichingMethod should error for unknown niching method")
+}
+
+func TestGetDistanceSpace(t *testing.T) {
+	space, err := GetDistanceSpace("phenotype")
+	assert.Nil(t, err, "GetDistanceSpace should find phenotype")
+	assert.Equal(t, Phenotype, space, "GetDistanceSpace returned wrong distance space")
+
+	_, err = GetDistanceSpace("invalid")
+	assert.NotNil(t, err, "GetDistanceSpace should error for unknown distance space")
+}
+
+func TestNichingConfig_Distance(t *testing.T) {
+	genotype := NichingConfig{Space: Genotype}
+	assert.Equal(t, 0.0, genotype.Distance([]uint16{5, 7}, []uint16{5, 7}), "Identical genes should have no distance")
+	assert.Equal(t, 3.0, genotype.Distance([]uint16{0b101, 0}, []uint16{0, 0b1}), "Hamming distance should count differing bits")
+
+	phenotype := NichingConfig{Space: Phenotype, Bounds: f.Bounds{Min: 0, Max: 65535}}
+	assert.InDelta(t, 5.0, phenotype.Distance([]uint16{0, 0}, []uint16{3, 4}), 1e-9, "Phenotype distance should be Euclidean")
+}
+
+func TestDefaultNicheRadius(t *testing.T) {
+	assert.InDelta(t, 3.2, DefaultNicheRadius(Genotype, 2, f.Bounds{}), 1e-9, "Genotype radius should be a tenth of the bits")
+	assert.InDelta(t, 2.0, DefaultNicheRadius(Phenotype, 4, f.Bounds{Min: -5, Max: 5}), 1e-9, "Phenotype radius should be a tenth of the diagonal")
+}
+
+func TestPopulation_Share(t *testing.T) {
+	config := NichingConfig{Method: Sharing, Space: Genotype, Sigma: 4, Alpha: 1}
+	pop := Population{
+		Individual{[]uint16{0}, 1, 0, 0},
+		Individual{[]uint16{0}, 1, 0, 0},
+		Individual{[]uint16{0xFFFF}, 1, 0, 0},
+	}
+	pop.Niche(config, 5)
+	assert.Equal(t, 2.0, pop[0].ScaledFitness, "Individuals sharing a niche should share their scaled fitness")
+	assert.Equal(t, 2.0, pop[1].ScaledFitness, "Individuals sharing a niche should share their scaled fitness")
+	assert.Equal(t, 4.0, pop[2].ScaledFitness, "Individual alone in its niche should keep its scaled fitness")
+}
+
+func TestPopulation_Clear(t *testing.T) {
+	config := NichingConfig{Method: Clearing, Space: Genotype, Sigma: 4, Capacity: 1}
+	pop := Population{
+		Individual{[]uint16{0}, 1, 0, 0},
+		Individual{[]uint16{1}, 2, 0, 0},
+		Individual{[]uint16{0xFFFF}, 3, 0, 0},
+	}
+	pop.Niche(config, 5)
+	assert.Equal(t, 4.0, pop[0].ScaledFitness, "Best of a niche should keep its scaled fitness")
+	assert.Equal(t, 0.0, pop[1].ScaledFitness, "Others in a full niche should be cleared")
+	assert.Equal(t, 2.0, pop[2].ScaledFitness, "Best of a niche should keep its scaled fitness")
+}
+
+func TestPopulation_Crowd(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	config := NichingConfig{Method: DeterministicCrowding, Space: Genotype}
+	pop := InitPopulation(f.RastriginN, 10, 0)
+	pop.EvalFitness(f.Rastrigin, 0)
+	before := append(Population(nil), pop...)
+
+	evals := pop.Crowd(f.Rastrigin, f.Minimise, 0.01, config, r)
+	assert.Equal(t, 10, evals, "Each offspring should be evaluated")
+	for i := range pop {
+		assert.LessOrEqual(t, pop[i].Fitness, before[i].Fitness, "Parents should only be replaced by fitter offspring")
+	}
+}
+
+func TestPopulation_RestrictedTournament(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	config := NichingConfig{Method: RestrictedTournament, Space: Genotype, Window: 4}
+	pop := InitPopulation(f.RastriginN, 10, 0)
+	pop.EvalFitness(f.Rastrigin, 0)
+	before := append(Population(nil), pop...)
+
+	evals := pop.RestrictedTournament(f.Rastrigin, f.Minimise, 0.01, config, r)
+	assert.Equal(t, 10, evals, "Each offspring should be evaluated")
+	for i := range pop {
+		assert.LessOrEqual(t, pop[i].Fitness, before[i].Fitness, "Individuals should only be replaced by fitter offspring")
+	}
+}
+
+func TestPopulation_DistinctOptima(t *testing.T) {
+	config := NichingConfig{Space: Genotype, Sigma: 4}
+	pop := Population{
+		Individual{[]uint16{0}, 0, 0, 0},
+		Individual{[]uint16{1}, 0.5, 0, 0},
+		Individual{[]uint16{0xFFFF}, 0, 0, 0},
+		Individual{[]uint16{0x00FF}, 9, 0, 0},
+	}
+	assert.Equal(t, 2, pop.DistinctOptima(config, f.Minimise), "Only seeds as fit as the best should count")
+	config.OptimaTolerance = -1
+	assert.Equal(t, 3, pop.DistinctOptima(config, f.Minimise), "Every niche seed should count with a negative tolerance")
+}
+
+func TestRunNiching(t *testing.T) {
+	for _, method := range []NichingMethod{Sharing, Clearing, DeterministicCrowding, RestrictedTournament} {
+		config := NichingConfig{Method: method, Space: Genotype, Sigma: 32, Alpha: 1, Capacity: 1, Window: 5, OptimaTolerance: -1}
+		history, best, genes, optima := RunNiching(5000, 0, 20, f.RastriginN, f.Rastrigin, f.Minimise, f.RastriginMutationP, config)
+		assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+		assert.GreaterOrEqual(t, optima, 1, "At least one optimum should be found")
+		for i := 1; i < len(history); i++ {
+			assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package genome
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+)
+
+// Bits is a binary-coded genome with Width bits per variable, from 1 to 64. Each variable is held in the lowest Width
+// bits of a uint64 and decoded linearly within the bounds, so precision grows with Width.
+type Bits struct {
+	Values []uint64
+	Width  int
+	Bounds f.Bounds
+}
+
+// NewBits creates a Bits genome of N random variables of width bits
+func NewBits(N int, width int, bounds f.Bounds, r *rand.Rand) *Bits {
+	b := &Bits{Values: make([]uint64, N), Width: width, Bounds: bounds}
+	for i := range b.Values {
+		b.Values[i] = r.Uint64() & b.MaxValue()
+	}
+	return b
+}
+
+// FromUint16 creates a 16-bit Bits genome holding the same variables as uint16 genes
+func FromUint16(genes []uint16, bounds f.Bounds) *Bits {
+	b := &Bits{Values: make([]uint64, len(genes)), Width: 16, Bounds: bounds}
+	for i, gene := range genes {
+		b.Values[i] = uint64(gene)
+	}
+	return b
+}
+
+// MaxValue is the largest value of a variable, with every bit set
+func (b *Bits) MaxValue() uint64 {
+	if b.Width >= 64 {
+		return math.MaxUint64
+	}
+	return 1<<uint(b.Width) - 1
+}
+
+// Decode scales each variable to its real value within the bounds
+func (b *Bits) Decode() []float64 {
+	x := make([]float64, len(b.Values))
+	max := float64(b.MaxValue())
+	for i, v := range b.Values {
+		x[i] = float64(v)/max*b.Bounds.Width() + b.Bounds.Min
+	}
+	return x
+}
+
+// Encode sets each variable to the nearest value to x, values outside the bounds are clamped
+func (b *Bits) Encode(x []float64) {
+	max := float64(b.MaxValue())
+	for i := range b.Values {
+		v := math.Max(0, math.Min(max, math.Round((x[i]-b.Bounds.Min)/b.Bounds.Width()*max)))
+		if v >= max {
+			// Converting math.MaxUint64 as a float64 overflows
+			b.Values[i] = b.MaxValue()
+		} else {
+			b.Values[i] = uint64(v)
+		}
+	}
+}
+
+// Mutate flips each bit with probability p
+func (b *Bits) Mutate(p float64, r *rand.Rand) {
+	for i := range b.Values {
+		for bit := 0; bit < b.Width; bit++ {
+			if r.Float64() < p {
+				b.Values[i] ^= 1 << uint(bit)
+			}
+		}
+	}
+}
+
+// Crossover performs two-point crossover on the genomes' bit strings, cut points may fall within variables
+func (b *Bits) Crossover(other Genome, r *rand.Rand) (Genome, Genome) {
+	childA, childB := b.Copy().(*Bits), other.Copy().(*Bits)
+	start, end := twoPointCuts(len(b.Values)*b.Width, r)
+	for pos := start; pos < end; pos++ {
+		i, mask := pos/b.Width, uint64(1)<<uint(pos%b.Width)
+		if (childA.Values[i]^childB.Values[i])&mask != 0 {
+			childA.Values[i] ^= mask
+			childB.Values[i] ^= mask
+		}
+	}
+	return childA, childB
+}
+
+// Copy creates a copy of the genome
+func (b *Bits) Copy() Genome {
+	return &Bits{Values: append([]uint64(nil), b.Values...), Width: b.Width, Bounds: b.Bounds}
+}
+
+// Uint16 gets the variables as uint16 genes, keeping the 16 most significant bits of each (or padding narrower
+// variables), so the genes decode to approximately the same values
+func (b *Bits) Uint16() []uint16 {
+	genes := make([]uint16, len(b.Values))
+	for i, v := range b.Values {
+		if b.Width >= 16 {
+			genes[i] = uint16(v >> uint(b.Width-16))
+		} else {
+			genes[i] = uint16(math.Round(float64(v) / float64(b.MaxValue()) * 65535))
+		}
+	}
+	return genes
+}
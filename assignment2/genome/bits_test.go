@@ -0,0 +1,68 @@
+package genome
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBits_MaxValue(t *testing.T) {
+	assert.Equal(t, uint64(1), (&Bits{Width: 1}).MaxValue(), "1-bit variables have a largest value of 1")
+	assert.Equal(t, uint64(65535), (&Bits{Width: 16}).MaxValue(), "16-bit variables have a largest value of 65535")
+	assert.Equal(t, uint64(math.MaxUint64), (&Bits{Width: 64}).MaxValue(), "64-bit variables use every bit")
+}
+
+func TestNewBits(t *testing.T) {
+	b := NewBits(50, 5, f.Bounds{}, rand.New(rand.NewSource(0)))
+	for _, v := range b.Values {
+		assert.LessOrEqual(t, v, uint64(31), "Variables should only use Width bits")
+	}
+}
+
+func TestBits_DecodeEncode(t *testing.T) {
+	bounds := f.Bounds{Min: -5, Max: 5}
+	for _, width := range []int{1, 8, 16, 32, 64} {
+		b := &Bits{Values: make([]uint64, 3), Width: width, Bounds: bounds}
+		b.Encode([]float64{-5, 5, 10})
+		assert.Equal(t, []float64{-5, 5, 5}, b.Decode(), "Bounds should be encoded exactly, and values outside clamped")
+	}
+
+	b := &Bits{Values: make([]uint64, 1), Width: 32, Bounds: bounds}
+	b.Encode([]float64{1.2345678})
+	assert.InDelta(t, 1.2345678, b.Decode()[0], 1e-8, "32-bit variables should be more precise than 16-bit genes")
+}
+
+func TestFromUint16(t *testing.T) {
+	bounds := f.Bounds{Min: -5.12, Max: 5.12}
+	genes := []uint16{0, 12345, 65535}
+	b := FromUint16(genes, bounds)
+	assert.Equal(t, bounds.DecodeAll(genes), b.Decode(), "16-bit genome should decode like uint16 genes")
+	assert.Equal(t, genes, b.Uint16(), "16-bit genome should convert back to the same genes")
+
+	wide := &Bits{Values: []uint64{0xFFFFFFFF, 0x80000000}, Width: 32}
+	assert.Equal(t, []uint16{0xFFFF, 0x8000}, wide.Uint16(), "Wider variables should keep their most significant bits")
+}
+
+func TestBits_Mutate(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	b := &Bits{Values: []uint64{0, 0}, Width: 12}
+	b.Mutate(0, r)
+	assert.Equal(t, []uint64{0, 0}, b.Values, "No bits should flip with zero probability")
+	b.Mutate(1, r)
+	assert.Equal(t, []uint64{0xFFF, 0xFFF}, b.Values, "Every bit within Width should flip with probability one")
+}
+
+func TestBits_Crossover(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	a := &Bits{Values: []uint64{0, 0, 0}, Width: 7}
+	b := &Bits{Values: []uint64{0x7F, 0x7F, 0x7F}, Width: 7}
+	for i := 0; i < 20; i++ {
+		childA, childB := a.Crossover(b, r)
+		for v := 0; v < 3; v++ {
+			assert.Equal(t, uint64(0x7F), childA.(*Bits).Values[v]^childB.(*Bits).Values[v], "Offspring should be complementary")
+		}
+	}
+	assert.Equal(t, []uint64{0, 0, 0}, a.Values, "Parents should be unchanged")
+}
@@ -0,0 +1,89 @@
+package genome
+
+import (
+	"errors"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+)
+
+// Genome is the genetic representation of a candidate solution. Variation operators act on the genome in place
+// (Mutate) or create new genomes (Crossover), so algorithms can evolve any representation. Only the standard GA
+// (ga.RunGenome) evolves genomes, the other algorithms keep 16-bit genes.
+type Genome interface {
+	Mutate(p float64, r *rand.Rand)                        // Mutate each locus with probability p
+	Crossover(other Genome, r *rand.Rand) (Genome, Genome) // Recombine with a genome of the same kind and length
+	Copy() Genome
+}
+
+// Vector is a Genome of numeric variables, which can be decoded to (and encoded from) real values
+type Vector interface {
+	Genome
+	Decode() []float64
+	Encode(x []float64)
+}
+
+// Fitness is an optimisation function evaluated on a genome
+type Fitness func(g Genome) float64
+
+// Kind sets the representation of a genome
+type Kind int
+
+const (
+	BitsKind        Kind = iota // Binary-coded variables of 1 to 64 bits each
+	RealKind                    // Real-valued variables
+	IntegerKind                 // Integer variables
+	PermutationKind             // A permutation of the integers 0 to N-1
+)
+
+// GetKind gets a Kind by name
+func GetKind(name string) (Kind, error) {
+	switch name {
+	case "bits":
+		return BitsKind, nil
+	case "real":
+		return RealKind, nil
+	case "integer":
+		return IntegerKind, nil
+	case "permutation":
+		return PermutationKind, nil
+	}
+
+	return BitsKind, errors.New("invalid genome kind passed to GetKind")
+}
+
+// New creates a random genome of N variables of the kind. Bits and real variables lie within the bounds, integer
+// variables are the integers within the bounds. width is the bits per variable of a Bits genome.
+func New(kind Kind, N int, width int, bounds f.Bounds, r *rand.Rand) Genome {
+	switch kind {
+	case RealKind:
+		return NewReals(N, bounds, r)
+	case IntegerKind:
+		return NewIntegers(N, bounds, r)
+	case PermutationKind:
+		return NewPermutation(N, r)
+	}
+	return NewBits(N, width, bounds, r)
+}
+
+// Numeric adapts a function of real values to a Fitness of Vector genomes
+func Numeric(function f.RealFitness) Fitness {
+	return func(g Genome) float64 {
+		return function(g.(Vector).Decode())
+	}
+}
+
+// Ordering adapts a function of an ordering to a Fitness of Permutation genomes
+func Ordering(function func(order []int) float64) Fitness {
+	return func(g Genome) float64 {
+		return function(g.(*Permutation).Order)
+	}
+}
+
+// twoPointCuts picks two cut points 0 <= a <= b <= length
+func twoPointCuts(length int, r *rand.Rand) (int, int) {
+	a, b := r.Intn(length+1), r.Intn(length+1)
+	if a > b {
+		a, b = b, a
+	}
+	return a, b
+}
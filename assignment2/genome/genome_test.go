@@ -0,0 +1,45 @@
+package genome
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGetKind(t *testing.T) {
+	kind, err := GetKind("permutation")
+	assert.Nil(t, err, "GetKind should find permutation")
+	assert.Equal(t, PermutationKind, kind, "GetKind returned wrong genome kind")
+
+	_, err = GetKind("invalid")
+	assert.NotNil(t, err, "GetKind should error for unknown genome kind")
+}
+
+func TestNew(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -5, Max: 5}
+	assert.IsType(t, &Bits{}, New(BitsKind, 3, 24, bounds, r), "New should create a Bits genome")
+	assert.IsType(t, &Reals{}, New(RealKind, 3, 0, bounds, r), "New should create a Reals genome")
+	assert.IsType(t, &Integers{}, New(IntegerKind, 3, 0, bounds, r), "New should create an Integers genome")
+	assert.IsType(t, &Permutation{}, New(PermutationKind, 3, 0, bounds, r), "New should create a Permutation genome")
+}
+
+func TestNumeric(t *testing.T) {
+	fitness := Numeric(f.RastriginReal)
+	g := &Reals{Values: make([]float64, 4), Bounds: f.Bounds{Min: -5.12, Max: 5.12}}
+	assert.Equal(t, 0.0, fitness(g), "Numeric fitness should evaluate the decoded variables")
+}
+
+func TestOrdering(t *testing.T) {
+	fitness := Ordering(func(order []int) float64 { return float64(order[0]) })
+	assert.Equal(t, 2.0, fitness(&Permutation{Order: []int{2, 0, 1}}), "Ordering fitness should evaluate the order")
+}
+
+func TestTwoPointCuts(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		a, b := twoPointCuts(10, r)
+		assert.True(t, 0 <= a && a <= b && b <= 10, "Cut points should be ordered within the length")
+	}
+}
@@ -0,0 +1,59 @@
+package genome
+
+import "math/rand"
+
+// Permutation is a genome ordering the integers 0 to N-1, for problems such as scheduling or routing
+type Permutation struct {
+	Order []int
+}
+
+// NewPermutation creates a uniformly random Permutation of N elements
+func NewPermutation(N int, r *rand.Rand) *Permutation {
+	return &Permutation{Order: r.Perm(N)}
+}
+
+// Mutate swaps each element with probability p with another element at a random position
+func (g *Permutation) Mutate(p float64, r *rand.Rand) {
+	for i := range g.Order {
+		if r.Float64() < p {
+			j := r.Intn(len(g.Order))
+			g.Order[i], g.Order[j] = g.Order[j], g.Order[i]
+		}
+	}
+}
+
+// Crossover performs order crossover (OX) of Davis. Each offspring keeps the section of one parent between two cut
+// points, and fills its remaining positions with the missing elements in the order they appear in the other parent.
+func (g *Permutation) Crossover(other Genome, r *rand.Rand) (Genome, Genome) {
+	o := other.(*Permutation)
+	start, end := twoPointCuts(len(g.Order), r)
+	return orderCrossover(g.Order, o.Order, start, end), orderCrossover(o.Order, g.Order, start, end)
+}
+
+// orderCrossover creates an offspring keeping keep[start:end], filled from fill's order after end (wrapping around)
+func orderCrossover(keep []int, fill []int, start int, end int) *Permutation {
+	N := len(keep)
+	child := make([]int, N)
+	used := make([]bool, N)
+	for i := start; i < end; i++ {
+		child[i] = keep[i]
+		used[keep[i]] = true
+	}
+
+	pos := end % N
+	for k := 0; k < N; k++ {
+		element := fill[(end+k)%N]
+		if used[element] {
+			continue
+		}
+		child[pos] = element
+		used[element] = true
+		pos = (pos + 1) % N
+	}
+	return &Permutation{Order: child}
+}
+
+// Copy creates a copy of the genome
+func (g *Permutation) Copy() Genome {
+	return &Permutation{Order: append([]int(nil), g.Order...)}
+}
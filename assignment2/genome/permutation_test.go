@@ -0,0 +1,41 @@
+package genome
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// assertPermutation checks the order holds each of 0 to N-1 exactly once
+func assertPermutation(t *testing.T, order []int, N int) {
+	sorted := append([]int(nil), order...)
+	sort.Ints(sorted)
+	for i := 0; i < N; i++ {
+		assert.Equal(t, i, sorted[i], "Genome should remain a permutation")
+	}
+}
+
+func TestPermutation_Mutate(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	g := NewPermutation(10, r)
+	g.Mutate(1, r)
+	assertPermutation(t, g.Order, 10)
+}
+
+func TestPermutation_Crossover(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 50; i++ {
+		a, b := NewPermutation(8, r), NewPermutation(8, r)
+		childA, childB := a.Crossover(b, r)
+		assertPermutation(t, childA.(*Permutation).Order, 8)
+		assertPermutation(t, childB.(*Permutation).Order, 8)
+	}
+}
+
+func TestOrderCrossover(t *testing.T) {
+	keep := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	fill := []int{7, 6, 5, 4, 3, 2, 1, 0}
+	child := orderCrossover(keep, fill, 2, 5)
+	assert.Equal(t, []int{6, 5, 2, 3, 4, 1, 0, 7}, child.Order, "Section should be kept, the rest filled in the other parent's order after the second cut")
+}
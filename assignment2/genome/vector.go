@@ -0,0 +1,123 @@
+package genome
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+)
+
+const (
+	MutationSigma = 0.1 // Standard deviation of Gaussian mutation of real variables, as a fraction of the bounds' width
+	BlendAlpha    = 0.5 // How far blend crossover may extend beyond the parents' values, as a fraction of their distance
+)
+
+// Reals is a genome of real-valued variables within the bounds
+type Reals struct {
+	Values []float64
+	Bounds f.Bounds
+}
+
+// NewReals creates a Reals genome of N uniformly random variables within the bounds
+func NewReals(N int, bounds f.Bounds, r *rand.Rand) *Reals {
+	g := &Reals{Values: make([]float64, N), Bounds: bounds}
+	for i := range g.Values {
+		g.Values[i] = bounds.Min + r.Float64()*bounds.Width()
+	}
+	return g
+}
+
+// Decode gets the variables' values
+func (g *Reals) Decode() []float64 {
+	return append([]float64(nil), g.Values...)
+}
+
+// Encode sets the variables' values, values outside the bounds are clamped
+func (g *Reals) Encode(x []float64) {
+	for i := range g.Values {
+		g.Values[i] = math.Max(g.Bounds.Min, math.Min(g.Bounds.Max, x[i]))
+	}
+}
+
+// Mutate offsets each variable with probability p by a normally distributed value, repairing values leaving the bounds
+func (g *Reals) Mutate(p float64, r *rand.Rand) {
+	for i := range g.Values {
+		if r.Float64() < p {
+			g.Values[i] = g.Bounds.Repair(g.Values[i]+r.NormFloat64()*MutationSigma*g.Bounds.Width(), r)
+		}
+	}
+}
+
+// Crossover performs blend crossover (BLX-alpha) of Eshelman & Schaffer, sampling each variable of the offspring
+// uniformly from the parents' range extended by BlendAlpha on each side
+func (g *Reals) Crossover(other Genome, r *rand.Rand) (Genome, Genome) {
+	o := other.(*Reals)
+	childA, childB := g.Copy().(*Reals), o.Copy().(*Reals)
+	for i := range g.Values {
+		low, high := math.Min(g.Values[i], o.Values[i]), math.Max(g.Values[i], o.Values[i])
+		extent := BlendAlpha * (high - low)
+		childA.Values[i] = g.Bounds.Repair(low-extent+r.Float64()*(high-low+2*extent), r)
+		childB.Values[i] = g.Bounds.Repair(low-extent+r.Float64()*(high-low+2*extent), r)
+	}
+	return childA, childB
+}
+
+// Copy creates a copy of the genome
+func (g *Reals) Copy() Genome {
+	return &Reals{Values: append([]float64(nil), g.Values...), Bounds: g.Bounds}
+}
+
+// Integers is a genome of integer variables between Min and Max inclusive
+type Integers struct {
+	Values []int
+	Min    int
+	Max    int
+}
+
+// NewIntegers creates an Integers genome of N uniformly random variables, taking the integers within the bounds
+func NewIntegers(N int, bounds f.Bounds, r *rand.Rand) *Integers {
+	g := &Integers{Values: make([]int, N), Min: int(math.Ceil(bounds.Min)), Max: int(math.Floor(bounds.Max))}
+	for i := range g.Values {
+		g.Values[i] = g.Min + r.Intn(g.Max-g.Min+1)
+	}
+	return g
+}
+
+// Decode gets the variables' values
+func (g *Integers) Decode() []float64 {
+	x := make([]float64, len(g.Values))
+	for i, v := range g.Values {
+		x[i] = float64(v)
+	}
+	return x
+}
+
+// Encode sets each variable to the nearest integer to x, values outside the bounds are clamped
+func (g *Integers) Encode(x []float64) {
+	for i := range g.Values {
+		g.Values[i] = int(math.Max(float64(g.Min), math.Min(float64(g.Max), math.Round(x[i]))))
+	}
+}
+
+// Mutate resets each variable with probability p to a uniformly random integer
+func (g *Integers) Mutate(p float64, r *rand.Rand) {
+	for i := range g.Values {
+		if r.Float64() < p {
+			g.Values[i] = g.Min + r.Intn(g.Max-g.Min+1)
+		}
+	}
+}
+
+// Crossover performs two-point crossover, swapping whole variables between the cut points
+func (g *Integers) Crossover(other Genome, r *rand.Rand) (Genome, Genome) {
+	childA, childB := g.Copy().(*Integers), other.Copy().(*Integers)
+	start, end := twoPointCuts(len(g.Values), r)
+	for i := start; i < end; i++ {
+		childA.Values[i], childB.Values[i] = childB.Values[i], childA.Values[i]
+	}
+	return childA, childB
+}
+
+// Copy creates a copy of the genome
+func (g *Integers) Copy() Genome {
+	return &Integers{Values: append([]int(nil), g.Values...), Min: g.Min, Max: g.Max}
+}
@@ -0,0 +1,65 @@
+package genome
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestReals_Mutate(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	g := NewReals(20, f.Bounds{Min: -1, Max: 1}, r)
+	before := g.Decode()
+	g.Mutate(0, r)
+	assert.Equal(t, before, g.Values, "No variables should change with zero probability")
+	g.Mutate(1, r)
+	for i, v := range g.Values {
+		assert.NotEqual(t, before[i], v, "Every variable should change with probability one")
+		assert.True(t, v >= -1 && v <= 1, "Mutated variables should be repaired within the bounds")
+	}
+}
+
+func TestReals_Crossover(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -10, Max: 10}
+	a := &Reals{Values: []float64{0, 1}, Bounds: bounds}
+	b := &Reals{Values: []float64{2, 1}, Bounds: bounds}
+	for i := 0; i < 50; i++ {
+		child, _ := a.Crossover(b, r)
+		x := child.(*Reals).Values
+		assert.True(t, x[0] >= -1 && x[0] <= 3, "Offspring should lie within the extended range of the parents")
+		assert.Equal(t, 1.0, x[1], "Variables the parents agree on should be inherited")
+	}
+}
+
+func TestReals_Encode(t *testing.T) {
+	g := &Reals{Values: make([]float64, 2), Bounds: f.Bounds{Min: -1, Max: 1}}
+	g.Encode([]float64{0.123456789, 3})
+	assert.Equal(t, []float64{0.123456789, 1}, g.Decode(), "Values should be kept exactly, and values outside clamped")
+}
+
+func TestIntegers(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	g := NewIntegers(30, f.Bounds{Min: -2.5, Max: 3.5}, r)
+	assert.Equal(t, -2, g.Min, "Smallest integer within the bounds")
+	assert.Equal(t, 3, g.Max, "Largest integer within the bounds")
+	g.Mutate(1, r)
+	for _, v := range g.Values {
+		assert.True(t, v >= -2 && v <= 3, "Variables should be integers within the bounds")
+	}
+
+	g = &Integers{Values: make([]int, 2), Min: -2, Max: 3}
+	g.Encode([]float64{1.6, -9})
+	assert.Equal(t, []float64{2, -2}, g.Decode(), "Values should be rounded to the nearest integer within the bounds")
+}
+
+func TestIntegers_Crossover(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	a := &Integers{Values: []int{0, 0, 0, 0}, Max: 1}
+	b := &Integers{Values: []int{1, 1, 1, 1}, Max: 1}
+	childA, childB := a.Crossover(b, r)
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, 1, childA.(*Integers).Values[i]+childB.(*Integers).Values[i], "Variables should be swapped between offspring")
+	}
+}
@@ -4,14 +4,14 @@ go 1.16
 
 require (
 	github.com/armon/consul-api v0.0.0-20180202201655-eb2c6b5be1b6 // indirect
-	github.com/cheggaaa/pb v1.0.29 // indirect
+	github.com/cheggaaa/pb v1.0.29
 	github.com/coreos/go-etcd v2.0.0+incompatible // indirect
 	github.com/cpuguy83/go-md2man v1.0.10 // indirect
-	github.com/go-echarts/go-echarts/v2 v2.2.4 // indirect
-	github.com/jinzhu/copier v0.3.0 // indirect
-	github.com/spf13/cobra v1.1.3 // indirect
-	github.com/stretchr/stew v0.0.0-20130812190256-80ef0842b48b // indirect
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/go-echarts/go-echarts/v2 v2.2.4
+	github.com/jinzhu/copier v0.3.0
+	github.com/spf13/cobra v1.1.3
+	github.com/stretchr/stew v0.0.0-20130812190256-80ef0842b48b
+	github.com/stretchr/testify v1.7.0
 	github.com/ugorji/go/codec v0.0.0-20181204163529-d75b2dcb6bc8 // indirect
 	github.com/xordataexchange/crypt v0.0.3-0.20170626215501-b2862e3d0a77 // indirect
 )
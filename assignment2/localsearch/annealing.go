@@ -0,0 +1,52 @@
+package localsearch
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+)
+
+// SimulatedAnnealing moves a random variable by a normally distributed offset in decoded space, always accepting
+// improvements and accepting moves worse by delta with probability exp(-delta/T). The temperature T is multiplied by Cooling after
+// every move.
+type SimulatedAnnealing struct {
+	Iters    int                 // Number of candidate moves (fitness evaluations)
+	StepSize float64             // Multiplier applied to the normally distributed offset, in decoded units
+	T0       float64             // Initial temperature, if 0 it is set to 10% of the starting fitness
+	Cooling  float64             // Geometric cooling rate
+	Bounds   optimisation.Bounds // Bounds of variables, and how moves outside of them are handled
+}
+
+func (sa SimulatedAnnealing) Search(x []uint16, fx float64, vars []int, fitness optimisation.Fitness, direction optimisation.Direction, r *rand.Rand) ([]uint16, float64, int) {
+	best := append([]uint16(nil), x...)
+	bestFitness := fx
+	current := append([]uint16(nil), x...)
+	currentFitness := fx
+	candidate := append([]uint16(nil), x...)
+
+	temperature := sa.T0
+	if temperature == 0 {
+		temperature = 0.1*math.Abs(fx) + 1e-9
+	}
+
+	for i := 0; i < sa.Iters; i++ {
+		v := vars[r.Intn(len(vars))]
+		copy(candidate, current)
+		candidate[v] = sa.Bounds.Move(current[v], r.NormFloat64()*sa.StepSize, r)
+		candidateFitness := fitness(candidate)
+
+		// Metropolis acceptance criterion
+		delta := math.Abs(candidateFitness - currentFitness)
+		if direction.Better(candidateFitness, currentFitness) || r.Float64() < math.Exp(-delta/temperature) {
+			copy(current, candidate)
+			currentFitness = candidateFitness
+			if direction.Better(currentFitness, bestFitness) {
+				copy(best, current)
+				bestFitness = currentFitness
+			}
+		}
+		temperature *= sa.Cooling
+	}
+
+	return best, bestFitness, sa.Iters
+}
@@ -0,0 +1,9 @@
+package localsearch
+
+import (
+	"testing"
+)
+
+func TestSimulatedAnnealing_Search(t *testing.T) {
+	testSearcher(t, SimulatedAnnealing{Iters: 100, StepSize: 5000, Cooling: 0.9, Bounds: geneBounds}, 100)
+}
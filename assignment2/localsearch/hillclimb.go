@@ -0,0 +1,99 @@
+package localsearch
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+)
+
+// StochasticHillClimb moves a random variable by a normally distributed offset in decoded space, keeping the move if
+// fitness improves. This is the hill climb used by CCGA-HC.
+type StochasticHillClimb struct {
+	Iters    int                 // Number of candidate moves (fitness evaluations)
+	StepSize float64             // Multiplier applied to the normally distributed offset, in decoded units
+	Bounds   optimisation.Bounds // Bounds of variables, and how moves outside of them are handled
+}
+
+func (hc StochasticHillClimb) Search(x []uint16, fx float64, vars []int, fitness optimisation.Fitness, direction optimisation.Direction, r *rand.Rand) ([]uint16, float64, int) {
+	best := append([]uint16(nil), x...)
+	bestFitness := fx
+	candidate := append([]uint16(nil), x...)
+
+	for i := 0; i < hc.Iters; i++ {
+		v := vars[r.Intn(len(vars))]
+		// Randomly generate offset using normal distribution
+		offset := r.NormFloat64() * hc.StepSize
+
+		copy(candidate, best)
+		candidate[v] = hc.Bounds.Move(best[v], offset, r)
+
+		// Update hill climber if fitness is improved
+		candidateFitness := fitness(candidate)
+		if direction.Better(candidateFitness, bestFitness) {
+			copy(best, candidate)
+			bestFitness = candidateFitness
+		}
+	}
+
+	return best, bestFitness, hc.Iters
+}
+
+// BitFlipClimb searches the neighbourhood of solutions one bit-flip away, moving to an improving neighbour until no
+// neighbour improves or the evaluation budget is spent.
+// With BestImprovement the whole neighbourhood is evaluated and the best neighbour is taken, otherwise the first
+// improving neighbour found (searching in a random order) is taken.
+type BitFlipClimb struct {
+	Iters           int // Maximum number of fitness evaluations
+	BestImprovement bool
+}
+
+func (bc BitFlipClimb) Search(x []uint16, fx float64, vars []int, fitness optimisation.Fitness, direction optimisation.Direction, r *rand.Rand) ([]uint16, float64, int) {
+	best := append([]uint16(nil), x...)
+	bestFitness := fx
+	candidate := append([]uint16(nil), x...)
+	evals := 0
+
+	// Each neighbour is identified by its variable and bit position, 16 bits per variable
+	neighbours := len(vars) * 16
+	for evals < bc.Iters {
+		improved := false
+		moveVar, moveBit := -1, uint(0)
+		moveFitness := bestFitness
+
+		for _, n := range r.Perm(neighbours) {
+			if evals >= bc.Iters {
+				break
+			}
+			v, b := vars[n/16], uint(n%16)
+			copy(candidate, best)
+			candidate[v] = flipBit(candidate[v], b)
+			candidateFitness := fitness(candidate)
+			evals++
+
+			if direction.Better(candidateFitness, moveFitness) {
+				moveVar, moveBit, moveFitness = v, b, candidateFitness
+				improved = true
+				if !bc.BestImprovement {
+					break
+				}
+			}
+		}
+
+		if !improved {
+			// Local optimum reached
+			break
+		}
+		best[moveVar] = flipBit(best[moveVar], moveBit)
+		bestFitness = moveFitness
+	}
+
+	return best, bestFitness, evals
+}
+
+// flipBit performs a bit-flip at index pos
+func flipBit(n uint16, pos uint) uint16 {
+	if common.HasBit(n, pos) {
+		return common.ClearBit(n, pos)
+	}
+	return common.SetBit(n, pos)
+}
@@ -0,0 +1,60 @@
+package localsearch
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestStochasticHillClimb_Search(t *testing.T) {
+	testSearcher(t, StochasticHillClimb{Iters: 50, StepSize: 5000, Bounds: geneBounds}, 50)
+}
+
+// TestStochasticHillClimb_Search_Bounds ensures moves near the upper bound do not wrap around to small genes
+func TestStochasticHillClimb_Search_Bounds(t *testing.T) {
+	// Fitness improves towards the upper bound, a wrapped move would be rejected as it makes fitness worse
+	upper := func(x []uint16) float64 {
+		return 65535 - float64(x[0])
+	}
+	x := []uint16{65000}
+	result, _, _ := StochasticHillClimb{Iters: 50, StepSize: 5000, Bounds: geneBounds}.Search(x, upper(x), []int{0}, upper, optimisation.Minimise, r)
+	assert.Equal(t, uint16(65535), result[0], "Hill climb should reach upper bound without overflowing")
+}
+
+// TestStochasticHillClimb_Search_Gray ensures moves stay local in decoded space with Gray-coded genes
+func TestStochasticHillClimb_Search_Gray(t *testing.T) {
+	bounds := geneBounds
+	bounds.Encoding = optimisation.Gray
+	decodedDistance := func(x []uint16) float64 {
+		return math.Abs(bounds.Decode(x[0]) - 30000)
+	}
+
+	x := []uint16{optimisation.BinaryToGray(20000)}
+	result, resultFitness, _ := StochasticHillClimb{Iters: 200, StepSize: 500, Bounds: bounds}.Search(x, decodedDistance(x), []int{0}, decodedDistance, optimisation.Minimise, r)
+	assert.Less(t, resultFitness, 1000.0, "Hill climb should move towards the optimum in decoded space")
+	assert.Equal(t, resultFitness, decodedDistance(result), "Fitness should match the Gray-coded result")
+}
+
+func TestBitFlipClimb_Search_FirstImprovement(t *testing.T) {
+	testSearcher(t, BitFlipClimb{Iters: 200, BestImprovement: false}, 200)
+}
+
+func TestBitFlipClimb_Search_BestImprovement(t *testing.T) {
+	testSearcher(t, BitFlipClimb{Iters: 200, BestImprovement: true}, 200)
+}
+
+// TestBitFlipClimb_Search_LocalOptimum ensures the climb stops early once no single bit-flip improves fitness
+func TestBitFlipClimb_Search_LocalOptimum(t *testing.T) {
+	x := []uint16{30000}
+	result, resultFitness, evals := BitFlipClimb{Iters: 1000, BestImprovement: true}.Search(x, distance(x), []int{0}, distance, optimisation.Minimise, r)
+
+	assert.Equal(t, x, result, "Optimal solution should not change")
+	assert.Equal(t, 0.0, resultFitness, "Optimal solution fitness should not change")
+	assert.Equal(t, 16, evals, "Climb should stop after evaluating the neighbourhood once")
+}
+
+func TestFlipBit(t *testing.T) {
+	assert.Equal(t, uint16(0x0001), flipBit(0x0000, 0), "flipBit should set a clear bit")
+	assert.Equal(t, uint16(0x7FFF), flipBit(0xFFFF, 15), "flipBit should clear a set bit")
+}
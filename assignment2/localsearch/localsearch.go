@@ -0,0 +1,104 @@
+package localsearch
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+)
+
+// LocalSearch improves a solution by searching its neighbourhood. Only the variables with indexes in vars are changed,
+// so the same searcher can be used on a whole GA individual or on a single CCGA species' gene within its coevolution.
+// Fitness improves in the given direction. Returns the best solution found (a new slice), its fitness and the number
+// of fitness evaluations used.
+type LocalSearch interface {
+	Search(x []uint16, fx float64, vars []int, fitness optimisation.Fitness, direction optimisation.Direction, r *rand.Rand) ([]uint16, float64, int)
+}
+
+// Mode sets how the result of a local search is used by the individual that was searched
+type Mode int
+
+const (
+	Lamarckian Mode = iota // The improved solution is written back into the individual's genes
+	Baldwinian             // Only the improved fitness is used for selection, the individual's genes are unchanged
+	Mixed                  // Lamarckian with probability LamarckianP, otherwise Baldwinian
+)
+
+// Config sets how local search is applied within a memetic algorithm
+type Config struct {
+	Searcher    LocalSearch // Local search to apply, nil disables local search
+	Frequency   int         // Apply local search every Frequency generations
+	Probability float64     // Probability of applying local search to each non-elite individual, the elite is always searched
+	Mode        Mode        // Whether learning is Lamarckian, Baldwinian or a mix of both
+	LamarckianP float64     // Probability of Lamarckian learning when Mode is Mixed
+
+	generation int // Generations counted so far, used with Frequency
+}
+
+// NextGeneration counts a generation and returns whether local search should be applied during it.
+func (c *Config) NextGeneration() bool {
+	if c == nil || c.Searcher == nil {
+		return false
+	}
+	c.generation++
+	return c.Frequency <= 1 || c.generation%c.Frequency == 0
+}
+
+// Lamarckian decides whether the result of a local search should be written back into the individual's genes, or only
+// used for its fitness.
+func (c *Config) Lamarckian(r *rand.Rand) bool {
+	switch c.Mode {
+	case Baldwinian:
+		return false
+	case Mixed:
+		return r.Float64() < c.LamarckianP
+	}
+	return true
+}
+
+// GetMode gets a local search Mode by name
+func GetMode(name string) (Mode, error) {
+	switch name {
+	case "lamarckian":
+		return Lamarckian, nil
+	case "baldwinian":
+		return Baldwinian, nil
+	case "mixed":
+		return Mixed, nil
+	}
+
+	return Lamarckian, errors.New("invalid mode passed to GetMode")
+}
+
+// GetSearcher gets a local search by name, where iters is the number of fitness evaluations each search may use.
+// Step sizes are set relative to the width of the variables' bounds.
+func GetSearcher(name string, iters int, bounds optimisation.Bounds) (LocalSearch, error) {
+	switch name {
+	case "hc":
+		return StochasticHillClimb{Iters: iters, StepSize: HillClimbStep * bounds.Width(), Bounds: bounds}, nil
+	case "firstbit":
+		return BitFlipClimb{Iters: iters, BestImprovement: false}, nil
+	case "bestbit":
+		return BitFlipClimb{Iters: iters, BestImprovement: true}, nil
+	case "pattern":
+		return PatternSearch{Iters: iters, Step: bounds.Width() / 16, MinStep: bounds.Width() / 65535, Bounds: bounds}, nil
+	case "neldermead":
+		return NelderMead{Iters: iters, Step: bounds.Width() / 16, Bounds: bounds}, nil
+	case "sa":
+		return SimulatedAnnealing{Iters: iters, StepSize: HillClimbStep * bounds.Width(), Cooling: 0.9, Bounds: bounds}, nil
+	}
+
+	return nil, errors.New("invalid local search passed to GetSearcher")
+}
+
+// HillClimbStep is the step size of CCGA-HC's hill climb as a fraction of the width of the variables' bounds.
+// Originally a step size of 5000 was applied to uint16 genes.
+const HillClimbStep = 5000.0 / 65535
+
+// AllVars gets the index of every variable in an N variable solution, for searching an entire solution
+func AllVars(N int) []int {
+	vars := make([]int, N)
+	for i := 0; i < N; i++ {
+		vars[i] = i
+	}
+	return vars
+}
@@ -0,0 +1,110 @@
+package localsearch
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var r *rand.Rand
+
+// geneBounds decodes genes to the same values, so moves can be checked in gene units
+var geneBounds = optimisation.Bounds{Min: 0, Max: 65535, Policy: optimisation.Clamp}
+
+func init() {
+	s := rand.NewSource(0)
+	r = rand.New(s)
+}
+
+// distance is a simple fitness function with its optimum where every gene is 30000
+func distance(x []uint16) float64 {
+	sum := 0.0
+	for i := 0; i < len(x); i++ {
+		sum += math.Abs(float64(x[i]) - 30000)
+	}
+	return sum
+}
+
+func TestConfig_NextGeneration(t *testing.T) {
+	config := Config{Searcher: StochasticHillClimb{Iters: 1, StepSize: 1, Bounds: geneBounds}, Frequency: 3}
+
+	var applied []bool
+	for i := 0; i < 6; i++ {
+		applied = append(applied, config.NextGeneration())
+	}
+	assert.Equal(t, []bool{false, false, true, false, false, true}, applied, "Local search should be applied every Frequency generations")
+}
+
+func TestConfig_NextGeneration_NoSearcher(t *testing.T) {
+	config := Config{Frequency: 1}
+	assert.False(t, config.NextGeneration(), "Local search should not be applied without a searcher")
+
+	var nilConfig *Config
+	assert.False(t, nilConfig.NextGeneration(), "Local search should not be applied without a config")
+}
+
+func TestConfig_Lamarckian(t *testing.T) {
+	config := Config{Mode: Lamarckian}
+	assert.True(t, config.Lamarckian(r), "Lamarckian mode should always write back")
+
+	config = Config{Mode: Baldwinian}
+	assert.False(t, config.Lamarckian(r), "Baldwinian mode should never write back")
+
+	// Mixed mode should write back in proportion to LamarckianP
+	config = Config{Mode: Mixed, LamarckianP: 0.25}
+	writeBacks := 0.0
+	for i := 0; i < 10000; i++ {
+		if config.Lamarckian(r) {
+			writeBacks++
+		}
+	}
+	assert.InDelta(t, 0.25, writeBacks/10000, 0.05, "Mixed mode should write back with probability LamarckianP")
+}
+
+func TestGetMode(t *testing.T) {
+	mode, err := GetMode("baldwinian")
+	assert.Nil(t, err, "GetMode should find baldwinian")
+	assert.Equal(t, Baldwinian, mode, "GetMode returned wrong mode")
+
+	_, err = GetMode("invalid")
+	assert.NotNil(t, err, "GetMode should error for unknown mode")
+}
+
+func TestGetSearcher(t *testing.T) {
+	for _, name := range []string{"hc", "firstbit", "bestbit", "pattern", "neldermead", "sa"} {
+		searcher, err := GetSearcher(name, 10, geneBounds)
+		assert.Nil(t, err, "GetSearcher should find "+name)
+		assert.NotNil(t, searcher, "GetSearcher should return a searcher for "+name)
+	}
+
+	_, err := GetSearcher("invalid", 10, geneBounds)
+	assert.NotNil(t, err, "GetSearcher should error for unknown local search")
+}
+
+func TestAllVars(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, AllVars(3), "AllVars should list every variable index")
+}
+
+// testSearcher checks a searcher improves a solution, only changes the searched variables and stays in budget
+func testSearcher(t *testing.T, searcher LocalSearch, iters int) {
+	x := []uint16{1000, 60000, 5000}
+	fx := distance(x)
+
+	result, resultFitness, evals := searcher.Search(x, fx, []int{0, 1}, distance, optimisation.Minimise, r)
+
+	assert.Less(t, resultFitness, fx, "Local search should improve fitness")
+	assert.Equal(t, distance(result), resultFitness, "Returned fitness should match returned solution")
+	assert.Equal(t, uint16(5000), result[2], "Variables not being searched should not change")
+	assert.Equal(t, []uint16{1000, 60000, 5000}, x, "Original solution should not be modified")
+	assert.LessOrEqual(t, evals, iters, "Local search should not exceed its evaluation budget")
+
+	// Maximising the negated distance should improve in the same way
+	negated := func(x []uint16) float64 {
+		return -distance(x)
+	}
+	result, resultFitness, _ = searcher.Search(x, -fx, []int{0, 1}, negated, optimisation.Maximise, r)
+	assert.Greater(t, resultFitness, -fx, "Local search should improve fitness when maximising")
+	assert.Equal(t, negated(result), resultFitness, "Returned fitness should match returned solution when maximising")
+}
@@ -0,0 +1,176 @@
+package localsearch
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math/rand"
+	"sort"
+)
+
+// PatternSearch is a compass (coordinate) pattern search in decoded space. Each variable is moved by +/- Step in turn,
+// keeping any improvement. When no move improves, Step is halved until it falls below MinStep.
+type PatternSearch struct {
+	Iters   int                 // Maximum number of fitness evaluations
+	Step    float64             // Initial step size in decoded units
+	MinStep float64             // Search stops once the step size falls below MinStep
+	Bounds  optimisation.Bounds // Bounds of variables, and how moves outside of them are handled
+}
+
+func (ps PatternSearch) Search(x []uint16, fx float64, vars []int, fitness optimisation.Fitness, direction optimisation.Direction, r *rand.Rand) ([]uint16, float64, int) {
+	best := append([]uint16(nil), x...)
+	bestFitness := fx
+	candidate := append([]uint16(nil), x...)
+	evals := 0
+	step := ps.Step
+
+	for step >= ps.MinStep && evals < ps.Iters {
+		improved := false
+		for _, v := range vars {
+			for _, sign := range []float64{1, -1} {
+				if evals >= ps.Iters {
+					break
+				}
+				copy(candidate, best)
+				candidate[v] = ps.Bounds.Move(best[v], sign*step, r)
+				if candidate[v] == best[v] {
+					// Move is too small to change the gene, or was repaired back to the same gene
+					continue
+				}
+				candidateFitness := fitness(candidate)
+				evals++
+				if direction.Better(candidateFitness, bestFitness) {
+					copy(best, candidate)
+					bestFitness = candidateFitness
+					improved = true
+					break
+				}
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+
+	return best, bestFitness, evals
+}
+
+// NelderMead is the Nelder-Mead downhill simplex method over the searched variables in decoded space.
+// The initial simplex is made by moving each variable by Step from the starting solution. Building it takes an
+// evaluation per searched variable, so with fewer Iters the search ends with the best point evaluated so far.
+type NelderMead struct {
+	Iters  int                 // Maximum number of fitness evaluations
+	Step   float64             // Size of initial simplex in decoded units
+	Bounds optimisation.Bounds // Bounds of variables, and how points outside of them are handled
+}
+
+// Standard Nelder-Mead reflection, expansion, contraction and shrink coefficients
+const (
+	nmAlpha = 1.0
+	nmGamma = 2.0
+	nmRho   = 0.5
+	nmSigma = 0.5
+)
+
+type vertex struct {
+	point   []float64
+	fitness float64
+}
+
+func (nm NelderMead) Search(x []uint16, fx float64, vars []int, fitness optimisation.Fitness, direction optimisation.Direction, r *rand.Rand) ([]uint16, float64, int) {
+	dims := len(vars)
+	evals := 0
+	candidate := append([]uint16(nil), x...)
+	best := append([]uint16(nil), x...)
+	bestFitness := fx
+
+	// Evaluate a point of the simplex, only the searched variables are part of the point.
+	// Points outside the bounds are repaired in place. Once the evaluation budget is spent points are not evaluated,
+	// and are given the worst possible fitness so they are never kept.
+	evaluate := func(point []float64) float64 {
+		if evals >= nm.Iters {
+			return direction.Worst()
+		}
+		for d, v := range vars {
+			point[d] = nm.Bounds.Repair(point[d], r)
+			candidate[v] = nm.Bounds.Encode(point[d])
+		}
+		evals++
+		candidateFitness := fitness(candidate)
+		if direction.Better(candidateFitness, bestFitness) {
+			copy(best, candidate)
+			bestFitness = candidateFitness
+		}
+		return candidateFitness
+	}
+
+	// Create initial simplex
+	simplex := make([]vertex, dims+1)
+	start := make([]float64, dims)
+	for d, v := range vars {
+		start[d] = nm.Bounds.Decode(x[v])
+	}
+	simplex[0] = vertex{start, fx}
+	for d := 0; d < dims; d++ {
+		point := append([]float64(nil), start...)
+		if point[d]+nm.Step > nm.Bounds.Max {
+			point[d] -= nm.Step
+		} else {
+			point[d] += nm.Step
+		}
+		simplex[d+1] = vertex{point, evaluate(point)}
+	}
+
+	// Find the point moved from a towards b by coefficient c, a + c(b - a)
+	move := func(a []float64, b []float64, c float64) []float64 {
+		point := make([]float64, dims)
+		for d := 0; d < dims; d++ {
+			point[d] = a[d] + c*(b[d]-a[d])
+		}
+		return point
+	}
+
+	for evals < nm.Iters {
+		sort.Slice(simplex, func(i, j int) bool {
+			return direction.Better(simplex[i].fitness, simplex[j].fitness)
+		})
+		worst := simplex[dims]
+
+		// Centroid of all but the worst point
+		centroid := make([]float64, dims)
+		for i := 0; i < dims; i++ {
+			for d := 0; d < dims; d++ {
+				centroid[d] += simplex[i].point[d] / float64(dims)
+			}
+		}
+
+		reflected := move(centroid, worst.point, -nmAlpha)
+		reflectedFitness := evaluate(reflected)
+
+		if direction.Better(reflectedFitness, simplex[0].fitness) {
+			// Try expanding further in the reflected direction
+			expanded := move(centroid, worst.point, -nmGamma)
+			expandedFitness := evaluate(expanded)
+			if direction.Better(expandedFitness, reflectedFitness) {
+				simplex[dims] = vertex{expanded, expandedFitness}
+			} else {
+				simplex[dims] = vertex{reflected, reflectedFitness}
+			}
+		} else if direction.Better(reflectedFitness, simplex[dims-1].fitness) {
+			simplex[dims] = vertex{reflected, reflectedFitness}
+		} else {
+			// Contract towards the worst point
+			contracted := move(centroid, worst.point, nmRho)
+			contractedFitness := evaluate(contracted)
+			if direction.Better(contractedFitness, worst.fitness) {
+				simplex[dims] = vertex{contracted, contractedFitness}
+			} else {
+				// Shrink every point towards the best point
+				for i := 1; i <= dims && evals < nm.Iters; i++ {
+					point := move(simplex[0].point, simplex[i].point, nmSigma)
+					simplex[i] = vertex{point, evaluate(point)}
+				}
+			}
+		}
+	}
+
+	return best, bestFitness, evals
+}
@@ -0,0 +1,33 @@
+package localsearch
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPatternSearch_Search(t *testing.T) {
+	testSearcher(t, PatternSearch{Iters: 100, Step: 4096, MinStep: 1, Bounds: geneBounds}, 100)
+}
+
+// TestPatternSearch_Search_Converges checks pattern search reaches the optimum of a simple function
+func TestPatternSearch_Search_Converges(t *testing.T) {
+	x := []uint16{1000}
+	result, _, _ := PatternSearch{Iters: 1000, Step: 4096, MinStep: 1, Bounds: geneBounds}.Search(x, distance(x), []int{0}, distance, optimisation.Minimise, r)
+	assert.Equal(t, uint16(30000), result[0], "Pattern search should find optimum")
+}
+
+func TestNelderMead_Search(t *testing.T) {
+	testSearcher(t, NelderMead{Iters: 100, Step: 4096, Bounds: geneBounds}, 100)
+}
+
+// TestNelderMead_Search_Budget checks the budget is kept when building the initial simplex takes more evaluations
+func TestNelderMead_Search_Budget(t *testing.T) {
+	x := []uint16{1000, 60000, 5000, 1000, 60000, 5000}
+	for _, iters := range []int{3, 8, 9} {
+		result, resultFitness, evals := NelderMead{Iters: iters, Step: 4096, Bounds: geneBounds}.Search(x, distance(x), []int{0, 1, 2, 3, 4, 5}, distance, optimisation.Minimise, r)
+		assert.Equal(t, iters, evals, "Nelder-Mead should use exactly its evaluation budget")
+		assert.Equal(t, distance(result), resultFitness, "Returned fitness should match returned solution")
+		assert.LessOrEqual(t, resultFitness, distance(x), "Nelder-Mead should return the best point evaluated")
+	}
+}
@@ -0,0 +1,127 @@
+package optimisation
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// RealFitness is an optimisation function evaluated on real (decoded) values, for real-valued algorithms
+type RealFitness func(x []float64) float64
+
+// BoundaryPolicy sets how a value moved outside of a variable's bounds is brought back within them
+type BoundaryPolicy int
+
+const (
+	Clamp    BoundaryPolicy = iota // Move the value to the nearest bound
+	Reflect                        // Reflect the value back off the bound it crossed
+	Resample                       // Replace the value with a uniformly random value within the bounds
+	Wrap                           // Wrap the value around to the opposite bound, treating the range as periodic
+)
+
+// Bounds is the range of a variable's decoded (real) values, and how values moved out of the range are handled.
+// Anything that perturbs variables (local search moves, real-valued mutations) should move in decoded space using
+// Bounds, so moves stay local instead of overflowing the range of a uint16 gene.
+type Bounds struct {
+	Min      float64
+	Max      float64
+	Policy   BoundaryPolicy
+	Encoding Encoding // Encoding genes are decoded with
+}
+
+// GetBoundaryPolicy gets a BoundaryPolicy by name
+func GetBoundaryPolicy(name string) (BoundaryPolicy, error) {
+	switch name {
+	case "clamp":
+		return Clamp, nil
+	case "reflect":
+		return Reflect, nil
+	case "resample":
+		return Resample, nil
+	case "wrap":
+		return Wrap, nil
+	}
+
+	return Clamp, errors.New("invalid boundary policy passed to GetBoundaryPolicy")
+}
+
+// GetBounds gets the Bounds of the optimisation function's variables, using the given BoundaryPolicy and the
+// function's Encoding
+func (p Params) GetBounds(policy BoundaryPolicy) Bounds {
+	return Bounds{Min: p.ScaleMin, Max: p.ScaleMax, Policy: policy, Encoding: p.Encoding}
+}
+
+// Width is the size of the range of values within the bounds
+func (b Bounds) Width() float64 {
+	return b.Max - b.Min
+}
+
+// Decode scales a gene to its real value within the bounds, with the bounds' Encoding
+func (b Bounds) Decode(gene uint16) float64 {
+	return (float64(b.Encoding.Value(gene))/65535)*b.Width() + b.Min
+}
+
+// Encode converts a real value to the nearest gene with the bounds' Encoding, values outside the bounds are clamped
+func (b Bounds) Encode(x float64) uint16 {
+	return b.Encoding.Gene(uint16(math.Max(0, math.Min(65535, math.Round((x-b.Min)/b.Width()*65535)))))
+}
+
+// Repair brings a value back within the bounds using the BoundaryPolicy, values within the bounds are unchanged
+func (b Bounds) Repair(x float64, r *rand.Rand) float64 {
+	if x >= b.Min && x <= b.Max {
+		return x
+	}
+
+	width := b.Width()
+	switch b.Policy {
+	case Reflect:
+		// Reflecting repeatedly off both bounds has a period of twice the width
+		y := math.Mod(x-b.Min, 2*width)
+		if y < 0 {
+			y += 2 * width
+		}
+		if y > width {
+			y = 2*width - y
+		}
+		return b.Min + y
+	case Resample:
+		return b.Min + r.Float64()*width
+	case Wrap:
+		y := math.Mod(x-b.Min, width)
+		if y < 0 {
+			y += width
+		}
+		return b.Min + y
+	}
+	return math.Max(b.Min, math.Min(b.Max, x))
+}
+
+// Move moves a gene by delta in decoded space, repairing the moved value if it leaves the bounds
+func (b Bounds) Move(gene uint16, delta float64, r *rand.Rand) uint16 {
+	return b.Encode(b.Repair(b.Decode(gene)+delta, r))
+}
+
+// DecodeAll scales each gene to its real value within the bounds
+func (b Bounds) DecodeAll(genes []uint16) []float64 {
+	x := make([]float64, len(genes))
+	for i := 0; i < len(genes); i++ {
+		x[i] = b.Decode(genes[i])
+	}
+	return x
+}
+
+// EncodeAll converts each real value to its nearest gene
+func (b Bounds) EncodeAll(x []float64) []uint16 {
+	genes := make([]uint16, len(x))
+	for i := 0; i < len(x); i++ {
+		genes[i] = b.Encode(x[i])
+	}
+	return genes
+}
+
+// Real adapts a Fitness to be evaluated on real values within the bounds, each value is evaluated at its nearest gene
+func (b Bounds) Real(function Fitness) RealFitness {
+	return func(x []float64) float64 {
+		return function(b.EncodeAll(x))
+	}
+}
@@ -0,0 +1,69 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGetBoundaryPolicy(t *testing.T) {
+	policy, err := GetBoundaryPolicy("reflect")
+	assert.Nil(t, err, "GetBoundaryPolicy should find reflect")
+	assert.Equal(t, Reflect, policy, "GetBoundaryPolicy returned wrong policy")
+
+	_, err = GetBoundaryPolicy("invalid")
+	assert.NotNil(t, err, "GetBoundaryPolicy should error for unknown policy")
+}
+
+func TestBounds_DecodeEncode(t *testing.T) {
+	bounds := Bounds{Min: -5.12, Max: 5.12}
+	assert.InDelta(t, -5.12, bounds.Decode(0), 0.0001, "Gene 0 should decode to Min")
+	assert.InDelta(t, 5.12, bounds.Decode(65535), 0.0001, "Gene 65535 should decode to Max")
+	for _, gene := range []uint16{0, 1, 1234, 32768, 65534, 65535} {
+		assert.Equal(t, gene, bounds.Encode(bounds.Decode(gene)), "Encode should invert Decode")
+	}
+	assert.Equal(t, uint16(65535), bounds.Encode(100), "Values above Max should encode to largest gene")
+	assert.Equal(t, uint16(0), bounds.Encode(-100), "Values below Min should encode to smallest gene")
+}
+
+func TestBounds_Repair(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+
+	clamp := Bounds{Min: 0, Max: 10, Policy: Clamp}
+	assert.Equal(t, 10.0, clamp.Repair(13, r), "Clamp should move value to nearest bound")
+	assert.Equal(t, 0.0, clamp.Repair(-3, r), "Clamp should move value to nearest bound")
+	assert.Equal(t, 4.0, clamp.Repair(4, r), "Values within bounds should not change")
+
+	reflect := Bounds{Min: 0, Max: 10, Policy: Reflect}
+	assert.InDelta(t, 7.0, reflect.Repair(13, r), 0.0001, "Reflect should reflect off upper bound")
+	assert.InDelta(t, 3.0, reflect.Repair(-3, r), 0.0001, "Reflect should reflect off lower bound")
+	assert.InDelta(t, 3.0, reflect.Repair(23, r), 0.0001, "Reflect should reflect repeatedly off both bounds")
+
+	wrap := Bounds{Min: 0, Max: 10, Policy: Wrap}
+	assert.InDelta(t, 3.0, wrap.Repair(13, r), 0.0001, "Wrap should wrap around to lower bound")
+	assert.InDelta(t, 7.0, wrap.Repair(-3, r), 0.0001, "Wrap should wrap around to upper bound")
+
+	resample := Bounds{Min: 0, Max: 10, Policy: Resample}
+	for i := 0; i < 100; i++ {
+		x := resample.Repair(13, r)
+		assert.True(t, x >= 0 && x <= 10, "Resample should pick a value within bounds")
+	}
+}
+
+// TestBounds_Move ensures a move past the upper bound does not overflow to a gene near zero
+func TestBounds_Move(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := Bounds{Min: 0, Max: 65535, Policy: Clamp}
+	assert.Equal(t, uint16(65535), bounds.Move(65000, 5000, r), "Move past upper bound should be clamped")
+	assert.Equal(t, uint16(35000), bounds.Move(30000, 5000, r), "Move within bounds should be exact")
+}
+
+func TestBounds_Real(t *testing.T) {
+	bounds := Bounds{Min: RastriginMin, Max: RastriginMax}
+	x := make([]float64, RastriginN)
+	assert.InDelta(t, 0.0, bounds.Real(Rastrigin)(x), 0.01, "Real fitness should evaluate decoded values")
+
+	genes := []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	assert.Equal(t, genes, bounds.EncodeAll(bounds.DecodeAll(genes)), "EncodeAll should invert DecodeAll")
+	assert.InDelta(t, Rastrigin(genes), bounds.Real(Rastrigin)(bounds.DecodeAll(genes)), 0.0001, "Real fitness should match fitness of genes")
+}
@@ -0,0 +1,286 @@
+package optimisation
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Encoded gets the Fitness of a function defined on real values, decoding genes to variables within [scaleMin, scaleMax]
+func Encoded(function RealFitness, scaleMin float64, scaleMax float64) Fitness {
+	return func(genes []uint16) float64 {
+		return function(ScaleInputs(genes, scaleMin, scaleMax))
+	}
+}
+
+const (
+	SphereLabel     = "Sphere Function"
+	SphereN         = 30
+	SphereMin       = -5.12
+	SphereMax       = 5.12
+	SphereMutationP = float32(1) / (float32(16) * SphereN)
+	SpherePlotMax   = 20
+)
+
+// SphereReal is De Jong's F1, the sum of the squares of the variables
+func SphereReal(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x); i++ {
+		sum += x[i] * x[i]
+	}
+	return sum
+}
+
+const (
+	EllipsoidLabel     = "Ellipsoid Function"
+	EllipsoidN         = 30
+	EllipsoidMin       = -5.12
+	EllipsoidMax       = 5.12
+	EllipsoidMutationP = float32(1) / (float32(16) * EllipsoidN)
+	EllipsoidPlotMax   = 200
+)
+
+// EllipsoidReal is the axis parallel hyper-ellipsoid, each variable's square weighted by its (1-based) index
+func EllipsoidReal(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x); i++ {
+		sum += float64(i+1) * x[i] * x[i]
+	}
+	return sum
+}
+
+const (
+	StepLabel     = "Step Function"
+	StepN         = 30
+	StepMin       = -100.0
+	StepMax       = 100.0
+	StepMutationP = float32(1) / (float32(16) * StepN)
+	StepPlotMax   = 1000
+)
+
+// StepReal is the step function, the sum of the squares of each variable rounded to the nearest integer. It is flat
+// within each step, so gives no gradient to follow.
+func StepReal(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x); i++ {
+		sum += math.Pow(math.Floor(x[i]+0.5), 2)
+	}
+	return sum
+}
+
+const (
+	SchafferF6Label     = "Schaffer F6 Function"
+	SchafferF6N         = 10
+	SchafferF6Min       = -100.0
+	SchafferF6Max       = 100.0
+	SchafferF6MutationP = float32(1) / (float32(16) * SchafferF6N)
+	SchafferF6PlotMax   = 5
+)
+
+// SchafferF6Real is Schaffer's F6 expanded to N variables, summed over each pair of consecutive variables. Its optimum
+// is surrounded by rings of local optima.
+func SchafferF6Real(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x)-1; i++ {
+		s := x[i]*x[i] + x[i+1]*x[i+1]
+		sum += 0.5 + (math.Pow(math.Sin(math.Sqrt(s)), 2)-0.5)/math.Pow(1+0.001*s, 2)
+	}
+	return sum
+}
+
+const (
+	SchafferF7Label     = "Schaffer F7 Function"
+	SchafferF7N         = 10
+	SchafferF7Min       = -100.0
+	SchafferF7Max       = 100.0
+	SchafferF7MutationP = float32(1) / (float32(16) * SchafferF7N)
+	SchafferF7PlotMax   = 100
+)
+
+// SchafferF7Real is Schaffer's F7 expanded to N variables, summed over each pair of consecutive variables
+func SchafferF7Real(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x)-1; i++ {
+		s := x[i]*x[i] + x[i+1]*x[i+1]
+		sum += math.Pow(s, 0.25) * (math.Pow(math.Sin(50*math.Pow(s, 0.1)), 2) + 1)
+	}
+	return sum
+}
+
+const (
+	LevyLabel     = "Levy Function"
+	LevyN         = 20
+	LevyMin       = -10.0
+	LevyMax       = 10.0
+	LevyMutationP = float32(1) / (float32(16) * LevyN)
+	LevyPlotMax   = 50
+	LevyOptimum   = 1.0 // Value of every variable at the global optimum
+)
+
+// LevyReal is the Levy function
+func LevyReal(x []float64) float64 {
+	w := make([]float64, len(x))
+	for i := range x {
+		w[i] = 1 + (x[i]-1)/4
+	}
+
+	last := w[len(w)-1]
+	sum := math.Pow(math.Sin(math.Pi*w[0]), 2) + math.Pow(last-1, 2)*(1+math.Pow(math.Sin(2*math.Pi*last), 2))
+	for i := 0; i < len(w)-1; i++ {
+		sum += math.Pow(w[i]-1, 2) * (1 + 10*math.Pow(math.Sin(math.Pi*w[i]+1), 2))
+	}
+	return sum
+}
+
+const (
+	WeierstrassLabel     = "Weierstrass Function"
+	WeierstrassN         = 10
+	WeierstrassMin       = -0.5
+	WeierstrassMax       = 0.5
+	WeierstrassMutationP = float32(1) / (float32(16) * WeierstrassN)
+	WeierstrassPlotMax   = 20
+	WeierstrassA         = 0.5
+	WeierstrassB         = 3.0
+	WeierstrassKMax      = 20
+)
+
+// WeierstrassReal is the Weierstrass function, continuous but differentiable only on a set of points
+func WeierstrassReal(x []float64) float64 {
+	sum := 0.0
+	for k := 0; k <= WeierstrassKMax; k++ {
+		ak, bk := math.Pow(WeierstrassA, float64(k)), math.Pow(WeierstrassB, float64(k))
+		for i := 0; i < len(x); i++ {
+			sum += ak * math.Cos(2*math.Pi*bk*(x[i]+0.5))
+		}
+		sum -= float64(len(x)) * ak * math.Cos(math.Pi*bk)
+	}
+	return sum
+}
+
+const (
+	MichalewiczLabel          = "Michalewicz Function"
+	MichalewiczN              = 10
+	MichalewiczMin            = 0.0
+	MichalewiczMax            = math.Pi
+	MichalewiczMutationP      = float32(1) / (float32(16) * MichalewiczN)
+	MichalewiczPlotMin        = -10
+	MichalewiczM              = 10       // Steepness of the valleys
+	MichalewiczOptimumFitness = -9.66015 // Fitness at the global optimum for MichalewiczN variables
+)
+
+// MichalewiczReal is the Michalewicz function, whose steep valleys make up a small fraction of the search space
+func MichalewiczReal(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x); i++ {
+		sum -= math.Sin(x[i]) * math.Pow(math.Sin(float64(i+1)*x[i]*x[i]/math.Pi), 2*MichalewiczM)
+	}
+	return sum
+}
+
+const (
+	ZakharovLabel     = "Zakharov Function"
+	ZakharovN         = 10
+	ZakharovMin       = -5.0
+	ZakharovMax       = 10.0
+	ZakharovMutationP = float32(1) / (float32(16) * ZakharovN)
+	ZakharovPlotMax   = 500
+)
+
+// ZakharovReal is the Zakharov function, a plate shaped function with no local optima
+func ZakharovReal(x []float64) float64 {
+	sumSquares, sumWeighted := 0.0, 0.0
+	for i := 0; i < len(x); i++ {
+		sumSquares += x[i] * x[i]
+		sumWeighted += 0.5 * float64(i+1) * x[i]
+	}
+	return sumSquares + math.Pow(sumWeighted, 2) + math.Pow(sumWeighted, 4)
+}
+
+const (
+	LunacekLabel     = "Lunacek bi-Rastrigin Function"
+	LunacekN         = 20
+	LunacekMin       = -5.12
+	LunacekMax       = 5.12
+	LunacekMutationP = float32(1) / (float32(16) * LunacekN)
+	LunacekPlotMax   = 200
+	LunacekMu0       = 2.5 // Centre of the funnel holding the global optimum, the value of every variable at the optimum
+	LunacekD         = 1.0 // Fitness of the second funnel's centre
+)
+
+// LunacekReal is Lunacek's bi-Rastrigin function, Rastrigin's function over two funnels. The larger funnel, centred
+// on a negative point, is a deceptive trap for algorithms following the global structure.
+func LunacekReal(x []float64) float64 {
+	N := float64(len(x))
+	s := 1 - 1/(2*math.Sqrt(N+20)-8.2)
+	mu1 := -math.Sqrt((LunacekMu0*LunacekMu0 - LunacekD) / s)
+
+	sphere0, sphere1, rastrigin := 0.0, 0.0, 0.0
+	for i := 0; i < len(x); i++ {
+		sphere0 += math.Pow(x[i]-LunacekMu0, 2)
+		sphere1 += math.Pow(x[i]-mu1, 2)
+		rastrigin += 1 - math.Cos(2*math.Pi*(x[i]-LunacekMu0))
+	}
+	return math.Min(sphere0, LunacekD*N+s*sphere1) + 10*rastrigin
+}
+
+const (
+	KatsuuraLabel     = "Katsuura Function"
+	KatsuuraN         = 10
+	KatsuuraMin       = -100.0
+	KatsuuraMax       = 100.0
+	KatsuuraMutationP = float32(1) / (float32(16) * KatsuuraN)
+	KatsuuraPlotMax   = 2
+)
+
+// KatsuuraReal is the Katsuura function, continuous everywhere but differentiable nowhere
+func KatsuuraReal(x []float64) float64 {
+	N := float64(len(x))
+	product := 1.0
+	for i := 0; i < len(x); i++ {
+		sum := 0.0
+		for j := 1; j <= 32; j++ {
+			power := math.Pow(2, float64(j))
+			sum += math.Abs(power*x[i]-math.Round(power*x[i])) / power
+		}
+		product *= math.Pow(1+float64(i+1)*sum, 10/math.Pow(N, 1.2))
+	}
+	return 10/(N*N)*product - 10/(N*N)
+}
+
+const (
+	StyblinskiTangLabel     = "Styblinski-Tang Function"
+	StyblinskiTangN         = 10
+	StyblinskiTangMin       = -5.0
+	StyblinskiTangMax       = 5.0
+	StyblinskiTangMutationP = float32(1) / (float32(16) * StyblinskiTangN)
+	StyblinskiTangPlotMin   = -400
+	StyblinskiTangPlotMax   = -300
+	StyblinskiTangOptimum   = -2.903534027771178 // Value of every variable at the global optimum
+)
+
+// StyblinskiTangReal is the Styblinski-Tang function, whose global optimum is in a corner of the search space
+func StyblinskiTangReal(x []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(x); i++ {
+		sum += math.Pow(x[i], 4) - 16*x[i]*x[i] + 5*x[i]
+	}
+	return sum / 2
+}
+
+const (
+	OneMaxLabel     = "OneMax Function"
+	OneMaxN         = 10
+	OneMaxMin       = 0.0
+	OneMaxMax       = 65535.0
+	OneMaxMutationP = float32(1) / (float32(16) * OneMaxN)
+	OneMaxPlotMin   = 120
+)
+
+// OneMaxReal counts the bits set in each variable rounded to a 16-bit integer, to be maximised. Its genes are decoded
+// within [0, 65535], so with binary encoding each variable is its gene.
+func OneMaxReal(x []float64) float64 {
+	sum := 0
+	for i := 0; i < len(x); i++ {
+		sum += bits.OnesCount16(uint16(math.Round(math.Max(OneMaxMin, math.Min(OneMaxMax, x[i])))))
+	}
+	return float64(sum)
+}
@@ -0,0 +1,57 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+var catalogue = []string{"sphere", "ellipsoid", "step", "schafferf6", "schafferf7", "levy", "weierstrass", "michalewicz",
+	"zakharov", "lunacek", "katsuura", "styblinskitang", "onemax"}
+
+func TestCatalogue_Optimum(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, name := range catalogue {
+		params, err := GetParams(name)
+		assert.Nil(t, err, name+" should exist")
+		assert.NotEmpty(t, params.Label, name+" should have a label")
+		assert.True(t, params.PlotMin != 0 || params.PlotMax != 0, name+" should have plot limits")
+
+		for _, optimum := range params.Optima {
+			assert.Equal(t, params.N, len(optimum), "Optimum of "+name+" should have every variable")
+			assert.InDelta(t, params.OptimumFitness, params.RealFunction(optimum), 1e-9, "Optimum of "+name+" should have the optimum fitness")
+		}
+
+		// No random point should beat the optimum
+		for i := 0; i < 1000; i++ {
+			x := make([]float64, params.N)
+			for j := range x {
+				x[j] = params.ScaleMin + r.Float64()*(params.ScaleMax-params.ScaleMin)
+			}
+			assert.False(t, params.Direction.Better(params.RealFunction(x), params.Direction.Worsen(params.OptimumFitness, -1e-9)), name+" should not beat its optimum")
+		}
+
+		genes := make([]uint16, params.N)
+		for i := range genes {
+			genes[i] = uint16(r.Intn(65536))
+		}
+		assert.Equal(t, params.RealFunction(params.Decode(genes)), params.Function(genes), "Function of "+name+" should decode genes")
+	}
+}
+
+func TestMichalewiczReal_Optimum(t *testing.T) {
+	// The optimum of the 2 variable Michalewicz function is known to more places than that of MichalewiczN variables
+	assert.InDelta(t, -1.8013034, MichalewiczReal([]float64{2.20290552, 1.57079633}), 1e-6, "Michalewicz should be optimal at its known optimum")
+	params, _ := GetParams("michalewicz")
+	assert.Equal(t, MichalewiczOptimumFitness, params.OptimumFitness, "Michalewicz should have its known optimum fitness")
+}
+
+func TestStepReal(t *testing.T) {
+	assert.Equal(t, 0.0, StepReal([]float64{0.49, -0.5}), "Step should be optimal within the central step")
+	assert.Equal(t, 5.0, StepReal([]float64{0.5, -1.7}), "Step should round each variable")
+}
+
+func TestStyblinskiTangReal_Optimum(t *testing.T) {
+	params, _ := GetParams("styblinskitang")
+	assert.InDelta(t, -39.16616570377142*StyblinskiTangN, params.OptimumFitness, 1e-9, "Styblinski-Tang optimum should be -39.166 for each variable")
+}
@@ -0,0 +1,440 @@
+package optimisation
+
+import (
+	"math"
+	"strconv"
+)
+
+// The constrained problems G01 to G13 as defined for the CEC 2006 special session on constrained real-parameter
+// optimisation (Liang et al., 2006), all minimised, followed by the pressure vessel and welded beam design problems.
+// Equality constraints are relaxed by EqualityTolerance.
+
+// bounded creates variables named x1 to xN for each pair of bounds
+func bounded(bounds ...[2]float64) []Variable {
+	variables := make([]Variable, len(bounds))
+	for i, b := range bounds {
+		variables[i] = Variable{Name: "x" + strconv.Itoa(i+1), Min: b[0], Max: b[1]}
+	}
+	return variables
+}
+
+// repeatBounds creates n variables with the same bounds
+func repeatBounds(n int, lower float64, upper float64) [][2]float64 {
+	bounds := make([][2]float64, n)
+	for i := range bounds {
+		bounds[i] = [2]float64{lower, upper}
+	}
+	return bounds
+}
+
+const (
+	G01Label = "G01"
+	G01N     = 13
+)
+
+// G01 is a quadratic objective with nine linear inequality constraints, six of them active at the optimum
+var G01 = Problem{
+	Label:     G01Label,
+	Variables: bounded(append(append(repeatBounds(9, 0, 1), repeatBounds(3, 0, 100)...), [2]float64{0, 1})...),
+	Objective: func(x []float64) float64 {
+		f := 0.0
+		for i := 0; i < 4; i++ {
+			f += 5*x[i] - 5*x[i]*x[i]
+		}
+		for i := 4; i < G01N; i++ {
+			f -= x[i]
+		}
+		return f
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(2*x[0] + 2*x[1] + x[9] + x[10] - 10),
+			Inequality(2*x[0] + 2*x[2] + x[9] + x[11] - 10),
+			Inequality(2*x[1] + 2*x[2] + x[10] + x[11] - 10),
+			Inequality(-8*x[0] + x[9]),
+			Inequality(-8*x[1] + x[10]),
+			Inequality(-8*x[2] + x[11]),
+			Inequality(-2*x[3] - x[4] + x[9]),
+			Inequality(-2*x[5] - x[6] + x[10]),
+			Inequality(-2*x[7] - x[8] + x[11]),
+		}
+	},
+	Optima:         [][]float64{{1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 3, 3, 1}},
+	OptimumFitness: -15,
+}
+
+const (
+	G02Label = "G02"
+	G02N     = 20
+)
+
+// G02 is a highly multimodal objective with a product and a sum constraint, whose optimum is not known exactly
+var G02 = Problem{
+	Label:     G02Label,
+	Variables: bounded(repeatBounds(G02N, 0, 10)...),
+	Objective: func(x []float64) float64 {
+		sumCos4, productCos2, weighted := 0.0, 1.0, 0.0
+		for i := range x {
+			c := math.Cos(x[i])
+			sumCos4 += math.Pow(c, 4)
+			productCos2 *= c * c
+			weighted += float64(i+1) * x[i] * x[i]
+		}
+		if weighted == 0 {
+			return 0
+		}
+		return -math.Abs(sumCos4-2*productCos2) / math.Sqrt(weighted)
+	},
+	Constraints: func(x []float64) []float64 {
+		product, total := 1.0, 0.0
+		for i := range x {
+			product *= x[i]
+			total += x[i]
+		}
+		return []float64{
+			Inequality(0.75 - product),
+			Inequality(total - 7.5*float64(len(x))),
+		}
+	},
+	OptimumFitness: -0.80361910412559,
+}
+
+const (
+	G03Label = "G03"
+	G03N     = 10
+)
+
+// G03 maximises a product on the unit hypersphere, a single equality constraint
+var G03 = Problem{
+	Label:     G03Label,
+	Variables: bounded(repeatBounds(G03N, 0, 1)...),
+	Objective: func(x []float64) float64 {
+		N := float64(len(x))
+		f := -math.Pow(math.Sqrt(N), N)
+		for i := range x {
+			f *= x[i]
+		}
+		return f
+	},
+	Constraints: func(x []float64) []float64 {
+		h := -1.0
+		for i := range x {
+			h += x[i] * x[i]
+		}
+		return []float64{Equality(h)}
+	},
+	Optima:         [][]float64{repeat(1/math.Sqrt(G03N), G03N)},
+	OptimumFitness: -1,
+}
+
+const G04Label = "G04"
+
+// G04 is Himmelblau's nonlinear problem, a quadratic objective with six nonlinear inequality constraints
+var G04 = Problem{
+	Label:     G04Label,
+	Variables: bounded([2]float64{78, 102}, [2]float64{33, 45}, [2]float64{27, 45}, [2]float64{27, 45}, [2]float64{27, 45}),
+	Objective: func(x []float64) float64 {
+		return 5.3578547*x[2]*x[2] + 0.8356891*x[0]*x[4] + 37.293239*x[0] - 40792.141
+	},
+	Constraints: func(x []float64) []float64 {
+		u := 85.334407 + 0.0056858*x[1]*x[4] + 0.0006262*x[0]*x[3] - 0.0022053*x[2]*x[4]
+		v := 80.51249 + 0.0071317*x[1]*x[4] + 0.0029955*x[0]*x[1] + 0.0021813*x[2]*x[2]
+		w := 9.300961 + 0.0047026*x[2]*x[4] + 0.0012547*x[0]*x[2] + 0.0019085*x[2]*x[3]
+		return []float64{
+			Inequality(u - 92), Inequality(-u),
+			Inequality(v - 110), Inequality(-v + 90),
+			Inequality(w - 25), Inequality(-w + 20),
+		}
+	},
+	Optima:         [][]float64{{78, 33, 29.9952560256815985, 45, 36.7758129057882073}},
+	OptimumFitness: -30665.5386717834,
+}
+
+const G05Label = "G05"
+
+// G05 is a cubic objective with two linear inequality constraints and three nonlinear equality constraints
+var G05 = Problem{
+	Label:     G05Label,
+	Variables: bounded([2]float64{0, 1200}, [2]float64{0, 1200}, [2]float64{-0.55, 0.55}, [2]float64{-0.55, 0.55}),
+	Objective: func(x []float64) float64 {
+		return 3*x[0] + 0.000001*math.Pow(x[0], 3) + 2*x[1] + (0.000002/3)*math.Pow(x[1], 3)
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(-x[3] + x[2] - 0.55),
+			Inequality(-x[2] + x[3] - 0.55),
+			Equality(1000*math.Sin(-x[2]-0.25) + 1000*math.Sin(-x[3]-0.25) + 894.8 - x[0]),
+			Equality(1000*math.Sin(x[2]-0.25) + 1000*math.Sin(x[2]-x[3]-0.25) + 894.8 - x[1]),
+			Equality(1000*math.Sin(x[3]-0.25) + 1000*math.Sin(x[3]-x[2]-0.25) + 1294.8),
+		}
+	},
+	Optima:         [][]float64{{679.945148297028709, 1026.06697600004691, 0.118876369094410433, -0.396233485215178266}},
+	OptimumFitness: 5126.4967140071,
+}
+
+const G06Label = "G06"
+
+// G06 is a cubic objective whose tiny feasible region lies between two circles
+var G06 = Problem{
+	Label:     G06Label,
+	Variables: bounded([2]float64{13, 100}, [2]float64{0, 100}),
+	Objective: func(x []float64) float64 {
+		return math.Pow(x[0]-10, 3) + math.Pow(x[1]-20, 3)
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(-math.Pow(x[0]-5, 2) - math.Pow(x[1]-5, 2) + 100),
+			Inequality(math.Pow(x[0]-6, 2) + math.Pow(x[1]-5, 2) - 82.81),
+		}
+	},
+	Optima:         [][]float64{{14.09500000000000064, 0.8429607892154795668}},
+	OptimumFitness: -6961.81387558015,
+}
+
+const G07Label = "G07"
+
+// G07 is a quadratic objective with three linear and five nonlinear inequality constraints
+var G07 = Problem{
+	Label:     G07Label,
+	Variables: bounded(repeatBounds(10, -10, 10)...),
+	Objective: func(x []float64) float64 {
+		return x[0]*x[0] + x[1]*x[1] + x[0]*x[1] - 14*x[0] - 16*x[1] + math.Pow(x[2]-10, 2) + 4*math.Pow(x[3]-5, 2) +
+			math.Pow(x[4]-3, 2) + 2*math.Pow(x[5]-1, 2) + 5*x[6]*x[6] + 7*math.Pow(x[7]-11, 2) + 2*math.Pow(x[8]-10, 2) +
+			math.Pow(x[9]-7, 2) + 45
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(-105 + 4*x[0] + 5*x[1] - 3*x[6] + 9*x[7]),
+			Inequality(10*x[0] - 8*x[1] - 17*x[6] + 2*x[7]),
+			Inequality(-8*x[0] + 2*x[1] + 5*x[8] - 2*x[9] - 12),
+			Inequality(3*math.Pow(x[0]-2, 2) + 4*math.Pow(x[1]-3, 2) + 2*x[2]*x[2] - 7*x[3] - 120),
+			Inequality(5*x[0]*x[0] + 8*x[1] + math.Pow(x[2]-6, 2) - 2*x[3] - 40),
+			Inequality(x[0]*x[0] + 2*math.Pow(x[1]-2, 2) - 2*x[0]*x[1] + 14*x[4] - 6*x[5]),
+			Inequality(0.5*math.Pow(x[0]-8, 2) + 2*math.Pow(x[1]-4, 2) + 3*x[4]*x[4] - x[5] - 30),
+			Inequality(-3*x[0] + 6*x[1] + 12*math.Pow(x[8]-8, 2) - 7*x[9]),
+		}
+	},
+	Optima: [][]float64{{2.17199634142692, 2.3636830416034, 8.77392573913157, 5.09598443745173, 0.990654756560493,
+		1.43057392853463, 1.32164415364306, 9.82872576524495, 8.2800915887356, 8.3759266477347}},
+	OptimumFitness: 24.30620906818,
+}
+
+const G08Label = "G08"
+
+// G08 is a multimodal objective whose optimum lies within a small feasible region
+var G08 = Problem{
+	Label:     G08Label,
+	Variables: bounded(repeatBounds(2, 0, 10)...),
+	Objective: func(x []float64) float64 {
+		denominator := math.Pow(x[0], 3) * (x[0] + x[1])
+		if denominator == 0 {
+			return 0
+		}
+		return -math.Pow(math.Sin(2*math.Pi*x[0]), 3) * math.Sin(2*math.Pi*x[1]) / denominator
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(x[0]*x[0] - x[1] + 1),
+			Inequality(1 - x[0] + math.Pow(x[1]-4, 2)),
+		}
+	},
+	Optima:         [][]float64{{1.22797135260752599, 4.24537336612274885}},
+	OptimumFitness: -0.0958250414180359,
+}
+
+const G09Label = "G09"
+
+// G09 is a polynomial objective with four nonlinear inequality constraints
+var G09 = Problem{
+	Label:     G09Label,
+	Variables: bounded(repeatBounds(7, -10, 10)...),
+	Objective: func(x []float64) float64 {
+		return math.Pow(x[0]-10, 2) + 5*math.Pow(x[1]-12, 2) + math.Pow(x[2], 4) + 3*math.Pow(x[3]-11, 2) +
+			10*math.Pow(x[4], 6) + 7*x[5]*x[5] + math.Pow(x[6], 4) - 4*x[5]*x[6] - 10*x[5] - 8*x[6]
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(-127 + 2*x[0]*x[0] + 3*math.Pow(x[1], 4) + x[2] + 4*x[3]*x[3] + 5*x[4]),
+			Inequality(-282 + 7*x[0] + 3*x[1] + 10*x[2]*x[2] + x[3] - x[4]),
+			Inequality(-196 + 23*x[0] + x[1]*x[1] + 6*x[5]*x[5] - 8*x[6]),
+			Inequality(4*x[0]*x[0] + x[1]*x[1] - 3*x[0]*x[1] + 2*x[2]*x[2] + 5*x[5] - 11*x[6]),
+		}
+	},
+	Optima: [][]float64{{2.33049935147405174, 1.95137236847114592, -0.477541399510615805, 4.36572624923625874,
+		-0.624486959100388983, 1.03813099410962173, 1.5942266780671519}},
+	OptimumFitness: 680.630057374402,
+}
+
+const G10Label = "G10"
+
+// G10 is a linear objective with three linear and three nonlinear inequality constraints, all active at the optimum
+var G10 = Problem{
+	Label: G10Label,
+	Variables: bounded([2]float64{100, 10000}, [2]float64{1000, 10000}, [2]float64{1000, 10000},
+		[2]float64{10, 1000}, [2]float64{10, 1000}, [2]float64{10, 1000}, [2]float64{10, 1000}, [2]float64{10, 1000}),
+	Objective: func(x []float64) float64 {
+		return x[0] + x[1] + x[2]
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(-1 + 0.0025*(x[3]+x[5])),
+			Inequality(-1 + 0.0025*(x[4]+x[6]-x[3])),
+			Inequality(-1 + 0.01*(x[7]-x[4])),
+			Inequality(-x[0]*x[5] + 833.33252*x[3] + 100*x[0] - 83333.333),
+			Inequality(-x[1]*x[6] + 1250*x[4] + x[1]*x[3] - 1250*x[3]),
+			Inequality(-x[2]*x[7] + 1250000 + x[2]*x[4] - 2500*x[4]),
+		}
+	},
+	Optima: [][]float64{{579.306685017979589, 1359.97067807935605, 5109.97065743133317, 182.01769963061534,
+		295.601173702746792, 217.982300369384632, 286.41652592786852, 395.601173702746735}},
+	OptimumFitness: 7049.24802052867,
+}
+
+const G11Label = "G11"
+
+// G11 is a quadratic objective with a single nonlinear equality constraint
+var G11 = Problem{
+	Label:     G11Label,
+	Variables: bounded(repeatBounds(2, -1, 1)...),
+	Objective: func(x []float64) float64 {
+		return x[0]*x[0] + math.Pow(x[1]-1, 2)
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{Equality(x[1] - x[0]*x[0])}
+	},
+	Optima:         [][]float64{{-0.707036070037170616, 0.500000004333606807}, {0.707036070037170616, 0.500000004333606807}},
+	OptimumFitness: 0.7499,
+}
+
+const G12Label = "G12"
+
+// G12 is a quadratic objective whose feasible region is 729 disjoint spheres, a solution being feasible within any
+var G12 = Problem{
+	Label:     G12Label,
+	Variables: bounded(repeatBounds(3, 0, 10)...),
+	Objective: func(x []float64) float64 {
+		return -(100 - math.Pow(x[0]-5, 2) - math.Pow(x[1]-5, 2) - math.Pow(x[2]-5, 2)) / 100
+	},
+	Constraints: func(x []float64) []float64 {
+		// The nearest sphere is centred on the integers nearest x, clamped to 1 to 9
+		g := -0.0625
+		for i := range x {
+			centre := math.Max(1, math.Min(9, math.Round(x[i])))
+			g += math.Pow(x[i]-centre, 2)
+		}
+		return []float64{Inequality(g)}
+	},
+	Optima:         [][]float64{{5, 5, 5}},
+	OptimumFitness: -1,
+}
+
+const G13Label = "G13"
+
+// G13 is an exponential objective with three nonlinear equality constraints
+var G13 = Problem{
+	Label:     G13Label,
+	Variables: bounded([2]float64{-2.3, 2.3}, [2]float64{-2.3, 2.3}, [2]float64{-3.2, 3.2}, [2]float64{-3.2, 3.2}, [2]float64{-3.2, 3.2}),
+	Objective: func(x []float64) float64 {
+		return math.Exp(x[0] * x[1] * x[2] * x[3] * x[4])
+	},
+	Constraints: func(x []float64) []float64 {
+		sumSquares := 0.0
+		for i := range x {
+			sumSquares += x[i] * x[i]
+		}
+		return []float64{
+			Equality(sumSquares - 10),
+			Equality(x[1]*x[2] - 5*x[3]*x[4]),
+			Equality(math.Pow(x[0], 3) + math.Pow(x[1], 3) + 1),
+		}
+	},
+	Optima:         [][]float64{{-1.71714224003, 1.59572124049468, 1.8272502406271, -0.763659881912867, -0.76365986736498}},
+	OptimumFitness: 0.053941514041898,
+}
+
+const (
+	PressureVesselLabel     = "Pressure Vessel Design"
+	PressureVesselThickness = 0.0625 // Steel plate is rolled in multiples of 1/16 inch
+)
+
+// PressureVessel is the pressure vessel design problem of Kannan & Kramer (DOI 10.1115/1.2919393), minimising the cost
+// of material, forming and welding of a cylindrical vessel capped by hemispherical heads. The shell (Ts) and head (Th)
+// thicknesses are multiples of 1/16 inch, the inner radius (R) and length of the shell (L) continuous.
+var PressureVessel = Problem{
+	Label: PressureVesselLabel,
+	Variables: []Variable{
+		{Name: "Ts", Min: PressureVesselThickness, Max: 99 * PressureVesselThickness, Precision: PressureVesselThickness},
+		{Name: "Th", Min: PressureVesselThickness, Max: 99 * PressureVesselThickness, Precision: PressureVesselThickness},
+		{Name: "R", Min: 10, Max: 200},
+		{Name: "L", Min: 10, Max: 200},
+	},
+	Objective: func(x []float64) float64 {
+		return 0.6224*x[0]*x[2]*x[3] + 1.7781*x[1]*x[2]*x[2] + 3.1661*x[0]*x[0]*x[3] + 19.84*x[0]*x[0]*x[2]
+	},
+	Constraints: func(x []float64) []float64 {
+		return []float64{
+			Inequality(-x[0] + 0.0193*x[2]),
+			Inequality(-x[1] + 0.00954*x[2]),
+			Inequality(-math.Pi*x[2]*x[2]*x[3] - 4.0/3*math.Pi*math.Pow(x[2], 3) + 1296000),
+			Inequality(x[3] - 240),
+		}
+	},
+	// Best known solution, its active constraints met to within 1e-6
+	Optima:         [][]float64{{0.8125, 0.4375, 42.0984455958549, 176.6365958424394}},
+	OptimumFitness: 6059.714335048436,
+}
+
+const (
+	WeldedBeamLabel = "Welded Beam Design"
+	WeldedBeamP     = 6000.0 // Load (lb)
+	WeldedBeamL     = 14.0   // Overhang of the beam (in)
+	WeldedBeamE     = 30e6   // Young's modulus (psi)
+	WeldedBeamG     = 12e6   // Shear modulus (psi)
+	WeldedBeamTau   = 13600  // Maximum shear stress of the weld (psi)
+	WeldedBeamSigma = 30000  // Maximum bending stress of the beam (psi)
+	WeldedBeamDelta = 0.25   // Maximum deflection of the beam's end (in)
+)
+
+// WeldedBeam is the welded beam design problem of Ragsdell & Phillips (DOI 10.1115/1.3438995), minimising the cost of
+// a beam welded to a support, with the weld thickness (h) and length (l), and the beam's height (t) and thickness (b)
+var WeldedBeam = Problem{
+	Label: WeldedBeamLabel,
+	Variables: []Variable{
+		{Name: "h", Min: 0.1, Max: 2},
+		{Name: "l", Min: 0.1, Max: 10},
+		{Name: "t", Min: 0.1, Max: 10},
+		{Name: "b", Min: 0.1, Max: 2},
+	},
+	Objective: func(x []float64) float64 {
+		return 1.10471*x[0]*x[0]*x[1] + 0.04811*x[2]*x[3]*(14+x[1])
+	},
+	Constraints: func(x []float64) []float64 {
+		h, l, t, b := x[0], x[1], x[2], x[3]
+
+		// Shear stress of the weld, from the primary and torsional stresses
+		primary := WeldedBeamP / (math.Sqrt2 * h * l)
+		moment := WeldedBeamP * (WeldedBeamL + l/2)
+		radius := math.Sqrt(l*l/4 + math.Pow((h+t)/2, 2))
+		inertia := 2 * math.Sqrt2 * h * l * (l*l/12 + math.Pow((h+t)/2, 2))
+		torsional := moment * radius / inertia
+		tau := math.Sqrt(primary*primary + primary*torsional*l/radius + torsional*torsional)
+
+		sigma := 6 * WeldedBeamP * WeldedBeamL / (b * t * t)
+		delta := 4 * WeldedBeamP * math.Pow(WeldedBeamL, 3) / (WeldedBeamE * math.Pow(t, 3) * b)
+		buckling := 4.013 * WeldedBeamE * math.Sqrt(t*t*math.Pow(b, 6)/36) / (WeldedBeamL * WeldedBeamL) *
+			(1 - t/(2*WeldedBeamL)*math.Sqrt(WeldedBeamE/(4*WeldedBeamG)))
+
+		return []float64{
+			Inequality(tau - WeldedBeamTau),
+			Inequality(sigma - WeldedBeamSigma),
+			Inequality(h - b),
+			Inequality(0.10471*h*h + 0.04811*t*b*(14+l) - 5),
+			Inequality(0.125 - h),
+			Inequality(delta - WeldedBeamDelta),
+			Inequality(WeldedBeamP - buckling),
+		}
+	},
+	// Best known solution, its active constraints met to within 1e-6
+	Optima:         [][]float64{{0.20572963978, 3.47048866563, 9.03662391046, 0.20572963978}},
+	OptimumFitness: 1.72485230859,
+}
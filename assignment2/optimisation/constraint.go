@@ -0,0 +1,298 @@
+package optimisation
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// EqualityTolerance is how far an equality constraint may be from 0 and still be satisfied, as in the CEC 2006 suite
+const EqualityTolerance = 1e-4
+
+// Constraints gets how far the decoded variables violate each constraint of a problem, 0 for each one satisfied
+type Constraints func(x []float64) []float64
+
+// Inequality gets the violation of the inequality constraint g(x) <= 0
+func Inequality(g float64) float64 {
+	return math.Max(0, g)
+}
+
+// Equality gets the violation of the equality constraint h(x) = 0, relaxed by EqualityTolerance
+func Equality(h float64) float64 {
+	return math.Max(0, math.Abs(h)-EqualityTolerance)
+}
+
+// Violation gets the total violation of the function's constraints by the genes, 0 if they are feasible or the
+// function is unconstrained
+func (p Params) Violation(genes []uint16) float64 {
+	if p.Constraints == nil {
+		return 0
+	}
+	return sum(p.Constraints(p.Decode(genes)))
+}
+
+// RealViolation is Violation for the real values searched by real-valued algorithms
+func (p Params) RealViolation(x []float64) float64 {
+	if p.Constraints == nil {
+		return 0
+	}
+	return sum(p.Constraints(p.DecodeReal(x)))
+}
+
+// sum adds up the values
+func sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// ConstraintHandler sets how the violation of constraints is combined with the objective, steering algorithms towards
+// feasible solutions
+type ConstraintHandler int
+
+const (
+	StaticPenalty     ConstraintHandler = iota // Adds the squared violations weighted by a fixed penalty coefficient
+	DynamicPenalty                             // Weights the squared violations by (C t)^alpha, growing with generation t
+	AdaptivePenalty                            // Adapts the penalty coefficient to whether recent best solutions were feasible
+	FeasibilityRules                           // Deb's rules: feasible beats infeasible, then by objective or violation
+	StochasticRanking                          // Ranks by objective or violation at random (evolution strategy only)
+)
+
+const (
+	PenaltyExponent     = 2.0 // Exponent of each constraint's violation in the penalties
+	DynamicC            = 0.5 // C of the dynamic penalty as per Joines & Houck (DOI 10.1109/ICEC.1994.349995)
+	DynamicAlpha        = 2.0 // Alpha of the dynamic penalty
+	AdaptiveGenerations = 5   // Generations whose best solutions decide the adaptive penalty as per Hadj-Alouane & Bean (DOI 10.1287/opre.45.1.92)
+	AdaptiveDecrease    = 1.5 // Factor the adaptive penalty coefficient is divided by when they were all feasible
+	AdaptiveIncrease    = 2.0 // Factor the adaptive penalty coefficient is multiplied by when they were all infeasible
+)
+
+// GetConstraintHandler gets a ConstraintHandler by name
+func GetConstraintHandler(name string) (ConstraintHandler, error) {
+	switch name {
+	case "static":
+		return StaticPenalty, nil
+	case "dynamic":
+		return DynamicPenalty, nil
+	case "adaptive":
+		return AdaptivePenalty, nil
+	case "deb":
+		return FeasibilityRules, nil
+	case "stochastic":
+		return StochasticRanking, nil
+	}
+
+	return StaticPenalty, errors.New("invalid constraint handler passed to GetConstraintHandler")
+}
+
+// ConstraintConfig configures how constrained functions are handled
+type ConstraintConfig struct {
+	Handler    ConstraintHandler
+	Penalty    float64 // Coefficient of the static penalty, and the initial coefficient of the adaptive penalty
+	Generation int     // Evaluations making up a generation, for the dynamic and adaptive penalties
+	RankingP   float64 // Probability of stochastic ranking comparing infeasible solutions by objective alone
+}
+
+// DefaultConstraintConfig returns a config for the handler, with generations of the given number of evaluations and
+// the ranking probability 0.45 of Runarsson & Yao (DOI 10.1109/4235.873238)
+func DefaultConstraintConfig(handler ConstraintHandler, generation int) ConstraintConfig {
+	return ConstraintConfig{Handler: handler, Penalty: 1e6, Generation: generation, RankingP: 0.45}
+}
+
+// Feasibility counts the evaluations of a constrained function during a run, and how many of them were feasible
+type Feasibility struct {
+	evaluations int64
+	feasible    int64
+}
+
+// Rate gets the fraction of evaluations that were feasible, 0 when there were none
+func (c *Feasibility) Rate() float64 {
+	if c == nil {
+		return 0
+	}
+	evaluations := atomic.LoadInt64(&c.evaluations)
+	if evaluations == 0 {
+		return 0
+	}
+	return float64(atomic.LoadInt64(&c.feasible)) / float64(evaluations)
+}
+
+// count records an evaluation and whether it was feasible
+func (c *Feasibility) count(feasible bool) {
+	atomic.AddInt64(&c.evaluations, 1)
+	if feasible {
+		atomic.AddInt64(&c.feasible, 1)
+	}
+}
+
+// Constrained gets the parameters of a constrained function for one run of an algorithm, its Function and RealFunction
+// combining the objective with the violation of its constraints as set by the config, along with the Feasibility of
+// the run's evaluations. As the dynamic and adaptive penalties and feasibility rules change over a run, each run needs
+// its own. With StochasticRanking the objective is left unchanged, to be ranked along with Violation. Unconstrained
+// functions are returned unchanged, with nil Feasibility.
+func (p Params) Constrained(config ConstraintConfig) (Params, *Feasibility) {
+	if p.Constraints == nil {
+		return p, nil
+	}
+
+	feasibility := &Feasibility{}
+	handler := &penalty{config: config, direction: p.Direction, lambda: config.Penalty}
+	function, realFunction := p.Function, p.RealFunction
+	p.Function = func(genes []uint16) float64 {
+		violations := p.Constraints(p.Decode(genes))
+		return handler.fitness(function(genes), violations, feasibility)
+	}
+	if realFunction != nil {
+		p.RealFunction = func(x []float64) float64 {
+			return handler.fitness(realFunction(x), p.Constraints(p.DecodeReal(x)), feasibility)
+		}
+	}
+	return p, feasibility
+}
+
+// penalty keeps the state of a ConstraintHandler over a run, guarded as algorithms such as the island GA evaluate
+// concurrently
+type penalty struct {
+	config    ConstraintConfig
+	direction Direction // Direction of the objective, which penalties make worse
+	mutex     sync.Mutex
+	evals     int
+
+	lambda          float64 // Adaptive: current penalty coefficient
+	genBest         float64 // Adaptive: best penalised fitness of the current generation
+	genBestFeasible bool    // Adaptive: whether the current generation's best solution is feasible
+	history         []bool  // Adaptive: whether the best solution of each of the last generations was feasible
+
+	worst        float64 // Feasibility rules: worst objective evaluated
+	anyEvaluated bool    // Feasibility rules: whether any solution has been evaluated
+}
+
+// fitness combines the objective with the violations of a solution, counting whether it is feasible
+func (h *penalty) fitness(objective float64, violations []float64, feasibility *Feasibility) float64 {
+	feasible := sum(violations) == 0
+	feasibility.count(feasible)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.evals++
+
+	var fitness float64
+	switch h.config.Handler {
+	case StaticPenalty:
+		fitness = h.direction.Worsen(objective, h.config.Penalty*penaltyTerm(violations))
+	case DynamicPenalty:
+		t := float64(h.generation() + 1)
+		fitness = h.direction.Worsen(objective, math.Pow(DynamicC*t, DynamicAlpha)*penaltyTerm(violations))
+	case AdaptivePenalty:
+		fitness = h.direction.Worsen(objective, h.lambda*penaltyTerm(violations))
+		h.adapt(fitness, feasible)
+	case FeasibilityRules:
+		// Infeasible solutions are worse than every solution evaluated so far, so worse than every feasible solution
+		// as long as the first evaluations have found close to the worst objective, and are compared by violation
+		if !h.anyEvaluated || h.direction.Better(h.worst, objective) {
+			h.worst, h.anyEvaluated = objective, true
+		}
+		fitness = objective
+		if !feasible {
+			fitness = h.direction.Worsen(h.worst, sum(violations))
+		}
+	default:
+		fitness = objective
+	}
+	return fitness
+}
+
+// generation gets the generation of the latest evaluation, counting from 0
+func (h *penalty) generation() int {
+	if h.config.Generation <= 0 {
+		return 0
+	}
+	return (h.evals - 1) / h.config.Generation
+}
+
+// adapt tracks the best solution of each generation, dividing the penalty coefficient when those of the last
+// AdaptiveGenerations generations were all feasible and multiplying it when they were all infeasible
+func (h *penalty) adapt(fitness float64, feasible bool) {
+	generation := h.config.Generation
+	if generation <= 0 {
+		generation = 1
+	}
+	if (h.evals-1)%generation == 0 || h.direction.Better(fitness, h.genBest) {
+		h.genBest, h.genBestFeasible = fitness, feasible
+	}
+	if h.evals%generation != 0 {
+		return
+	}
+
+	h.history = append(h.history, h.genBestFeasible)
+	if len(h.history) > AdaptiveGenerations {
+		h.history = h.history[1:]
+	}
+	if len(h.history) < AdaptiveGenerations {
+		return
+	}
+	allFeasible, allInfeasible := true, true
+	for _, wasFeasible := range h.history {
+		allFeasible = allFeasible && wasFeasible
+		allInfeasible = allInfeasible && !wasFeasible
+	}
+	if allFeasible {
+		h.lambda /= AdaptiveDecrease
+	} else if allInfeasible {
+		h.lambda *= AdaptiveIncrease
+	}
+}
+
+// penaltyTerm sums each constraint's violation raised to PenaltyExponent
+func penaltyTerm(violations []float64) float64 {
+	var total float64
+	for _, v := range violations {
+		total += math.Pow(v, PenaltyExponent)
+	}
+	return total
+}
+
+// FeasibilityBetter reports whether a solution with fitness a and violation violationA is better than one with
+// fitness b and violation violationB by Deb's rules: a feasible solution beats an infeasible one, feasible solutions
+// are compared by fitness in the direction and infeasible solutions by violation
+func FeasibilityBetter(direction Direction, a float64, violationA float64, b float64, violationB float64) bool {
+	if violationA == 0 && violationB == 0 {
+		return direction.Better(a, b)
+	}
+	return violationA < violationB
+}
+
+// StochasticRank ranks solutions by their fitness and violation with the stochastic bubble sort of Runarsson & Yao
+// (DOI 10.1109/4235.873238). Adjacent solutions are compared by fitness if both are feasible, or otherwise with
+// probability rankingP, and by violation the rest of the time. Return the solutions' indices, best first.
+func StochasticRank(fitness []float64, violation []float64, rankingP float64, direction Direction, r *rand.Rand) []int {
+	order := make([]int, len(fitness))
+	for i := range order {
+		order[i] = i
+	}
+
+	for sweep := 0; sweep < len(order); sweep++ {
+		swapped := false
+		for j := 0; j < len(order)-1; j++ {
+			a, b := order[j], order[j+1]
+			var swap bool
+			if (violation[a] == 0 && violation[b] == 0) || r.Float64() < rankingP {
+				swap = direction.Better(fitness[b], fitness[a])
+			} else {
+				swap = violation[a] > violation[b]
+			}
+			if swap {
+				order[j], order[j+1] = b, a
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+	return order
+}
@@ -0,0 +1,159 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var constrained = []string{"g01", "g02", "g03", "g04", "g05", "g06", "g07", "g08", "g09", "g10", "g11", "g12", "g13",
+	"pressurevessel", "weldedbeam"}
+
+func TestGetConstraintHandler(t *testing.T) {
+	handler, err := GetConstraintHandler("deb")
+	assert.Nil(t, err, "deb should be a constraint handler")
+	assert.Equal(t, FeasibilityRules, handler, "deb should be Deb's feasibility rules")
+	handler, err = GetConstraintHandler("stochastic")
+	assert.Nil(t, err, "stochastic should be a constraint handler")
+	assert.Equal(t, StochasticRanking, handler, "stochastic should be stochastic ranking")
+	_, err = GetConstraintHandler("death")
+	assert.NotNil(t, err, "Unknown constraint handlers should be rejected")
+}
+
+func TestInequality_Equality(t *testing.T) {
+	assert.Equal(t, 0.0, Inequality(-2), "Satisfied inequality should not be violated")
+	assert.Equal(t, 3.0, Inequality(3), "Inequality should be violated by how far it is above 0")
+	assert.Equal(t, 0.0, Equality(EqualityTolerance/2), "Equality within the tolerance should not be violated")
+	assert.InDelta(t, 1.0, Equality(-1-EqualityTolerance), 1e-12, "Equality should be violated by how far it is beyond the tolerance")
+}
+
+func TestConstrained_Optimum(t *testing.T) {
+	for _, name := range constrained {
+		params, err := GetParams(name)
+		assert.Nil(t, err, name+" should exist")
+		assert.NotNil(t, params.Constraints, name+" should be constrained")
+		assert.NotEqual(t, 0.0, params.OptimumFitness, name+" should have its optimum fitness")
+
+		problem := Problem{Variables: params.Variables}
+		for _, optimum := range params.Optima {
+			assert.Equal(t, params.N, len(optimum), "Optimum of "+name+" should have every variable")
+			assert.InDelta(t, 0, sum(params.Constraints(optimum)), 1e-6, "Optimum of "+name+" should be feasible")
+			// Fitness at the optimum's nearest genes is evaluated with the objective through RealFunction
+			fitness := params.RealFunction(unit(problem, optimum))
+			assert.InDelta(t, params.OptimumFitness, fitness, 1e-9*math.Max(1, math.Abs(params.OptimumFitness)), "Optimum of "+name+" should have the optimum fitness")
+		}
+	}
+}
+
+// unit gets the position of each variable's value within the unit hypercube
+func unit(problem Problem, x []float64) []float64 {
+	u := make([]float64, len(x))
+	for i, v := range problem.Variables {
+		u[i] = v.EncodeUnit(x[i])
+	}
+	return u
+}
+
+func TestParams_Violation(t *testing.T) {
+	params := G06.Params()
+	assert.Equal(t, 0.0, Params{}.Violation([]uint16{0}), "Unconstrained functions should not be violated")
+	// Every gene 0 decodes to the lower bounds (13, 0), inside the first circle
+	assert.InDelta(t, 100-math.Pow(13-5, 2)-25, params.Violation([]uint16{0, 0}), 1e-9, "Violation should total each constraint's violation")
+}
+
+// constrainedParams gets parameters with objective x, and the inequality constraint x <= 0
+func constrainedParams() Params {
+	return Params{
+		Function:    func(genes []uint16) float64 { return float64(genes[0]) },
+		ScaleMin:    0,
+		ScaleMax:    65535,
+		Constraints: func(x []float64) []float64 { return []float64{Inequality(x[0])} },
+	}
+}
+
+func TestParams_Constrained_Unconstrained(t *testing.T) {
+	params, feasibility := Params{Function: func(genes []uint16) float64 { return 1 }}.Constrained(DefaultConstraintConfig(StaticPenalty, 10))
+	assert.Nil(t, feasibility, "Unconstrained functions should not count feasibility")
+	assert.Equal(t, 1.0, params.Function([]uint16{5}), "Unconstrained functions should be unchanged")
+}
+
+func TestParams_Constrained_Static(t *testing.T) {
+	config := DefaultConstraintConfig(StaticPenalty, 10)
+	config.Penalty = 100
+	params, feasibility := constrainedParams().Constrained(config)
+	assert.Equal(t, 0.0, params.Function([]uint16{0}), "Feasible solutions should not be penalised")
+	assert.Equal(t, 3+100*9.0, params.Function([]uint16{3}), "Infeasible solutions should be penalised by their squared violation")
+	assert.Equal(t, 0.5, feasibility.Rate(), "Half the evaluations were feasible")
+}
+
+func TestParams_Constrained_Maximised(t *testing.T) {
+	config := DefaultConstraintConfig(StaticPenalty, 10)
+	config.Penalty = 100
+	maximised := constrainedParams()
+	maximised.Direction = Maximise
+	params, _ := maximised.Constrained(config)
+	assert.Equal(t, 3-100*9.0, params.Function([]uint16{3}), "Maximised infeasible solutions should be penalised downwards")
+}
+
+func TestParams_Constrained_Dynamic(t *testing.T) {
+	params, _ := constrainedParams().Constrained(DefaultConstraintConfig(DynamicPenalty, 2))
+	assert.Equal(t, 2+math.Pow(DynamicC, DynamicAlpha)*4, params.Function([]uint16{2}), "First generation should be penalised with t = 1")
+	params.Function([]uint16{0})
+	assert.Equal(t, 2+math.Pow(2*DynamicC, DynamicAlpha)*4, params.Function([]uint16{2}), "Second generation should be penalised with t = 2")
+}
+
+func TestParams_Constrained_Adaptive(t *testing.T) {
+	config := DefaultConstraintConfig(AdaptivePenalty, 1)
+	config.Penalty = 1
+	params, _ := constrainedParams().Constrained(config)
+	for i := 0; i < AdaptiveGenerations; i++ {
+		assert.Equal(t, 1+1.0, params.Function([]uint16{1}), "Penalty should be unchanged until enough generations have passed")
+	}
+	assert.Equal(t, 1+AdaptiveIncrease, params.Function([]uint16{1}), "Penalty should increase once recent best solutions were all infeasible")
+	for i := 0; i < AdaptiveGenerations; i++ {
+		params.Function([]uint16{0})
+	}
+	assert.Equal(t, 1+AdaptiveIncrease*AdaptiveIncrease/AdaptiveDecrease, params.Function([]uint16{1}), "Penalty should decrease once recent best solutions were all feasible")
+}
+
+func TestParams_Constrained_FeasibilityRules(t *testing.T) {
+	params := Params{
+		Function:    func(genes []uint16) float64 { return -float64(genes[0]) },
+		ScaleMin:    0,
+		ScaleMax:    65535,
+		Constraints: func(x []float64) []float64 { return []float64{Inequality(x[0] - 10)} },
+	}
+	params, _ = params.Constrained(DefaultConstraintConfig(FeasibilityRules, 10))
+	assert.Equal(t, -15+5.0, params.Function([]uint16{15}), "Infeasible solutions should be worse than the worst objective evaluated")
+	assert.Equal(t, -2.0, params.Function([]uint16{2}), "Feasible solutions should be compared by objective")
+	assert.Equal(t, -10.0, params.Function([]uint16{10}), "Feasible solutions should be compared by objective")
+	assert.Equal(t, -2+1.0, params.Function([]uint16{11}), "Infeasible solutions should be worse than the worst feasible solution")
+	assert.Equal(t, -2+3.0, params.Function([]uint16{13}), "Infeasible solutions should be compared by violation")
+}
+
+func TestFeasibilityBetter(t *testing.T) {
+	assert.True(t, FeasibilityBetter(Minimise, 100, 0, -100, 1), "Feasible solutions should beat infeasible solutions")
+	assert.True(t, FeasibilityBetter(Minimise, 1, 0, 2, 0), "Feasible solutions should be compared by fitness")
+	assert.True(t, FeasibilityBetter(Maximise, 2, 0, 1, 0), "Feasible solutions should be compared by fitness in the direction")
+	assert.True(t, FeasibilityBetter(Minimise, 100, 1, -100, 2), "Infeasible solutions should be compared by violation")
+}
+
+func TestStochasticRank(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	fitness := []float64{1, 4, 2, 3}
+	violation := []float64{0, 0, 2, 1}
+	assert.Equal(t, []int{0, 2, 3, 1}, StochasticRank(fitness, violation, 1, Minimise, r), "Ranking probability 1 should rank by fitness alone")
+	assert.Equal(t, []int{0, 1, 3, 2}, StochasticRank(fitness, violation, 0, Minimise, r), "Ranking probability 0 should rank feasible solutions first, then by violation")
+}
+
+func TestFeasibility_Rate(t *testing.T) {
+	var feasibility *Feasibility
+	assert.Equal(t, 0.0, feasibility.Rate(), "Nil feasibility should have rate 0")
+	feasibility = &Feasibility{}
+	feasibility.count(true)
+	feasibility.count(false)
+	feasibility.count(false)
+	feasibility.count(true)
+	assert.Equal(t, 0.5, feasibility.Rate(), "Rate should be the fraction of feasible evaluations")
+}
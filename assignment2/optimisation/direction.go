@@ -0,0 +1,57 @@
+package optimisation
+
+import (
+	"errors"
+	"math"
+)
+
+// Direction sets whether an optimisation function is minimised or maximised
+type Direction int
+
+const (
+	Minimise Direction = iota // Smaller fitness is better, as for the benchmark functions
+	Maximise                  // Larger fitness is better, as for OneMax or a classifier's accuracy
+)
+
+// GetDirection gets a Direction by name
+func GetDirection(name string) (Direction, error) {
+	switch name {
+	case "minimise":
+		return Minimise, nil
+	case "maximise":
+		return Maximise, nil
+	}
+
+	return Minimise, errors.New("invalid direction passed to GetDirection")
+}
+
+func (d Direction) String() string {
+	if d == Maximise {
+		return "maximise"
+	}
+	return "minimise"
+}
+
+// Better reports whether fitness a is better than fitness b
+func (d Direction) Better(a float64, b float64) bool {
+	if d == Maximise {
+		return a > b
+	}
+	return a < b
+}
+
+// Worst gets the worst possible fitness, which every fitness found is at least as good as
+func (d Direction) Worst() float64 {
+	if d == Maximise {
+		return -math.MaxFloat64
+	}
+	return math.MaxFloat64
+}
+
+// Worsen makes a fitness worse by amount, as when penalising it
+func (d Direction) Worsen(fitness float64, amount float64) float64 {
+	if d == Maximise {
+		return fitness - amount
+	}
+	return fitness + amount
+}
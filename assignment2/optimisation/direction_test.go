@@ -0,0 +1,34 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetDirection(t *testing.T) {
+	for _, d := range []Direction{Minimise, Maximise} {
+		direction, err := GetDirection(d.String())
+		assert.Nil(t, err, "Direction should be found by name")
+		assert.Equal(t, d, direction, "Direction should be found by name")
+	}
+	_, err := GetDirection("sideways")
+	assert.NotNil(t, err, "Unknown direction should not be found")
+}
+
+func TestDirection_Better(t *testing.T) {
+	assert.True(t, Minimise.Better(-2, 1), "Smaller fitness should be better when minimising")
+	assert.True(t, Maximise.Better(1, -2), "Larger fitness should be better when maximising")
+	assert.False(t, Maximise.Better(1, 1), "Equal fitness should not be better")
+}
+
+func TestDirection_Worst(t *testing.T) {
+	for _, d := range []Direction{Minimise, Maximise} {
+		assert.True(t, d.Better(0, d.Worst()), "Any fitness should be better than the worst")
+		assert.True(t, d.Better(1, d.Worsen(1, 0.5)), "Worsened fitness should be worse")
+	}
+
+	params, _ := GetParams("onemax")
+	assert.Equal(t, Maximise, params.Direction, "OneMax should be maximised")
+	genes := []uint16{0xFFFF, 0x00FF, 0, 1, 3, 7, 15, 31, 63, 127}
+	assert.Equal(t, 16.0+8+1+2+3+4+5+6+7, params.Function(genes), "OneMax should count the bits set")
+}
@@ -0,0 +1,93 @@
+package optimisation
+
+import "errors"
+
+// Encoding sets how the bits of a uint16 gene represent its value when decoded
+type Encoding int
+
+const (
+	Binary Encoding = iota // Standard binary, where neighbouring values may differ in every bit (e.g. 0x7FFF and 0x8000)
+	Gray                   // Reflected binary Gray code, where neighbouring values always differ in exactly one bit
+)
+
+// GetEncoding gets an Encoding by name
+func GetEncoding(name string) (Encoding, error) {
+	switch name {
+	case "binary":
+		return Binary, nil
+	case "gray":
+		return Gray, nil
+	}
+
+	return Binary, errors.New("invalid encoding passed to GetEncoding")
+}
+
+// String gets the name of the Encoding
+func (e Encoding) String() string {
+	if e == Gray {
+		return "gray"
+	}
+	return "binary"
+}
+
+// BinaryToGray converts a binary value to its Gray code
+func BinaryToGray(value uint16) uint16 {
+	return value ^ (value >> 1)
+}
+
+// GrayToBinary converts a Gray code to its binary value
+func GrayToBinary(gray uint16) uint16 {
+	value := gray
+	for shift := uint(1); shift < 16; shift <<= 1 {
+		value ^= value >> shift
+	}
+	return value
+}
+
+// Value gets the binary value a gene represents with the Encoding
+func (e Encoding) Value(gene uint16) uint16 {
+	if e == Gray {
+		return GrayToBinary(gene)
+	}
+	return gene
+}
+
+// Gene gets the gene representing a binary value with the Encoding
+func (e Encoding) Gene(value uint16) uint16 {
+	if e == Gray {
+		return BinaryToGray(value)
+	}
+	return value
+}
+
+// Values gets the binary value each gene represents with the Encoding
+func (e Encoding) Values(genes []uint16) []uint16 {
+	if e == Binary {
+		return genes
+	}
+	values := make([]uint16, len(genes))
+	for i := range genes {
+		values[i] = e.Value(genes[i])
+	}
+	return values
+}
+
+// WithEncoding gets the parameters of the function with its genes decoded by the Encoding. The functions are defined
+// on binary genes, so Gray-coded genes are converted to their binary values before being evaluated or decoded.
+func (p Params) WithEncoding(e Encoding) Params {
+	p.Encoding = e
+	if e == Binary {
+		return p
+	}
+
+	function := p.Function
+	p.Function = func(genes []uint16) float64 {
+		return function(e.Values(genes))
+	}
+	if noiseFree := p.NoiseFree; noiseFree != nil {
+		p.NoiseFree = func(genes []uint16) float64 {
+			return noiseFree(e.Values(genes))
+		}
+	}
+	return p
+}
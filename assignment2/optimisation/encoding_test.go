@@ -0,0 +1,45 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/bits"
+	"testing"
+)
+
+func TestGetEncoding(t *testing.T) {
+	e, err := GetEncoding("gray")
+	assert.Nil(t, err, "GetEncoding should find gray")
+	assert.Equal(t, Gray, e, "GetEncoding returned wrong encoding")
+	assert.Equal(t, "gray", e.String(), "Encoding name should round trip")
+
+	_, err = GetEncoding("invalid")
+	assert.NotNil(t, err, "GetEncoding should error for unknown encoding")
+}
+
+func TestGrayCode(t *testing.T) {
+	for value := 0; value < 65535; value++ {
+		gray := BinaryToGray(uint16(value))
+		assert.Equal(t, uint16(value), GrayToBinary(gray), "Gray code should convert back to its value")
+		assert.Equal(t, 1, bits.OnesCount16(gray^BinaryToGray(uint16(value+1))), "Neighbouring values should differ in one bit")
+	}
+}
+
+func TestParams_WithEncoding(t *testing.T) {
+	params, _ := GetParams("rastrigin")
+	cliff := []uint16{0x7FFF, 0x8000}
+	assert.InDelta(t, 0, params.Decode(cliff)[0], 1e-3, "Binary decodes the Hamming cliff to neighbouring values")
+	assert.InDelta(t, 0, params.Decode(cliff)[1], 1e-3, "Binary decodes the Hamming cliff to neighbouring values")
+
+	gray := params.WithEncoding(Gray)
+	bounds := gray.GetBounds(Clamp)
+	genes := make([]uint16, RastriginN)
+	for i := range genes {
+		genes[i] = uint16(0x7FFF + 1000*i)
+	}
+	assert.Equal(t, Gray, bounds.Encoding, "Bounds should decode with the function's encoding")
+	assert.Equal(t, gray.Decode(genes), bounds.DecodeAll(genes), "Bounds and Params should decode alike")
+	assert.Equal(t, RastriginReal(gray.Decode(genes)), gray.Function(genes), "Function should decode Gray-coded genes")
+	assert.Equal(t, uint16(0x4000), bounds.Encode(bounds.Decode(0x4000)), "Encoding a decoded gene should give the same gene")
+	assert.Equal(t, uint16(0xC000), bounds.Encode(0), "Gray encoded midpoint")
+	assert.Equal(t, Binary, params.Encoding, "Original parameters should be unchanged")
+}
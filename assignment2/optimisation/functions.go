@@ -8,12 +8,26 @@ import (
 type Fitness func(x []uint16) float64
 
 type Params struct {
-	Function  Fitness
-	Label     string
-	N         int
-	MutationP float32
-	ScaleMin  float64
-	ScaleMax  float64
+	Function     Fitness
+	RealFunction RealFitness // Function evaluated directly on real values, without the 16-bit encoding
+	Label        string
+	N            int
+	MutationP    float32
+	ScaleMin     float64
+	ScaleMax     float64
+	Variables    []Variable  // Each variable's own bounds and type, if they differ (see Problem)
+	Optima       [][]float64 // Known global optima, if any, for measuring how far solutions are from them
+	Constraints  Constraints // Violation of each constraint by the decoded variables, if constrained
+	NoiseFree    Fitness     // Function without its noise, if noisy, for the true fitness of solutions
+	Noise        *Noise      // Noise added to each evaluation, if noisy
+	Encoding     Encoding    // Encoding genes are decoded with, applied to Function by WithEncoding
+
+	Direction      Direction // Whether fitness is minimised (the default) or maximised
+	OptimumFitness float64   // Fitness at the global optimum
+	PlotMin        float64   // Y-axis lower limit of fitness charts, 0 to fit the data
+	PlotMax        float64   // Y-axis upper limit of fitness charts, 0 to fit the data
+
+	noiseFreeReal RealFitness // RealFunction without its noise, if noisy, for Reseeded
 }
 
 // GetParams gets the parameters required for using the algorithms on an optimisation function
@@ -21,49 +35,258 @@ func GetParams(algo string) (Params, error) {
 	switch algo {
 	case "rastrigin":
 		return Params{
-			Function:  Rastrigin,
-			Label:     RastriginLabel,
-			N:         RastriginN,
-			MutationP: RastriginMutationP,
-			ScaleMin:  RastriginMin,
-			ScaleMax:  RastriginMax,
+			Function:     Rastrigin,
+			RealFunction: RastriginReal,
+			Label:        RastriginLabel,
+			N:            RastriginN,
+			MutationP:    RastriginMutationP,
+			ScaleMin:     RastriginMin,
+			ScaleMax:     RastriginMax,
+			Optima:       [][]float64{repeat(0, RastriginN)},
+			PlotMax:      RastriginPlotMax,
 		}, nil
 	case "schwefel":
 		return Params{
-			Function:  Schwefel,
-			Label:     SchwefelLabel,
-			N:         SchwefelN,
-			MutationP: SchwefelMutationP,
-			ScaleMin:  SchwefelMin,
-			ScaleMax:  SchwefelMax,
+			Function:     Schwefel,
+			RealFunction: SchwefelReal,
+			Label:        SchwefelLabel,
+			N:            SchwefelN,
+			MutationP:    SchwefelMutationP,
+			ScaleMin:     SchwefelMin,
+			ScaleMax:     SchwefelMax,
+			Optima:       [][]float64{repeat(SchwefelOptimum, SchwefelN)},
+			PlotMax:      SchwefelPlotMax,
 		}, nil
 	case "griewangk":
 		return Params{
-			Function:  Griewangk,
-			Label:     GriewangkLabel,
-			N:         GriewangkN,
-			MutationP: GriewangkMutationP,
-			ScaleMin:  GriewangkMin,
-			ScaleMax:  GriewangkMax,
+			Function:     Griewangk,
+			RealFunction: GriewangkReal,
+			Label:        GriewangkLabel,
+			N:            GriewangkN,
+			MutationP:    GriewangkMutationP,
+			ScaleMin:     GriewangkMin,
+			ScaleMax:     GriewangkMax,
+			Optima:       [][]float64{repeat(0, GriewangkN)},
+			PlotMax:      GriewangkPlotMax,
 		}, nil
 	case "ackley":
 		return Params{
-			Function:  Ackley,
-			Label:     AckleyLabel,
-			N:         AckleyN,
-			MutationP: AckleyMutationP,
-			ScaleMin:  AckleyMin,
-			ScaleMax:  AckleyMax,
+			Function:     Ackley,
+			RealFunction: AckleyReal,
+			Label:        AckleyLabel,
+			N:            AckleyN,
+			MutationP:    AckleyMutationP,
+			ScaleMin:     AckleyMin,
+			ScaleMax:     AckleyMax,
+			Optima:       [][]float64{repeat(0, AckleyN)},
+			PlotMax:      AckleyPlotMax,
 		}, nil
 	case "rosenbrock":
 		return Params{
-			Function:  Rosenbrock,
-			Label:     RosenbrockLabel,
-			N:         RosenbrockN,
-			MutationP: RosenbrockMutationP,
-			ScaleMin:  RosenbrockMin,
-			ScaleMax:  RosenbrockMax,
+			Function:     Rosenbrock,
+			RealFunction: RosenbrockReal,
+			Label:        RosenbrockLabel,
+			N:            RosenbrockN,
+			MutationP:    RosenbrockMutationP,
+			ScaleMin:     RosenbrockMin,
+			ScaleMax:     RosenbrockMax,
+			Optima:       [][]float64{repeat(1, RosenbrockN)},
+		}, nil
+	case "sphere":
+		return Params{
+			Function:     Encoded(SphereReal, SphereMin, SphereMax),
+			RealFunction: SphereReal,
+			Label:        SphereLabel,
+			N:            SphereN,
+			MutationP:    SphereMutationP,
+			ScaleMin:     SphereMin,
+			ScaleMax:     SphereMax,
+			Optima:       [][]float64{repeat(0, SphereN)},
+			PlotMax:      SpherePlotMax,
+		}, nil
+	case "ellipsoid":
+		return Params{
+			Function:     Encoded(EllipsoidReal, EllipsoidMin, EllipsoidMax),
+			RealFunction: EllipsoidReal,
+			Label:        EllipsoidLabel,
+			N:            EllipsoidN,
+			MutationP:    EllipsoidMutationP,
+			ScaleMin:     EllipsoidMin,
+			ScaleMax:     EllipsoidMax,
+			Optima:       [][]float64{repeat(0, EllipsoidN)},
+			PlotMax:      EllipsoidPlotMax,
+		}, nil
+	case "step":
+		return Params{
+			Function:     Encoded(StepReal, StepMin, StepMax),
+			RealFunction: StepReal,
+			Label:        StepLabel,
+			N:            StepN,
+			MutationP:    StepMutationP,
+			ScaleMin:     StepMin,
+			ScaleMax:     StepMax,
+			Optima:       [][]float64{repeat(0, StepN)},
+			PlotMax:      StepPlotMax,
+		}, nil
+	case "schafferf6":
+		return Params{
+			Function:     Encoded(SchafferF6Real, SchafferF6Min, SchafferF6Max),
+			RealFunction: SchafferF6Real,
+			Label:        SchafferF6Label,
+			N:            SchafferF6N,
+			MutationP:    SchafferF6MutationP,
+			ScaleMin:     SchafferF6Min,
+			ScaleMax:     SchafferF6Max,
+			Optima:       [][]float64{repeat(0, SchafferF6N)},
+			PlotMax:      SchafferF6PlotMax,
+		}, nil
+	case "schafferf7":
+		return Params{
+			Function:     Encoded(SchafferF7Real, SchafferF7Min, SchafferF7Max),
+			RealFunction: SchafferF7Real,
+			Label:        SchafferF7Label,
+			N:            SchafferF7N,
+			MutationP:    SchafferF7MutationP,
+			ScaleMin:     SchafferF7Min,
+			ScaleMax:     SchafferF7Max,
+			Optima:       [][]float64{repeat(0, SchafferF7N)},
+			PlotMax:      SchafferF7PlotMax,
+		}, nil
+	case "levy":
+		return Params{
+			Function:     Encoded(LevyReal, LevyMin, LevyMax),
+			RealFunction: LevyReal,
+			Label:        LevyLabel,
+			N:            LevyN,
+			MutationP:    LevyMutationP,
+			ScaleMin:     LevyMin,
+			ScaleMax:     LevyMax,
+			Optima:       [][]float64{repeat(LevyOptimum, LevyN)},
+			PlotMax:      LevyPlotMax,
+		}, nil
+	case "weierstrass":
+		return Params{
+			Function:     Encoded(WeierstrassReal, WeierstrassMin, WeierstrassMax),
+			RealFunction: WeierstrassReal,
+			Label:        WeierstrassLabel,
+			N:            WeierstrassN,
+			MutationP:    WeierstrassMutationP,
+			ScaleMin:     WeierstrassMin,
+			ScaleMax:     WeierstrassMax,
+			Optima:       [][]float64{repeat(0, WeierstrassN)},
+			PlotMax:      WeierstrassPlotMax,
+		}, nil
+	case "michalewicz":
+		return Params{
+			Function:       Encoded(MichalewiczReal, MichalewiczMin, MichalewiczMax),
+			RealFunction:   MichalewiczReal,
+			Label:          MichalewiczLabel,
+			N:              MichalewiczN,
+			MutationP:      MichalewiczMutationP,
+			ScaleMin:       MichalewiczMin,
+			ScaleMax:       MichalewiczMax,
+			OptimumFitness: MichalewiczOptimumFitness,
+			PlotMin:        MichalewiczPlotMin,
+		}, nil
+	case "zakharov":
+		return Params{
+			Function:     Encoded(ZakharovReal, ZakharovMin, ZakharovMax),
+			RealFunction: ZakharovReal,
+			Label:        ZakharovLabel,
+			N:            ZakharovN,
+			MutationP:    ZakharovMutationP,
+			ScaleMin:     ZakharovMin,
+			ScaleMax:     ZakharovMax,
+			Optima:       [][]float64{repeat(0, ZakharovN)},
+			PlotMax:      ZakharovPlotMax,
+		}, nil
+	case "lunacek":
+		return Params{
+			Function:     Encoded(LunacekReal, LunacekMin, LunacekMax),
+			RealFunction: LunacekReal,
+			Label:        LunacekLabel,
+			N:            LunacekN,
+			MutationP:    LunacekMutationP,
+			ScaleMin:     LunacekMin,
+			ScaleMax:     LunacekMax,
+			Optima:       [][]float64{repeat(LunacekMu0, LunacekN)},
+			PlotMax:      LunacekPlotMax,
+		}, nil
+	case "katsuura":
+		return Params{
+			Function:     Encoded(KatsuuraReal, KatsuuraMin, KatsuuraMax),
+			RealFunction: KatsuuraReal,
+			Label:        KatsuuraLabel,
+			N:            KatsuuraN,
+			MutationP:    KatsuuraMutationP,
+			ScaleMin:     KatsuuraMin,
+			ScaleMax:     KatsuuraMax,
+			Optima:       [][]float64{repeat(0, KatsuuraN)},
+			PlotMax:      KatsuuraPlotMax,
 		}, nil
+	case "styblinskitang":
+		return Params{
+			Function:       Encoded(StyblinskiTangReal, StyblinskiTangMin, StyblinskiTangMax),
+			RealFunction:   StyblinskiTangReal,
+			Label:          StyblinskiTangLabel,
+			N:              StyblinskiTangN,
+			MutationP:      StyblinskiTangMutationP,
+			ScaleMin:       StyblinskiTangMin,
+			ScaleMax:       StyblinskiTangMax,
+			Optima:         [][]float64{repeat(StyblinskiTangOptimum, StyblinskiTangN)},
+			OptimumFitness: StyblinskiTangReal(repeat(StyblinskiTangOptimum, StyblinskiTangN)),
+			PlotMin:        StyblinskiTangPlotMin,
+			PlotMax:        StyblinskiTangPlotMax,
+		}, nil
+	case "onemax":
+		return Params{
+			Function:       Encoded(OneMaxReal, OneMaxMin, OneMaxMax),
+			RealFunction:   OneMaxReal,
+			Label:          OneMaxLabel,
+			N:              OneMaxN,
+			MutationP:      OneMaxMutationP,
+			ScaleMin:       OneMaxMin,
+			ScaleMax:       OneMaxMax,
+			Optima:         [][]float64{repeat(OneMaxMax, OneMaxN)},
+			Direction:      Maximise,
+			OptimumFitness: 16 * OneMaxN,
+			PlotMin:        OneMaxPlotMin,
+		}, nil
+	case "geartrain":
+		return GearTrain.Params(), nil
+	case "g01":
+		return G01.Params(), nil
+	case "g02":
+		return G02.Params(), nil
+	case "g03":
+		return G03.Params(), nil
+	case "g04":
+		return G04.Params(), nil
+	case "g05":
+		return G05.Params(), nil
+	case "g06":
+		return G06.Params(), nil
+	case "g07":
+		return G07.Params(), nil
+	case "g08":
+		return G08.Params(), nil
+	case "g09":
+		return G09.Params(), nil
+	case "g10":
+		return G10.Params(), nil
+	case "g11":
+		return G11.Params(), nil
+	case "g12":
+		return G12.Params(), nil
+	case "g13":
+		return G13.Params(), nil
+	case "pressurevessel":
+		return PressureVessel.Params(), nil
+	case "weldedbeam":
+		return WeldedBeam.Params(), nil
+	}
+	if l, err := GetLSGO(algo); err == nil {
+		return l.Params(), nil
 	}
 
 	return Params{}, errors.New("invalid function passed to GetParams")
@@ -75,15 +298,20 @@ const (
 	RastriginMin       = -5.12
 	RastriginMax       = 5.12
 	RastriginMutationP = float32(1) / (float32(16) * RastriginN)
+	RastriginPlotMax   = 40 // Y-axis limit used in the paper's charts
 )
 
 func Rastrigin(x []uint16) float64 {
-	xScaled := ScaleInputs(x[:], RastriginMin, RastriginMax)
+	return RastriginReal(ScaleInputs(x[:RastriginN], RastriginMin, RastriginMax))
+}
+
+// RastriginReal is the Rastrigin Function of real values
+func RastriginReal(xScaled []float64) float64 {
 	sum := 0.0
-	for i := 0; i < RastriginN; i++ {
+	for i := 0; i < len(xScaled); i++ {
 		sum += math.Pow(xScaled[i], 2.0) - 3.0*math.Cos(2.0*math.Pi*xScaled[i])
 	}
-	return 3.0*float64(RastriginN) + sum
+	return 3.0*float64(len(xScaled)) + sum
 }
 
 const (
@@ -92,16 +320,22 @@ const (
 	SchwefelMin       = -500.0
 	SchwefelMax       = 500.0
 	SchwefelMutationP = float32(1) / (float32(16) * SchwefelN)
+	SchwefelPlotMax   = 400      // Y-axis limit used in the paper's charts
+	SchwefelOptimum   = 420.9687 // Value of every variable at the global optimum
 )
 
 // Schwefel Function differs to that in the paper, the paper has a mistake in a sign (+ve instead of -ve)
 func Schwefel(x []uint16) float64 {
-	xScaled := ScaleInputs(x[:], SchwefelMin, SchwefelMax)
+	return SchwefelReal(ScaleInputs(x[:SchwefelN], SchwefelMin, SchwefelMax))
+}
+
+// SchwefelReal is the Schwefel Function of real values
+func SchwefelReal(xScaled []float64) float64 {
 	sum := 0.0
-	for i := 0; i < SchwefelN; i++ {
+	for i := 0; i < len(xScaled); i++ {
 		sum += xScaled[i] * math.Sin(math.Sqrt(math.Abs(xScaled[i])))
 	}
-	return 418.9829*float64(SchwefelN) - sum
+	return 418.9829*float64(len(xScaled)) - sum
 }
 
 const (
@@ -110,13 +344,18 @@ const (
 	GriewangkMin       = -600.0
 	GriewangkMax       = 600.0
 	GriewangkMutationP = float32(1) / (float32(16) * GriewangkN)
+	GriewangkPlotMax   = 8 // Y-axis limit used in the paper's charts
 )
 
 func Griewangk(x []uint16) float64 {
-	xScaled := ScaleInputs(x[:], GriewangkMin, GriewangkMax)
+	return GriewangkReal(ScaleInputs(x[:GriewangkN], GriewangkMin, GriewangkMax))
+}
+
+// GriewangkReal is the Griewangk Function of real values
+func GriewangkReal(xScaled []float64) float64 {
 	sigma := 0.0
 	product := 1.0
-	for i := 0; i < GriewangkN; i++ {
+	for i := 0; i < len(xScaled); i++ {
 		sigma += math.Pow(xScaled[i], 2) / 4000
 		product *= math.Cos(xScaled[i] / math.Sqrt(float64(i+1)))
 	}
@@ -130,18 +369,23 @@ const (
 	AckleyMin       = -30.0
 	AckleyMax       = 30.0
 	AckleyMutationP = float32(1) / (float32(16) * AckleyN)
+	AckleyPlotMax   = 16 // Y-axis limit used in the paper's charts
 )
 
 func Ackley(x []uint16) float64 {
-	xScaled := ScaleInputs(x[:], AckleyMin, AckleyMax)
+	return AckleyReal(ScaleInputs(x[:AckleyN], AckleyMin, AckleyMax))
+}
+
+// AckleyReal is the Ackley Function of real values
+func AckleyReal(xScaled []float64) float64 {
 	sumA, sumB := 0.0, 0.0
-	for i := 0; i < AckleyN; i++ {
+	for i := 0; i < len(xScaled); i++ {
 		sumA += math.Pow(xScaled[i], 2)
 		sumB += math.Cos(2.0 * math.Pi * xScaled[i])
 	}
 
-	sumA *= 1 / float64(AckleyN)
-	sumB *= 1 / float64(AckleyN)
+	sumA *= 1 / float64(len(xScaled))
+	sumB *= 1 / float64(len(xScaled))
 
 	return 20.0 + math.E - 20.0*math.Exp(-0.2*math.Sqrt(sumA)) - math.Exp(sumB)
 }
@@ -155,15 +399,28 @@ const (
 )
 
 func Rosenbrock(x []uint16) float64 {
-	xScaled := ScaleInputs(x[:], RosenbrockMin, RosenbrockMax)
+	return RosenbrockReal(ScaleInputs(x[:RosenbrockN], RosenbrockMin, RosenbrockMax))
+}
+
+// RosenbrockReal is the Rosenbrock Function of real values
+func RosenbrockReal(xScaled []float64) float64 {
 	sum := 0.0
-	for i := 0; i < RosenbrockN/2; i++ {
+	for i := 0; i < len(xScaled)/2; i++ {
 		sum += math.Pow(100.0*(xScaled[2*i]-xScaled[2*i+1]), 2) + math.Pow(xScaled[2*i]-1, 2)
 	}
 
 	return sum
 }
 
+// repeat creates a point with every one of its N variables set to value
+func repeat(value float64, N int) []float64 {
+	x := make([]float64, N)
+	for i := range x {
+		x[i] = value
+	}
+	return x
+}
+
 func TestFunc(_ []uint16) float64 {
 	return 0.0
 }
@@ -72,3 +72,24 @@ func TestScaleInputs(t *testing.T) {
 		assert.InDelta(t, float64(input[i])/10, scaled[i], 0.01, "ScaleInputs did not scale inputs correctly")
 	}
 }
+
+func TestRealFunctions_Optimal(t *testing.T) {
+	assert.InDelta(t, 0.0, RastriginReal(make([]float64, RastriginN)), 1e-9, "Unexpected result from Rastrigin Function")
+	assert.InDelta(t, 0.0, GriewangkReal(make([]float64, GriewangkN)), 1e-9, "Unexpected result from Griewangk Function")
+	assert.InDelta(t, 0.0, AckleyReal(make([]float64, AckleyN)), 1e-9, "Unexpected result from Ackley Function")
+	schwefel := make([]float64, SchwefelN)
+	rosenbrock := make([]float64, RosenbrockN)
+	for i := range schwefel {
+		schwefel[i] = 420.9687
+	}
+	for i := range rosenbrock {
+		rosenbrock[i] = 1
+	}
+	assert.InDelta(t, 0.0, SchwefelReal(schwefel), 0.01, "Unexpected result from Schwefel Function")
+	assert.InDelta(t, 0.0, RosenbrockReal(rosenbrock), 1e-9, "Unexpected result from Rosenbrock Function")
+}
+
+func TestRealFunctions_MatchEncoded(t *testing.T) {
+	input := []uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	assert.Equal(t, Rastrigin(input), RastriginReal(ScaleInputs(input, RastriginMin, RastriginMax)), "Real function should match encoded function")
+}
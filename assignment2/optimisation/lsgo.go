@@ -0,0 +1,516 @@
+package optimisation
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	LSGOD = 1000 // Dimensions of the CEC 2010 and 2013 LSGO functions
+	LSGOM = 50   // Size of each nonseparable group of the CEC 2010 LSGO functions
+)
+
+// LSGO is a function of the CEC 2010 or CEC 2013 large-scale global optimisation (LSGO) benchmark suites. Its variables
+// are shifted by Optimum and permuted by Permutation, then split into nonseparable groups of Sizes consecutive permuted
+// variables. Each group is optionally rotated and evaluated by the Group function, weighted by Weights, and any
+// variables beyond the last group are evaluated together by the separable Rest function.
+//
+// GetLSGO generates the shift, permutation, rotations and weights deterministically from the function's number, so
+// every experiment faces the same function. Load replaces them with the suite's data files, giving the exact
+// instances used in the literature.
+type LSGO struct {
+	Label  string
+	Suite  int // Year of the suite, 2010 or 2013
+	Number int // Number of the function in its suite, naming its data files
+	D      int
+	Min    float64
+	Max    float64
+
+	Group       RealFitness                 // Base function of each nonseparable group, with its optimum at the origin
+	Rest        RealFitness                 // Base function of the variables in no group, nil if every variable is grouped
+	Inner       func(z []float64) []float64 // Applied to each group and the rest before their base function, nil for none
+	Sizes       []int                       // Size of each nonseparable group
+	Rotate      bool                        // Whether each group is rotated
+	Overlap     int                         // Variables each group shares with the previous group
+	Conflicting bool                        // Whether each group is shifted separately, so shared variables have conflicting optima
+
+	Optimum     []float64           // Shift of the variables, or of each group's variables in turn if Conflicting
+	Permutation []int               // Order the variables are taken into groups
+	Rotations   map[int][][]float64 // Rotation matrix for each group size
+	Weights     []float64           // Weight of each group
+
+	randomWeights bool // Whether generated weights vary between groups, as in the CEC 2013 suite
+}
+
+// GetLSGO gets a function of the CEC 2010 (cec2010f1 to cec2010f20) or CEC 2013 (cec2013f1 to cec2013f15) LSGO
+// suites, with its shift, permutation, rotations and weights generated from its number
+func GetLSGO(name string) (*LSGO, error) {
+	var suite, number int
+	if _, err := fmt.Sscanf(name, "cec%df%d", &suite, &number); err != nil || name != fmt.Sprintf("cec%df%d", suite, number) {
+		return nil, errors.New("invalid function passed to GetLSGO")
+	}
+
+	var l *LSGO
+	switch suite {
+	case 2010:
+		l = lsgo2010(number)
+	case 2013:
+		l = lsgo2013(number)
+	}
+	if l == nil {
+		return nil, errors.New("invalid function passed to GetLSGO")
+	}
+	l.Suite, l.Number = suite, number
+	l.Label = fmt.Sprintf("CEC %d F%d %s", suite, number, l.Label)
+	l.Generate(int64(suite*100 + number))
+	return l, nil
+}
+
+// lsgo2010 defines function number of the CEC 2010 suite, nil if there is no such function
+func lsgo2010(number int) *LSGO {
+	l := &LSGO{D: LSGOD, Min: -100, Max: 100}
+	bases := []struct {
+		name   string
+		group  RealFitness
+		rest   RealFitness
+		rotate bool
+		min    float64
+		max    float64
+	}{
+		{"Elliptic", elliptic, elliptic, true, -100, 100},
+		{"Rastrigin", rastrigin10, rastrigin10, true, -5, 5},
+		{"Ackley", AckleyReal, AckleyReal, true, -32, 32},
+		{"Schwefel 1.2", schwefel12, SphereReal, false, -100, 100},
+		{"Rosenbrock", rosenbrock, SphereReal, false, -100, 100},
+	}
+
+	switch {
+	case number >= 1 && number <= 3:
+		base := bases[number-1]
+		l.Label = "Shifted " + base.name
+		l.Rest, l.Min, l.Max = base.rest, base.min, base.max
+	case number >= 4 && number <= 18:
+		base := bases[(number-4)%5]
+		l.Group, l.Rotate, l.Min, l.Max = base.group, base.rotate, base.min, base.max
+		groups := []int{1, LSGOD / (2 * LSGOM), LSGOD / LSGOM}[(number-4)/5]
+		l.Sizes = make([]int, groups)
+		for k := range l.Sizes {
+			l.Sizes[k] = LSGOM
+		}
+		if groups == 1 {
+			// The single group dominates the separable rest
+			l.Weights = []float64{1e6}
+		}
+		if groups != LSGOD/LSGOM {
+			l.Rest = base.rest
+		}
+		structure := map[int]string{1: "Single-group", LSGOD / (2 * LSGOM): "D/2m-group", LSGOD / LSGOM: "D/m-group"}[groups]
+		rotated := ""
+		if base.rotate {
+			rotated = " m-rotated"
+		}
+		l.Label = structure + " Shifted" + rotated + " " + base.name
+	case number == 19:
+		l.Label = "Shifted Schwefel 1.2"
+		l.Group, l.Sizes = schwefel12, []int{LSGOD}
+	case number == 20:
+		l.Label = "Shifted Rosenbrock"
+		l.Group, l.Sizes = rosenbrock, []int{LSGOD}
+	default:
+		return nil
+	}
+	return l
+}
+
+// Group sizes of the CEC 2013 partially separable functions
+var (
+	lsgo2013Sizes   = []int{50, 25, 25, 100, 50, 25, 25}
+	lsgo2013AllSize = []int{50, 50, 25, 25, 100, 100, 25, 25, 50, 25, 100, 25, 100, 50, 25, 25, 25, 100, 50, 25}
+)
+
+// lsgo2013 defines function number of the CEC 2013 suite, nil if there is no such function
+func lsgo2013(number int) *LSGO {
+	l := &LSGO{D: LSGOD, Min: -100, Max: 100}
+	conditioned := func(z []float64) []float64 {
+		return illConditioned(10, asymmetric(0.2, oscillated(z)))
+	}
+	asymmetricOscillated := func(z []float64) []float64 {
+		return asymmetric(0.2, oscillated(z))
+	}
+
+	switch number {
+	case 1:
+		l.Label = "Shifted Elliptic"
+		l.Rest, l.Inner = elliptic, oscillated
+	case 2:
+		l.Label = "Shifted Rastrigin"
+		l.Rest, l.Inner, l.Min, l.Max = rastrigin10, conditioned, -5, 5
+	case 3:
+		l.Label = "Shifted Ackley"
+		l.Rest, l.Inner, l.Min, l.Max = AckleyReal, conditioned, -32, 32
+	case 4:
+		l.Label = "7-nonseparable, 1-separable Shifted and Rotated Elliptic"
+		l.Group, l.Rest, l.Inner = elliptic, elliptic, oscillated
+	case 5:
+		l.Label = "7-nonseparable, 1-separable Shifted and Rotated Rastrigin"
+		l.Group, l.Rest, l.Inner, l.Min, l.Max = rastrigin10, rastrigin10, conditioned, -5, 5
+	case 6:
+		l.Label = "7-nonseparable, 1-separable Shifted and Rotated Ackley"
+		l.Group, l.Rest, l.Inner, l.Min, l.Max = AckleyReal, AckleyReal, conditioned, -32, 32
+	case 7:
+		l.Label = "7-nonseparable, 1-separable Shifted Schwefel 1.2"
+		l.Group, l.Rest, l.Inner = schwefel12, SphereReal, asymmetricOscillated
+	case 8:
+		l.Label = "20-nonseparable Shifted and Rotated Elliptic"
+		l.Group, l.Inner = elliptic, oscillated
+	case 9:
+		l.Label = "20-nonseparable Shifted and Rotated Rastrigin"
+		l.Group, l.Inner, l.Min, l.Max = rastrigin10, conditioned, -5, 5
+	case 10:
+		l.Label = "20-nonseparable Shifted and Rotated Ackley"
+		l.Group, l.Inner, l.Min, l.Max = AckleyReal, conditioned, -32, 32
+	case 11:
+		l.Label = "20-nonseparable Shifted Schwefel 1.2"
+		l.Group, l.Inner = schwefel12, asymmetricOscillated
+	case 12:
+		l.Label = "Shifted Rosenbrock"
+		l.Group, l.Sizes = rosenbrock, []int{LSGOD}
+		return l
+	case 13, 14:
+		l.Label = "Shifted Schwefel 1.2 with Conforming Overlapping Subcomponents"
+		if number == 14 {
+			l.Label = "Shifted Schwefel 1.2 with Conflicting Overlapping Subcomponents"
+			l.Conflicting = true
+		}
+		l.Group, l.Inner = schwefel12, asymmetricOscillated
+		l.Sizes, l.Overlap, l.Rotate, l.randomWeights = lsgo2013AllSize, 5, true, true
+		l.D = LSGOD - (len(l.Sizes)-1)*l.Overlap
+		return l
+	case 15:
+		l.Label = "Shifted Schwefel 1.2"
+		l.Group, l.Inner, l.Sizes = schwefel12, asymmetricOscillated, []int{LSGOD}
+		return l
+	default:
+		return nil
+	}
+
+	switch {
+	case number >= 4 && number <= 7:
+		l.Sizes = lsgo2013Sizes
+	case number >= 8:
+		l.Sizes = lsgo2013AllSize
+	}
+	if len(l.Sizes) > 0 {
+		l.Rotate, l.randomWeights = true, true
+	}
+	return l
+}
+
+// Generate generates the function's shift, permutation, rotations and weights from the seed. The optimum is placed
+// uniformly within the central 80% of the bounds, and the variables are only permuted if the function is partially
+// separable.
+func (l *LSGO) Generate(seed int64) {
+	r := rand.New(rand.NewSource(seed))
+	width := l.Max - l.Min
+
+	l.Optimum = make([]float64, l.shiftLen())
+	for i := range l.Optimum {
+		l.Optimum[i] = l.Min + 0.1*width + r.Float64()*0.8*width
+	}
+
+	l.Permutation = make([]int, l.D)
+	for i := range l.Permutation {
+		l.Permutation[i] = i
+	}
+	if len(l.Sizes) > 0 && l.Sizes[0] != l.D {
+		l.Permutation = r.Perm(l.D)
+	}
+
+	if l.Rotate {
+		l.Rotations = make(map[int][][]float64)
+		for _, size := range l.Sizes {
+			if _, ok := l.Rotations[size]; !ok {
+				l.Rotations[size] = RandomOrthogonal(size, r)
+			}
+		}
+	}
+
+	if l.randomWeights {
+		// Spread the groups' contributions over orders of magnitude
+		l.Weights = make([]float64, len(l.Sizes))
+		for k := range l.Weights {
+			l.Weights[k] = math.Pow(10, 3*r.NormFloat64())
+		}
+	}
+}
+
+// Load loads the function's data files from dir, as distributed with the CEC 2013 suite: F<n>-xopt.txt for the shift,
+// F<n>-p.txt for the (1-based) permutation, F<n>-R<size>.txt for the rotation of each group size, F<n>-s.txt for the
+// group sizes and F<n>-w.txt for the group weights. Files the function does not use, or that are missing, keep their
+// generated values.
+func (l *LSGO) Load(dir string) error {
+	read := func(name string) ([]float64, bool, error) {
+		path := filepath.Join(dir, fmt.Sprintf("F%d-%s.txt", l.Number, name))
+		b, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+
+		var values []float64
+		for _, field := range strings.FieldsFunc(string(b), func(c rune) bool { return c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r' }) {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, false, errors.New("invalid value " + field + " in " + path)
+			}
+			values = append(values, value)
+		}
+		return values, true, nil
+	}
+
+	if sizes, ok, err := read("s"); err != nil {
+		return err
+	} else if ok && len(l.Sizes) > 0 {
+		l.Sizes = make([]int, len(sizes))
+		for k := range sizes {
+			l.Sizes[k] = int(sizes[k])
+		}
+	}
+
+	if weights, ok, err := read("w"); err != nil {
+		return err
+	} else if ok && len(l.Sizes) > 0 {
+		if len(weights) < len(l.Sizes) {
+			return errors.New("too few weights in data files of " + l.Label)
+		}
+		l.Weights = weights[:len(l.Sizes)]
+	}
+
+	if optimum, ok, err := read("xopt"); err != nil {
+		return err
+	} else if ok {
+		if len(optimum) < l.shiftLen() {
+			return errors.New("too few values of the optimum in data files of " + l.Label)
+		}
+		l.Optimum = optimum[:l.shiftLen()]
+	}
+
+	if permutation, ok, err := read("p"); err != nil {
+		return err
+	} else if ok {
+		if len(permutation) != l.D {
+			return errors.New("permutation of the wrong length in data files of " + l.Label)
+		}
+		used := make([]bool, l.D)
+		for i, p := range permutation {
+			index := int(p) - 1
+			if index < 0 || index >= l.D || used[index] {
+				return errors.New("invalid permutation in data files of " + l.Label)
+			}
+			used[index] = true
+			l.Permutation[i] = index
+		}
+	}
+
+	if l.Rotate {
+		for _, size := range l.Sizes {
+			values, ok, err := read(fmt.Sprintf("R%d", size))
+			if err != nil {
+				return err
+			} else if !ok {
+				if _, generated := l.Rotations[size]; !generated {
+					return fmt.Errorf("missing rotation of size %d in data files of %s", size, l.Label)
+				}
+				continue
+			}
+			if len(values) != size*size {
+				return fmt.Errorf("rotation of size %d has the wrong number of values in data files of %s", size, l.Label)
+			}
+			matrix := make([][]float64, size)
+			for i := range matrix {
+				matrix[i] = values[i*size : (i+1)*size]
+			}
+			l.Rotations[size] = matrix
+		}
+	}
+	return nil
+}
+
+// shiftLen gets the number of values in the function's shift, one for each variable of each group if Conflicting
+func (l *LSGO) shiftLen() int {
+	if !l.Conflicting {
+		return l.D
+	}
+	var length int
+	for _, size := range l.Sizes {
+		length += size
+	}
+	return length
+}
+
+// Evaluate evaluates the function at x
+func (l *LSGO) Evaluate(x []float64) float64 {
+	inner := func(z []float64) []float64 {
+		if l.Inner == nil {
+			return z
+		}
+		return l.Inner(z)
+	}
+
+	var sum float64
+	var start, end, shift int
+	for k, size := range l.Sizes {
+		group := make([]float64, size)
+		for i := range group {
+			p := l.Permutation[start+i]
+			if l.Conflicting {
+				group[i] = x[p] - l.Optimum[shift+i]
+			} else {
+				group[i] = x[p] - l.Optimum[p]
+			}
+		}
+		if l.Rotate {
+			group = rotate(l.Rotations[size], group)
+		}
+
+		weight := 1.0
+		if l.Weights != nil {
+			weight = l.Weights[k]
+		}
+		sum += weight * l.Group(inner(group))
+		shift += size
+		end = start + size
+		start = end - l.Overlap
+	}
+
+	if l.Rest != nil && end < l.D {
+		rest := make([]float64, l.D-end)
+		for i := range rest {
+			p := l.Permutation[end+i]
+			rest[i] = x[p] - l.Optimum[p]
+		}
+		sum += l.Rest(inner(rest))
+	}
+	return sum
+}
+
+// Params gets the parameters for using the algorithms on the function
+func (l *LSGO) Params() Params {
+	params := Params{
+		Function:     Encoded(l.Evaluate, l.Min, l.Max),
+		RealFunction: l.Evaluate,
+		Label:        l.Label,
+		N:            l.D,
+		MutationP:    float32(1) / (float32(16) * float32(l.D)),
+		ScaleMin:     l.Min,
+		ScaleMax:     l.Max,
+	}
+	// Overlapping groups with conflicting shifts have no known optimum
+	if !l.Conflicting {
+		params.Optima = [][]float64{append([]float64(nil), l.Optimum...)}
+	}
+	return params
+}
+
+// rotate multiplies z by the matrix
+func rotate(matrix [][]float64, z []float64) []float64 {
+	rotated := make([]float64, len(z))
+	for i := range rotated {
+		for j := range z {
+			rotated[i] += matrix[i][j] * z[j]
+		}
+	}
+	return rotated
+}
+
+// elliptic is the high conditioned elliptic function, each variable's weight growing from 1 to 10^6
+func elliptic(z []float64) float64 {
+	var sum float64
+	for i := range z {
+		weight := 1.0
+		if len(z) > 1 {
+			weight = math.Pow(1e6, float64(i)/float64(len(z)-1))
+		}
+		sum += weight * z[i] * z[i]
+	}
+	return sum
+}
+
+// rastrigin10 is the Rastrigin function as defined by the CEC suites, with A = 10
+func rastrigin10(z []float64) float64 {
+	var sum float64
+	for i := range z {
+		sum += z[i]*z[i] - 10*math.Cos(2*math.Pi*z[i]) + 10
+	}
+	return sum
+}
+
+// schwefel12 is Schwefel's problem 1.2, the sum of the squares of each prefix sum of the variables
+func schwefel12(z []float64) float64 {
+	var sum, prefix float64
+	for i := range z {
+		prefix += z[i]
+		sum += prefix * prefix
+	}
+	return sum
+}
+
+// rosenbrock is the Rosenbrock function as defined by the CEC suites, moved so its optimum is at the origin
+func rosenbrock(z []float64) float64 {
+	var sum float64
+	for i := 0; i < len(z)-1; i++ {
+		a, b := z[i]+1, z[i+1]+1
+		sum += 100*math.Pow(a*a-b, 2) + math.Pow(a-1, 2)
+	}
+	return sum
+}
+
+// oscillated applies the CEC 2013 suite's Tosz transform, creating smooth local irregularities
+func oscillated(z []float64) []float64 {
+	result := make([]float64, len(z))
+	for i, x := range z {
+		if x == 0 {
+			continue
+		}
+		c1, c2 := 5.5, 3.1
+		if x > 0 {
+			c1, c2 = 10, 7.9
+		}
+		xHat := math.Log(math.Abs(x))
+		result[i] = math.Copysign(math.Exp(xHat+0.049*(math.Sin(c1*xHat)+math.Sin(c2*xHat))), x)
+	}
+	return result
+}
+
+// asymmetric applies the CEC 2013 suite's Tasy transform with the given beta, breaking the symmetry of the function
+func asymmetric(beta float64, z []float64) []float64 {
+	result := make([]float64, len(z))
+	for i, x := range z {
+		result[i] = x
+		if x > 0 && len(z) > 1 {
+			result[i] = math.Pow(x, 1+beta*float64(i)/float64(len(z)-1)*math.Sqrt(x))
+		}
+	}
+	return result
+}
+
+// illConditioned applies the CEC 2013 suite's Λ transform with the given alpha, scaling the variables by factors
+// growing from 1 to sqrt(alpha)
+func illConditioned(alpha float64, z []float64) []float64 {
+	result := make([]float64, len(z))
+	for i, x := range z {
+		result[i] = x
+		if len(z) > 1 {
+			result[i] = math.Pow(alpha, float64(i)/float64(len(z)-1)/2) * x
+		}
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package optimisation
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetLSGO(t *testing.T) {
+	for suite, functions := range map[int]int{2010: 20, 2013: 15} {
+		for number := 1; number <= functions; number++ {
+			name := fmt.Sprintf("cec%df%d", suite, number)
+			l, err := GetLSGO(name)
+			assert.Nil(t, err, name+" should exist")
+			assert.True(t, strings.HasPrefix(l.Label, fmt.Sprintf("CEC %d F%d ", suite, number)), "Label should name the function")
+
+			params, _ := GetParams(name)
+			assert.Equal(t, l.D, params.N, "Params should have every variable")
+			if number == 14 && suite == 2013 {
+				assert.Nil(t, params.Optima, "Conflicting overlapping groups have no known optimum")
+				continue
+			}
+			assert.InDelta(t, 0, params.RealFunction(params.Optima[0]), 1e-8, name+" should be optimal at its shift")
+			assert.Greater(t, params.RealFunction(make([]float64, l.D)), 1.0, name+" should not be optimal at the origin")
+		}
+	}
+
+	for _, name := range []string{"cec2010f0", "cec2010f21", "cec2013f16", "cec2011f1", "cec2010f1x"} {
+		_, err := GetLSGO(name)
+		assert.NotNil(t, err, name+" should not exist")
+	}
+}
+
+func TestGetLSGO_Deterministic(t *testing.T) {
+	a, _ := GetLSGO("cec2010f9")
+	b, _ := GetLSGO("cec2010f9")
+	assert.Equal(t, a.Optimum, b.Optimum, "Shift should be generated deterministically")
+	assert.Equal(t, a.Permutation, b.Permutation, "Permutation should be generated deterministically")
+	assert.Equal(t, a.Rotations, b.Rotations, "Rotations should be generated deterministically")
+}
+
+func TestLSGO_Evaluate_Groups(t *testing.T) {
+	l, _ := GetLSGO("cec2010f4")
+	assert.Equal(t, []int{LSGOM}, l.Sizes, "Single-group function should have one group of m variables")
+	assert.Equal(t, []float64{1e6}, l.Weights, "Single group should dominate the rest")
+
+	// Moving a variable of the group from the optimum costs far more than moving a separable variable
+	x := append([]float64(nil), l.Optimum...)
+	x[l.Permutation[0]]++
+	grouped := l.Evaluate(x)
+	x = append([]float64(nil), l.Optimum...)
+	x[l.Permutation[LSGOM]]++
+	separable := l.Evaluate(x)
+	assert.Greater(t, grouped, separable, "Group should be weighted more than the rest")
+
+	l, _ = GetLSGO("cec2013f13")
+	assert.Equal(t, 905, l.D, "Overlapping groups should share variables")
+	assert.Equal(t, 905, len(l.Optimum), "Conforming groups should share a shift")
+	l, _ = GetLSGO("cec2013f14")
+	assert.Equal(t, 1000, len(l.Optimum), "Conflicting groups should be shifted separately")
+}
+
+func TestLSGO_Load(t *testing.T) {
+	l, _ := GetLSGO("cec2010f4")
+	dir := t.TempDir()
+
+	var optimum, permutation, rotation []string
+	for i := 0; i < l.D; i++ {
+		optimum = append(optimum, "1.5")
+		permutation = append(permutation, fmt.Sprint(l.D-i))
+	}
+	for i := 0; i < LSGOM; i++ {
+		for j := 0; j < LSGOM; j++ {
+			if i == j {
+				rotation = append(rotation, "1")
+			} else {
+				rotation = append(rotation, "0")
+			}
+		}
+	}
+	write := func(name string, values []string) {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "F4-"+name+".txt"), []byte(strings.Join(values, " ")), 0644))
+	}
+	write("xopt", optimum)
+	write("p", permutation)
+	write("R50", rotation)
+
+	assert.Nil(t, l.Load(dir), "Data files should load")
+	assert.Equal(t, 1.5, l.Optimum[0], "Shift should be loaded")
+	assert.Equal(t, l.D-1, l.Permutation[0], "Permutation should be loaded and made 0-based")
+	assert.Equal(t, 1.0, l.Rotations[LSGOM][0][0], "Rotation should be loaded")
+	assert.Equal(t, 0.0, l.Evaluate(l.Optimum), "Loaded shift should be optimal")
+
+	write("p", permutation[1:])
+	assert.NotNil(t, l.Load(dir), "Permutation of the wrong length should not load")
+}
+
+func TestLSGO_BaseFunctions(t *testing.T) {
+	assert.Equal(t, 1e6+1, elliptic([]float64{1, 1}), "Elliptic weights should grow to 10^6")
+	assert.Equal(t, 1.0+9+36, schwefel12([]float64{1, 2, 3}), "Schwefel 1.2 should sum squared prefix sums")
+	assert.Equal(t, 0.0, rosenbrock([]float64{0, 0, 0}), "Rosenbrock should be optimal at the origin")
+	assert.Equal(t, 0.0, rastrigin10([]float64{0, 0}), "Rastrigin should be optimal at the origin")
+	assert.Equal(t, []float64{0, 0}, illConditioned(10, asymmetric(0.2, oscillated([]float64{0, 0}))), "Transforms should keep the origin")
+	assert.InDelta(t, 1, oscillated([]float64{1})[0], 1e-12, "Tosz should keep 1")
+	assert.Equal(t, []float64{-2, math.Pow(4, 1.4)}, asymmetric(0.2, []float64{-2, 4}), "Tasy should only raise positive values")
+}
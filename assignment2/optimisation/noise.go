@@ -0,0 +1,250 @@
+package optimisation
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// NoiseModel sets how noise changes each evaluation of a noisy function
+type NoiseModel int
+
+const (
+	AdditiveNoise       NoiseModel = iota // f(x) + sigma N(0, 1)
+	MultiplicativeNoise                   // f(x) (1 + sigma N(0, 1)), so the noise shrinks along with the fitness
+	OutlierNoise                          // f(x), but with probability OutlierP an outlier f(x) + sigma Cauchy(0, 1)
+)
+
+// OutlierP is the probability of an evaluation with OutlierNoise being an outlier
+const OutlierP = 0.05
+
+// GetNoiseModel gets a NoiseModel by name
+func GetNoiseModel(name string) (NoiseModel, error) {
+	switch name {
+	case "additive":
+		return AdditiveNoise, nil
+	case "multiplicative":
+		return MultiplicativeNoise, nil
+	case "outlier":
+		return OutlierNoise, nil
+	}
+
+	return AdditiveNoise, errors.New("invalid noise model passed to GetNoiseModel")
+}
+
+func (m NoiseModel) String() string {
+	switch m {
+	case MultiplicativeNoise:
+		return "multiplicative"
+	case OutlierNoise:
+		return "outlier"
+	}
+	return "additive"
+}
+
+// Noise changes each evaluation of the function by noise of the Model, with standard deviation (or for outliers,
+// scale) Sigma. Noise is not safe for concurrent use, so each run of an algorithm samples its own (see Params.Reseeded).
+type Noise struct {
+	Model NoiseModel
+	Sigma float64
+
+	r *rand.Rand
+}
+
+// NewNoise creates seeded Noise of the model with standard deviation or scale sigma
+func NewNoise(model NoiseModel, sigma float64, seed int64) *Noise {
+	return &Noise{Model: model, Sigma: sigma, r: rand.New(rand.NewSource(seed))}
+}
+
+// Sample gets a noisy observation of the fitness
+func (t *Noise) Sample(fitness float64) float64 {
+	switch t.Model {
+	case MultiplicativeNoise:
+		return fitness * (1 + t.Sigma*t.r.NormFloat64())
+	case OutlierNoise:
+		if t.r.Float64() < OutlierP {
+			// The ratio of two standard normal samples is Cauchy distributed
+			return fitness + t.Sigma*t.r.NormFloat64()/math.Abs(t.r.NormFloat64())
+		}
+		return fitness
+	}
+	return fitness + t.Sigma*t.r.NormFloat64()
+}
+
+// Wrap creates the noisy real-valued function
+func (t *Noise) Wrap(function RealFitness) RealFitness {
+	return func(x []float64) float64 {
+		return t.Sample(function(x))
+	}
+}
+
+// Fitness creates the noisy function of genes
+func (t *Noise) Fitness(function Fitness) Fitness {
+	return func(genes []uint16) float64 {
+		return t.Sample(function(genes))
+	}
+}
+
+// Optimum leaves the optimum x in place, noise does not move it
+func (t *Noise) Optimum(x []float64) []float64 {
+	return x
+}
+
+func (t *Noise) String() string {
+	if t.Model == AdditiveNoise {
+		return fmt.Sprintf("noise %g", t.Sigma)
+	}
+	return fmt.Sprintf("%s noise %g", t.Model, t.Sigma)
+}
+
+// Noisy gets the parameters of the function with noise added to each evaluation, keeping the function without noise as
+// NoiseFree to report the true fitness of solutions. Unlike transforms, noise can be added to Problems.
+func (p Params) Noisy(noise *Noise) Params {
+	if p.NoiseFree == nil {
+		p.NoiseFree, p.noiseFreeReal = p.Function, p.RealFunction
+	}
+	p.Noise = noise
+	p.Function = noise.Fitness(p.Function)
+	if p.RealFunction != nil {
+		p.RealFunction = noise.Wrap(p.RealFunction)
+	}
+	// Plot limits of the function no longer suit its noisy fitness
+	p.PlotMin, p.PlotMax = 0, 0
+	p.Label += " (" + noise.String() + ")"
+	return p
+}
+
+// Reseeded gets the parameters of a noisy function for one run of an algorithm, its Function and RealFunction sampling
+// new Noise of the same model seeded with seed. Concurrent runs then neither share a random source nor wait on each
+// other, and each run's noise is reproducible. Noise-free functions are returned unchanged.
+func (p Params) Reseeded(seed int64) Params {
+	if p.Noise == nil {
+		return p
+	}
+
+	noise := NewNoise(p.Noise.Model, p.Noise.Sigma, seed)
+	p.Noise = noise
+	p.Function = noise.Fitness(p.NoiseFree)
+	if p.noiseFreeReal != nil {
+		p.RealFunction = noise.Wrap(p.noiseFreeReal)
+	}
+	return p
+}
+
+// NoiseStrategy configures how evaluations of a noisy function estimate its true fitness
+type NoiseStrategy struct {
+	Samples int // Samples of the noisy function averaged by each evaluation, explicit resampling when more than 1
+	Window  int // Latest evaluations of the same solution averaged, a rolling average when more than 1
+}
+
+// Averaged gets the parameters of a noisy function for one run of an algorithm, each evaluation of its Function and
+// RealFunction averaging strategy.Samples samples, then the latest strategy.Window evaluations of the same solution. As
+// the rolling averages are kept over a run, each run needs its own. Noise-free functions are returned unchanged.
+func (p Params) Averaged(strategy NoiseStrategy) Params {
+	if p.NoiseFree == nil || (strategy.Samples <= 1 && strategy.Window <= 1) {
+		return p
+	}
+
+	function, realFunction := p.Function, p.RealFunction
+	genesAverage, realAverage := newRollingAverage(strategy.Window), newRollingAverage(strategy.Window)
+	p.Function = func(genes []uint16) float64 {
+		sample := resample(strategy.Samples, func() float64 { return function(genes) })
+		return genesAverage.add(genesKey(genes), sample)
+	}
+	if realFunction != nil {
+		p.RealFunction = func(x []float64) float64 {
+			sample := resample(strategy.Samples, func() float64 { return realFunction(x) })
+			return realAverage.add(realKey(x), sample)
+		}
+	}
+	return p
+}
+
+// resample gets the mean of the given number of samples, or a single sample
+func resample(samples int, sample func() float64) float64 {
+	if samples <= 1 {
+		return sample()
+	}
+	var total float64
+	for i := 0; i < samples; i++ {
+		total += sample()
+	}
+	return total / float64(samples)
+}
+
+// RollingSolutions is the most solutions a rolling average keeps the evaluations of, the least recently evaluated
+// being forgotten first
+const RollingSolutions = 1 << 16
+
+// rollingAverage keeps the latest evaluations of the RollingSolutions most recently evaluated solutions over a run,
+// guarded as algorithms such as the island GA evaluate concurrently
+type rollingAverage struct {
+	window  int
+	mutex   sync.Mutex
+	samples map[string]*list.Element // Element of each solution in recent
+	recent  *list.List               // Solutions' evaluations, most recently evaluated first
+}
+
+// rollingSamples are the latest evaluations of the solution with the key
+type rollingSamples struct {
+	key     string
+	samples []float64
+}
+
+// newRollingAverage creates a rolling average of the latest window evaluations, nil if there is nothing to average
+func newRollingAverage(window int) *rollingAverage {
+	if window <= 1 {
+		return nil
+	}
+	return &rollingAverage{window: window, samples: make(map[string]*list.Element), recent: list.New()}
+}
+
+// add records an evaluation of the solution with the key, getting the mean of its latest evaluations
+func (a *rollingAverage) add(key string, sample float64) float64 {
+	if a == nil {
+		return sample
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	element, ok := a.samples[key]
+	if ok {
+		a.recent.MoveToFront(element)
+	} else {
+		if a.recent.Len() == RollingSolutions {
+			oldest := a.recent.Remove(a.recent.Back()).(*rollingSamples)
+			delete(a.samples, oldest.key)
+		}
+		element = a.recent.PushFront(&rollingSamples{key: key})
+		a.samples[key] = element
+	}
+
+	solution := element.Value.(*rollingSamples)
+	solution.samples = append(solution.samples, sample)
+	if len(solution.samples) > a.window {
+		solution.samples = append([]float64(nil), solution.samples[len(solution.samples)-a.window:]...)
+	}
+	return sum(solution.samples) / float64(len(solution.samples))
+}
+
+// genesKey gets a map key identifying the genes
+func genesKey(genes []uint16) string {
+	b := make([]byte, 2*len(genes))
+	for i, gene := range genes {
+		binary.BigEndian.PutUint16(b[2*i:], gene)
+	}
+	return string(b)
+}
+
+// realKey gets a map key identifying the real values
+func realKey(x []float64) string {
+	b := make([]byte, 8*len(x))
+	for i, v := range x {
+		binary.BigEndian.PutUint64(b[8*i:], math.Float64bits(v))
+	}
+	return string(b)
+}
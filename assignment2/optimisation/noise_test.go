@@ -0,0 +1,107 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNoiseModel(t *testing.T) {
+	model, err := GetNoiseModel("outlier")
+	assert.Nil(t, err, "GetNoiseModel should find outlier")
+	assert.Equal(t, OutlierNoise, model, "GetNoiseModel returned wrong model")
+
+	_, err = GetNoiseModel("invalid")
+	assert.NotNil(t, err, "GetNoiseModel should error for unknown model")
+}
+
+func TestNoise(t *testing.T) {
+	noisy := NewNoise(AdditiveNoise, 0.5, 4).Wrap(RastriginReal)
+	x := make([]float64, 3)
+	assert.NotEqual(t, noisy(x), noisy(x), "Each evaluation should have its own noise")
+	assert.Equal(t, NewNoise(AdditiveNoise, 0.5, 4).Wrap(RastriginReal)(x), NewNoise(AdditiveNoise, 0.5, 4).Wrap(RastriginReal)(x), "Noise should be deterministic for a seed")
+	assert.Equal(t, 0.0, NewNoise(AdditiveNoise, 0, 4).Wrap(RastriginReal)(x), "No noise should leave the function unchanged")
+	assert.Equal(t, "noise 0.5", NewNoise(AdditiveNoise, 0.5, 4).String())
+	assert.Equal(t, "outlier noise 0.5", NewNoise(OutlierNoise, 0.5, 4).String())
+}
+
+func TestNoise_Sample(t *testing.T) {
+	multiplicative := NewNoise(MultiplicativeNoise, 0.5, 4)
+	assert.Equal(t, 0.0, multiplicative.Sample(0), "Multiplicative noise should leave the optimal fitness 0 unchanged")
+	assert.NotEqual(t, 10.0, multiplicative.Sample(10), "Multiplicative noise should change other fitness")
+
+	outlier := NewNoise(OutlierNoise, 100, 4)
+	var outliers int
+	for i := 0; i < 10000; i++ {
+		if outlier.Sample(10) != 10 {
+			outliers++
+		}
+	}
+	assert.InDelta(t, OutlierP, float64(outliers)/10000, 0.01, "Outliers should occur with probability OutlierP")
+}
+
+func TestParams_Noisy(t *testing.T) {
+	params, _ := GetParams("rastrigin")
+	noisy := params.Noisy(NewNoise(AdditiveNoise, 1, 0))
+	genes := make([]uint16, RastriginN)
+	assert.NotEqual(t, Rastrigin(genes), noisy.Function(genes), "Function should be noisy")
+	assert.NotEqual(t, RastriginReal(params.Decode(genes)), noisy.RealFunction(params.Decode(genes)), "Real function should be noisy")
+	assert.Equal(t, Rastrigin(genes), noisy.NoiseFree(genes), "Function without noise should be kept")
+	assert.Equal(t, params.Label+" (noise 1)", noisy.Label, "Label should give the noise")
+
+	params, _ = GetParams("geartrain")
+	noisy = params.Noisy(NewNoise(MultiplicativeNoise, 1, 0))
+	genes = []uint16{0, 0, 0, 0}
+	assert.Equal(t, params.Function(genes), noisy.NoiseFree(genes), "Problems with per-variable bounds can be made noisy")
+}
+
+func TestParams_Reseeded(t *testing.T) {
+	params, _ := GetParams("rastrigin")
+	assert.Nil(t, params.Reseeded(1).Noise, "Functions without noise should be unchanged")
+
+	noisy := params.Noisy(NewNoise(MultiplicativeNoise, 1, 0))
+	genes, x := make([]uint16, RastriginN), make([]float64, RastriginN)
+	genes[0], x[0] = 1000, 1
+	run, again := noisy.Reseeded(3), noisy.Reseeded(3)
+	assert.Equal(t, MultiplicativeNoise, run.Noise.Model, "Run should keep the noise model")
+	assert.Equal(t, run.Function(genes), again.Function(genes), "Noise of runs with the same seed should be the same")
+	assert.Equal(t, run.RealFunction(x), again.RealFunction(x), "Real noise of runs with the same seed should be the same")
+	assert.NotEqual(t, run.Function(genes), noisy.Reseeded(4).Function(genes), "Runs with other seeds should have other noise")
+	assert.Equal(t, Rastrigin(genes), run.NoiseFree(genes), "Function without noise should be kept")
+}
+
+// counter gets params of a noisy function whose evaluations are 1, 2, 3, ... in turn
+func counter() Params {
+	var evaluations float64
+	function := func(genes []uint16) float64 {
+		evaluations++
+		return evaluations
+	}
+	return Params{Function: function, NoiseFree: function}
+}
+
+func TestParams_Averaged(t *testing.T) {
+	params := counter()
+	assert.Equal(t, 1.0, params.Averaged(NoiseStrategy{Samples: 1}).Function(nil), "One sample should not be averaged")
+
+	resampled := counter().Averaged(NoiseStrategy{Samples: 2})
+	assert.Equal(t, 1.5, resampled.Function(nil), "Samples should be averaged")
+	assert.Equal(t, 3.5, resampled.Function(nil), "Each evaluation should take its own samples")
+
+	rolling := counter().Averaged(NoiseStrategy{Samples: 1, Window: 2})
+	assert.Equal(t, 1.0, rolling.Function([]uint16{0}), "First evaluation of a solution should not be averaged")
+	assert.Equal(t, 1.5, rolling.Function([]uint16{0}), "Evaluations of a solution should be averaged")
+	assert.Equal(t, 3.0, rolling.Function([]uint16{1}), "Each solution should have its own average")
+	assert.Equal(t, 3.0, rolling.Function([]uint16{0}), "Only the latest evaluations of a solution should be averaged")
+
+	evicted := counter().Averaged(NoiseStrategy{Samples: 1, Window: 2})
+	evicted.Function([]uint16{0})
+	for i := 1; i <= RollingSolutions; i++ {
+		evicted.Function([]uint16{uint16(i), uint16(i >> 16)})
+	}
+	assert.Equal(t, float64(RollingSolutions+2), evicted.Function([]uint16{0}), "Least recently evaluated solution should be forgotten")
+
+	params, _ = GetParams("rastrigin")
+	genes := make([]uint16, RastriginN)
+	averaged := params.Averaged(NoiseStrategy{Samples: 2, Window: 2})
+	assert.Equal(t, params.Function(genes), averaged.Function(genes), "Functions without noise should be unchanged")
+}
@@ -0,0 +1,199 @@
+package optimisation
+
+import (
+	"errors"
+	"math"
+)
+
+// VariableType sets how a variable's gene is decoded
+type VariableType int
+
+const (
+	Continuous  VariableType = iota // Real value within the variable's bounds, optionally rounded to its precision
+	Integer                         // Integer within the variable's bounds, each equally likely from a random gene
+	Categorical                     // Index of one of the variable's categories, each equally likely from a random gene
+)
+
+// Variable defines one decision variable of a Problem
+type Variable struct {
+	Name       string
+	Type       VariableType
+	Min        float64  // Continuous and Integer: smallest value
+	Max        float64  // Continuous and Integer: largest value
+	Precision  float64  // Continuous: decoded values are rounded to multiples of Precision from Min, 0 keeps full precision
+	Categories []string // Categorical: the categories the variable picks between
+}
+
+// Problem is an optimisation problem whose variables have their own bounds, precision and type. Each variable's gene is
+// decoded accordingly before the Objective is called, categorical variables being decoded to their category's index.
+type Problem struct {
+	Label          string
+	Variables      []Variable
+	Objective      RealFitness // Objective of the decoded variables
+	Constraints    Constraints // Violation of each constraint by the decoded variables, if constrained
+	Direction      Direction   // Whether the objective is minimised (the default) or maximised
+	Optima         [][]float64 // Known global optima, if any
+	OptimumFitness float64     // Objective at the known global optima
+}
+
+// GetVariableType gets a VariableType by name
+func GetVariableType(name string) (VariableType, error) {
+	switch name {
+	case "continuous":
+		return Continuous, nil
+	case "integer":
+		return Integer, nil
+	case "categorical":
+		return Categorical, nil
+	}
+
+	return Continuous, errors.New("invalid variable type passed to GetVariableType")
+}
+
+// levels gets the number of distinct values of an Integer or Categorical variable
+func (v Variable) levels() int {
+	if v.Type == Categorical {
+		return len(v.Categories)
+	}
+	return int(math.Floor(v.Max)-math.Ceil(v.Min)) + 1
+}
+
+// DecodeUnit decodes the variable from u, its position within [0, 1]
+func (v Variable) DecodeUnit(u float64) float64 {
+	u = math.Max(0, math.Min(1, u))
+	switch v.Type {
+	case Integer, Categorical:
+		// Split [0, 1] into equally sized intervals, one for each value
+		level := math.Min(math.Floor(u*float64(v.levels())), float64(v.levels()-1))
+		if v.Type == Categorical {
+			return level
+		}
+		return math.Ceil(v.Min) + level
+	}
+
+	x := v.Min + u*(v.Max-v.Min)
+	if v.Precision > 0 {
+		x = math.Min(v.Max, v.Min+math.Round((x-v.Min)/v.Precision)*v.Precision)
+	}
+	return x
+}
+
+// EncodeUnit encodes the variable's value x as its position within [0, 1], integer and categorical values at the centre
+// of their interval
+func (v Variable) EncodeUnit(x float64) float64 {
+	switch v.Type {
+	case Integer, Categorical:
+		level := x
+		if v.Type == Integer {
+			level = x - math.Ceil(v.Min)
+		}
+		level = math.Max(0, math.Min(float64(v.levels()-1), math.Round(level)))
+		return (level + 0.5) / float64(v.levels())
+	}
+	return math.Max(0, math.Min(1, (x-v.Min)/(v.Max-v.Min)))
+}
+
+// Decode decodes the variable from its binary gene
+func (v Variable) Decode(gene uint16) float64 {
+	return v.DecodeUnit(float64(gene) / 65535)
+}
+
+// Encode encodes the variable's value as its nearest binary gene
+func (v Variable) Encode(x float64) uint16 {
+	return uint16(math.Round(v.EncodeUnit(x) * 65535))
+}
+
+// Category gets the name of the category a decoded Categorical variable picks
+func (v Variable) Category(x float64) string {
+	return v.Categories[int(x)]
+}
+
+// Decode decodes each variable from its gene
+func (p Problem) Decode(genes []uint16) []float64 {
+	x := make([]float64, len(p.Variables))
+	for i, v := range p.Variables {
+		x[i] = v.Decode(genes[i])
+	}
+	return x
+}
+
+// Encode encodes each variable's value as its nearest gene
+func (p Problem) Encode(x []float64) []uint16 {
+	genes := make([]uint16, len(p.Variables))
+	for i, v := range p.Variables {
+		genes[i] = v.Encode(x[i])
+	}
+	return genes
+}
+
+// DecodeUnit decodes each variable from its position within the unit hypercube
+func (p Problem) DecodeUnit(u []float64) []float64 {
+	x := make([]float64, len(p.Variables))
+	for i, v := range p.Variables {
+		x[i] = v.DecodeUnit(u[i])
+	}
+	return x
+}
+
+// Params gets the parameters for using the algorithms on the problem. Real-valued algorithms search the unit
+// hypercube, each position being decoded per variable.
+func (p Problem) Params() Params {
+	N := len(p.Variables)
+	return Params{
+		Function: func(genes []uint16) float64 {
+			return p.Objective(p.Decode(genes))
+		},
+		RealFunction: func(u []float64) float64 {
+			return p.Objective(p.DecodeUnit(u))
+		},
+		Label:          p.Label,
+		N:              N,
+		MutationP:      float32(1) / (float32(16) * float32(N)),
+		ScaleMin:       0,
+		ScaleMax:       1,
+		Variables:      p.Variables,
+		Optima:         p.Optima,
+		Constraints:    p.Constraints,
+		Direction:      p.Direction,
+		OptimumFitness: p.OptimumFitness,
+	}
+}
+
+// Decode decodes genes to the values of the function's variables, with the function's Encoding
+func (p Params) Decode(genes []uint16) []float64 {
+	genes = p.Encoding.Values(genes)
+	if len(p.Variables) > 0 {
+		return Problem{Variables: p.Variables}.Decode(genes)
+	}
+	return ScaleInputs(genes, p.ScaleMin, p.ScaleMax)
+}
+
+// DecodeReal decodes the real values searched by real-valued algorithms to the values of the function's variables
+func (p Params) DecodeReal(x []float64) []float64 {
+	if len(p.Variables) > 0 {
+		return Problem{Variables: p.Variables}.DecodeUnit(x)
+	}
+	return x
+}
+
+const (
+	GearTrainLabel = "Gear Train Design"
+	GearTrainRatio = 1 / 6.931 // Required gear ratio
+)
+
+// GearTrain is the gear train design problem of Sandgren (DOI 10.1115/1.2912596), choosing the number of teeth (12 to
+// 60) of four gears so the gear ratio is as close as possible to 1/6.931
+var GearTrain = Problem{
+	Label: GearTrainLabel,
+	Variables: []Variable{
+		{Name: "Ta", Type: Integer, Min: 12, Max: 60},
+		{Name: "Tb", Type: Integer, Min: 12, Max: 60},
+		{Name: "Td", Type: Integer, Min: 12, Max: 60},
+		{Name: "Tf", Type: Integer, Min: 12, Max: 60},
+	},
+	Objective: func(x []float64) float64 {
+		return math.Pow(GearTrainRatio-(x[0]*x[1])/(x[2]*x[3]), 2)
+	},
+	// Swapping the driving gears, or the driven gears, gives the same ratio
+	Optima: [][]float64{{19, 16, 43, 49}, {16, 19, 43, 49}, {19, 16, 49, 43}, {16, 19, 49, 43}},
+}
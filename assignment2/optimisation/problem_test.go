@@ -0,0 +1,71 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetVariableType(t *testing.T) {
+	variableType, err := GetVariableType("categorical")
+	assert.Nil(t, err, "GetVariableType should find categorical")
+	assert.Equal(t, Categorical, variableType, "GetVariableType returned wrong variable type")
+
+	_, err = GetVariableType("invalid")
+	assert.NotNil(t, err, "GetVariableType should error for unknown variable type")
+}
+
+func TestVariable_Decode(t *testing.T) {
+	continuous := Variable{Type: Continuous, Min: -1, Max: 3}
+	assert.Equal(t, -1.0, continuous.Decode(0), "Smallest gene should decode to the lower bound")
+	assert.Equal(t, 3.0, continuous.Decode(65535), "Largest gene should decode to the upper bound")
+
+	precise := Variable{Type: Continuous, Min: 0, Max: 1, Precision: 0.25}
+	assert.Equal(t, 0.5, precise.Decode(30000), "Values should be rounded to the precision")
+
+	integer := Variable{Type: Integer, Min: 2, Max: 5}
+	assert.Equal(t, 2.0, integer.Decode(0), "Smallest gene should decode to the smallest integer")
+	assert.Equal(t, 3.0, integer.Decode(20000), "Genes should decode to equally sized intervals of integers")
+	assert.Equal(t, 5.0, integer.Decode(65535), "Largest gene should decode to the largest integer")
+
+	categorical := Variable{Type: Categorical, Categories: []string{"steel", "aluminium", "titanium"}}
+	assert.Equal(t, 1.0, categorical.Decode(30000), "Genes should decode to a category index")
+	assert.Equal(t, "titanium", categorical.Category(categorical.Decode(65535)), "Largest gene should decode to the last category")
+}
+
+func TestVariable_Encode(t *testing.T) {
+	for _, v := range []Variable{
+		{Type: Integer, Min: -3, Max: 7},
+		{Type: Categorical, Categories: []string{"a", "b", "c", "d"}},
+	} {
+		for level := 0; level < v.levels(); level++ {
+			x := v.DecodeUnit((float64(level) + 0.5) / float64(v.levels()))
+			assert.Equal(t, x, v.Decode(v.Encode(x)), "Encoded values should decode to the same value")
+		}
+	}
+
+	continuous := Variable{Type: Continuous, Min: 0, Max: 10}
+	assert.InDelta(t, 4.2, continuous.Decode(continuous.Encode(4.2)), 1e-3, "Encoded values should decode to the nearest value")
+}
+
+func TestProblem_Params(t *testing.T) {
+	problem := Problem{
+		Variables: []Variable{
+			{Type: Continuous, Min: 0, Max: 100},
+			{Type: Categorical, Categories: []string{"x", "y"}},
+		},
+		Objective: func(x []float64) float64 { return x[0] + x[1] },
+	}
+	params := problem.Params()
+	assert.Equal(t, 2, params.N, "Problem should have a gene per variable")
+	assert.Equal(t, 101.0, params.Function([]uint16{65535, 65535}), "Function should decode each variable before the objective")
+	assert.Equal(t, 101.0, params.RealFunction([]float64{1, 1}), "Real function should decode each variable from the unit hypercube")
+	assert.Equal(t, []float64{0, 0}, params.Decode([]uint16{0, 0}), "Params should decode per variable")
+}
+
+func TestGearTrain(t *testing.T) {
+	params, err := GetParams("geartrain")
+	assert.Nil(t, err, "GetParams should find geartrain")
+	genes := GearTrain.Encode([]float64{19, 16, 43, 49})
+	assert.Equal(t, []float64{19, 16, 43, 49}, params.Decode(genes), "Teeth should be encoded exactly")
+	assert.InDelta(t, 2.7009e-12, params.Function(genes), 1e-15, "Best known gear train")
+}
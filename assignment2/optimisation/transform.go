@@ -0,0 +1,217 @@
+package optimisation
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// Transform changes the landscape of a real-valued function, such as moving its optimum away from a fixed known point
+// or rotating it so its variables are no longer separable. Transforms are composed with Params.Transform.
+type Transform interface {
+	Wrap(function RealFitness) RealFitness // Wrap creates the transformed function
+	Optimum(x []float64) []float64         // Optimum gets where the function's optimum x moves to once transformed
+	String() string
+}
+
+// Shift moves the function's landscape by Offset, so f'(x) = f(x - Offset)
+type Shift struct {
+	Offset []float64
+}
+
+// NewShift creates a seeded random Shift, moving the optimum to a point uniformly within the central 80% of the bounds
+func NewShift(optimum []float64, bounds Bounds, seed int64) Shift {
+	r := rand.New(rand.NewSource(seed))
+	offset := make([]float64, len(optimum))
+	for i := range offset {
+		target := bounds.Min + 0.1*bounds.Width() + r.Float64()*0.8*bounds.Width()
+		offset[i] = target - optimum[i]
+	}
+	return Shift{Offset: offset}
+}
+
+// Wrap creates the shifted function
+func (t Shift) Wrap(function RealFitness) RealFitness {
+	return func(x []float64) float64 {
+		shifted := make([]float64, len(x))
+		for i := range x {
+			shifted[i] = x[i] - t.Offset[i]
+		}
+		return function(shifted)
+	}
+}
+
+// Optimum moves the optimum x by the offset
+func (t Shift) Optimum(x []float64) []float64 {
+	moved := make([]float64, len(x))
+	for i := range x {
+		moved[i] = x[i] + t.Offset[i]
+	}
+	return moved
+}
+
+func (t Shift) String() string {
+	return "shifted"
+}
+
+// Rotation rotates the function's landscape about Centre by the orthogonal Matrix, so f'(x) = f(Centre + Matrix(x - Centre)).
+// Rotating about the optimum keeps it in place while making the variables non-separable.
+type Rotation struct {
+	Matrix [][]float64
+	Centre []float64
+}
+
+// NewRotation creates a seeded random Rotation about the centre
+func NewRotation(centre []float64, seed int64) Rotation {
+	return Rotation{Matrix: RandomOrthogonal(len(centre), rand.New(rand.NewSource(seed))), Centre: centre}
+}
+
+// RandomOrthogonal creates a random N x N orthogonal matrix, orthonormalising the rows of a matrix of normally
+// distributed values with the Gram-Schmidt process
+func RandomOrthogonal(N int, r *rand.Rand) [][]float64 {
+	matrix := make([][]float64, N)
+	for i := range matrix {
+		matrix[i] = make([]float64, N)
+		for {
+			for j := range matrix[i] {
+				matrix[i][j] = r.NormFloat64()
+			}
+			// Remove the components along each previous row, retrying in the unlikely case nothing is left
+			for k := 0; k < i; k++ {
+				var dot float64
+				for j := range matrix[i] {
+					dot += matrix[i][j] * matrix[k][j]
+				}
+				for j := range matrix[i] {
+					matrix[i][j] -= dot * matrix[k][j]
+				}
+			}
+			var norm float64
+			for j := range matrix[i] {
+				norm += matrix[i][j] * matrix[i][j]
+			}
+			norm = math.Sqrt(norm)
+			if norm > 1e-8 {
+				for j := range matrix[i] {
+					matrix[i][j] /= norm
+				}
+				break
+			}
+		}
+	}
+	return matrix
+}
+
+// Wrap creates the rotated function
+func (t Rotation) Wrap(function RealFitness) RealFitness {
+	return func(x []float64) float64 {
+		rotated := make([]float64, len(x))
+		for i := range rotated {
+			rotated[i] = t.Centre[i]
+			for j := range x {
+				rotated[i] += t.Matrix[i][j] * (x[j] - t.Centre[j])
+			}
+		}
+		return function(rotated)
+	}
+}
+
+// Optimum rotates the optimum x the opposite way about the centre
+func (t Rotation) Optimum(x []float64) []float64 {
+	// The inverse of an orthogonal matrix is its transpose
+	moved := make([]float64, len(x))
+	for i := range moved {
+		moved[i] = t.Centre[i]
+		for j := range x {
+			moved[i] += t.Matrix[j][i] * (x[j] - t.Centre[j])
+		}
+	}
+	return moved
+}
+
+func (t Rotation) String() string {
+	return "rotated"
+}
+
+// Scaling scales each variable about Centre by its factor, so f'(x) = f(Centre + Factors*(x - Centre))
+type Scaling struct {
+	Factors []float64
+	Centre  []float64
+}
+
+// NewConditioning creates a Scaling about the centre whose factors grow geometrically from 1 to sqrt(condition), so
+// a quadratic function such as the sphere has a Hessian with the given condition number
+func NewConditioning(condition float64, centre []float64) Scaling {
+	N := len(centre)
+	factors := make([]float64, N)
+	for i := range factors {
+		factors[i] = 1
+		if N > 1 {
+			factors[i] = math.Pow(condition, float64(i)/float64(N-1)/2)
+		}
+	}
+	return Scaling{Factors: factors, Centre: centre}
+}
+
+// Wrap creates the scaled function
+func (t Scaling) Wrap(function RealFitness) RealFitness {
+	return func(x []float64) float64 {
+		scaled := make([]float64, len(x))
+		for i := range x {
+			scaled[i] = t.Centre[i] + t.Factors[i]*(x[i]-t.Centre[i])
+		}
+		return function(scaled)
+	}
+}
+
+// Optimum scales the optimum x inversely about the centre
+func (t Scaling) Optimum(x []float64) []float64 {
+	moved := make([]float64, len(x))
+	for i := range x {
+		moved[i] = t.Centre[i] + (x[i]-t.Centre[i])/t.Factors[i]
+	}
+	return moved
+}
+
+func (t Scaling) String() string {
+	return "scaled"
+}
+
+// Transform gets the parameters of the function with each transform applied in turn, moving its known optima with it
+func (p Params) Transform(transforms ...Transform) (Params, error) {
+	if len(transforms) == 0 {
+		return p, nil
+	}
+	if len(p.Variables) > 0 {
+		return p, errors.New("invalid params passed to Transform")
+	}
+
+	var names []string
+	for _, t := range transforms {
+		p.RealFunction = t.Wrap(p.RealFunction)
+		if p.Optima != nil {
+			optima := make([][]float64, len(p.Optima))
+			for i, optimum := range p.Optima {
+				optima[i] = t.Optimum(optimum)
+			}
+			p.Optima = optima
+		}
+		names = append(names, t.String())
+	}
+
+	p.Function = Encoded(p.RealFunction, p.ScaleMin, p.ScaleMax)
+	// Plot limits of the function no longer suit its transformed landscape
+	p.PlotMin, p.PlotMax = 0, 0
+	p.Label += " (" + strings.Join(names, ", ") + ")"
+	return p, nil
+}
+
+// Centre gets the point transforms such as Rotation and Scaling are centred on, the function's first known optimum
+// or the origin if it has none
+func (p Params) Centre() []float64 {
+	if len(p.Optima) > 0 {
+		return append([]float64(nil), p.Optima[0]...)
+	}
+	return make([]float64, p.N)
+}
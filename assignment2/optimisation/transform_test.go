@@ -0,0 +1,85 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewShift(t *testing.T) {
+	bounds := Bounds{Min: RastriginMin, Max: RastriginMax}
+	shift := NewShift(make([]float64, 5), bounds, 1)
+	assert.Equal(t, shift, NewShift(make([]float64, 5), bounds, 1), "Shift should be deterministic for a seed")
+	assert.NotEqual(t, shift, NewShift(make([]float64, 5), bounds, 2), "Shift should differ between seeds")
+
+	optimum := shift.Optimum(make([]float64, 5))
+	for _, x := range optimum {
+		assert.True(t, x >= -4.096 && x <= 4.096, "Shifted optimum should be within the central 80% of the bounds")
+	}
+	assert.Equal(t, 0.0, shift.Wrap(RastriginReal)(optimum), "Shifted optimum should be optimal")
+}
+
+func TestRandomOrthogonal(t *testing.T) {
+	matrix := RandomOrthogonal(6, rand.New(rand.NewSource(0)))
+	for i := range matrix {
+		for k := range matrix {
+			var dot float64
+			for j := range matrix[i] {
+				dot += matrix[i][j] * matrix[k][j]
+			}
+			if i == k {
+				assert.InDelta(t, 1, dot, 1e-9, "Rows should be unit length")
+			} else {
+				assert.InDelta(t, 0, dot, 1e-9, "Rows should be orthogonal")
+			}
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	sphere := func(x []float64) float64 {
+		var sum float64
+		for _, v := range x {
+			sum += v * v
+		}
+		return sum
+	}
+	rotation := NewRotation(make([]float64, 4), 3)
+	x := []float64{1, -2, 0.5, 3}
+	assert.InDelta(t, sphere(x), rotation.Wrap(sphere)(x), 1e-9, "Rotation should preserve distance from the centre")
+
+	moved := rotation.Optimum(x)
+	assert.InDelta(t, RastriginReal(x), rotation.Wrap(RastriginReal)(moved), 1e-9, "Optimum should undo the rotation")
+}
+
+func TestNewConditioning(t *testing.T) {
+	scaling := NewConditioning(1e6, []float64{1, 1, 1})
+	assert.InDeltaSlice(t, []float64{1, 31.6227766, 1000}, scaling.Factors, 1e-6, "Factors should grow geometrically to sqrt(condition)")
+	assert.Equal(t, 0.0, scaling.Wrap(RosenbrockReal)(scaling.Optimum([]float64{1, 1, 1})), "Scaling about the optimum should keep it optimal")
+	assert.Equal(t, []float64{1}, NewConditioning(1e6, []float64{0}).Factors, "One variable should not be scaled")
+}
+
+func TestParams_Transform(t *testing.T) {
+	for _, name := range []string{"rastrigin", "schwefel", "griewangk", "ackley", "rosenbrock"} {
+		params, _ := GetParams(name)
+		transformed, err := params.Transform(NewConditioning(100, params.Centre()), NewRotation(params.Centre(), 1),
+			NewShift(params.Centre(), Bounds{Min: params.ScaleMin, Max: params.ScaleMax}, 1))
+		assert.Nil(t, err, "Functions without per-variable bounds can be transformed")
+		assert.Equal(t, params.Label+" (scaled, rotated, shifted)", transformed.Label, "Label should list the transforms")
+		assert.InDelta(t, params.RealFunction(params.Optima[0]), transformed.RealFunction(transformed.Optima[0]), 1e-9,
+			"Transformed optimum of "+name+" should be optimal")
+		assert.NotEqual(t, params.Optima[0], transformed.Optima[0], "Optimum of "+name+" should move")
+
+		genes := make([]uint16, params.N)
+		for i := range genes {
+			genes[i] = uint16(i * 1000)
+		}
+		assert.Equal(t, transformed.RealFunction(params.Decode(genes)), transformed.Function(genes), "Function should decode genes for the transformed function")
+	}
+
+	params, _ := GetParams("geartrain")
+	_, err := params.Transform(NewNoise(AdditiveNoise, 1, 0))
+	assert.NotNil(t, err, "Problems with per-variable bounds cannot be transformed")
+	_, err = params.Transform()
+	assert.Nil(t, err, "Problems with per-variable bounds can be used without transforms")
+}
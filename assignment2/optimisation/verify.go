@@ -0,0 +1,78 @@
+package optimisation
+
+import "math"
+
+// MismatchTolerance is the relative difference allowed between a recorded and re-evaluated fitness
+const MismatchTolerance = 1e-9
+
+// Solution is a solution's genes decoded to the function's variables, with its fitness re-evaluated to verify the
+// fitness recorded by the algorithm that found it
+type Solution struct {
+	Genes           []uint16
+	Decoded         []float64
+	RecordedFitness float64
+	Fitness         float64  // Fitness of the genes re-evaluated, the true fitness without noise for noisy functions
+	Mismatch        bool     `json:",omitempty"` // Set when the recorded fitness is not the re-evaluated fitness
+	OptimumDistance *float64 `json:",omitempty"` // Euclidean distance to the nearest known global optimum
+	Violation       *float64 `json:",omitempty"` // Total violation of the constraints, 0 if feasible (constrained functions only)
+}
+
+// Verify decodes the genes and re-evaluates their fitness, flagging a mismatch with the recorded fitness. The recorded
+// fitness of an infeasible solution includes its penalty, and that of a noisy function's solution was observed with
+// noise, so neither is checked.
+func (p Params) Verify(genes []uint16, recordedFitness float64) Solution {
+	function := p.Function
+	if p.NoiseFree != nil {
+		function = p.NoiseFree
+	}
+	return p.solution(genes, p.Decode(genes), function(genes), recordedFitness)
+}
+
+// VerifyReal is Verify for the real values found by a real-valued algorithm, re-evaluating them with RealFunction
+// rather than at the genes they encode to. Noisy functions only keep their function of genes without noise, so the
+// genes of their solutions are re-evaluated.
+func (p Params) VerifyReal(x []float64, recordedFitness float64) Solution {
+	genes := p.GetBounds(Clamp).EncodeAll(x)
+	if p.RealFunction == nil || p.NoiseFree != nil {
+		return p.Verify(genes, recordedFitness)
+	}
+	return p.solution(genes, p.DecodeReal(x), p.RealFunction(x), recordedFitness)
+}
+
+// solution creates the Solution of the genes with their decoded variables and re-evaluated fitness
+func (p Params) solution(genes []uint16, decoded []float64, fitness float64, recordedFitness float64) Solution {
+	s := Solution{
+		Genes:           genes,
+		Decoded:         decoded,
+		RecordedFitness: recordedFitness,
+		Fitness:         fitness,
+	}
+	s.Mismatch = p.NoiseFree == nil &&
+		math.Abs(s.Fitness-recordedFitness) > MismatchTolerance*math.Max(1, math.Abs(recordedFitness))
+	if p.Constraints != nil {
+		violation := sum(p.Constraints(decoded))
+		s.Violation = &violation
+		s.Mismatch = s.Mismatch && violation == 0
+	}
+	if distance, ok := p.OptimumDistance(s.Decoded); ok {
+		s.OptimumDistance = &distance
+	}
+	return s
+}
+
+// OptimumDistance gets the Euclidean distance from x to the nearest known global optimum, if the function has any
+func (p Params) OptimumDistance(x []float64) (float64, bool) {
+	if len(p.Optima) == 0 {
+		return 0, false
+	}
+
+	nearest := math.MaxFloat64
+	for _, optimum := range p.Optima {
+		var sum float64
+		for i := range optimum {
+			sum += math.Pow(x[i]-optimum[i], 2)
+		}
+		nearest = math.Min(nearest, math.Sqrt(sum))
+	}
+	return nearest, true
+}
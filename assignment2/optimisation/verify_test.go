@@ -0,0 +1,86 @@
+package optimisation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParams_Verify(t *testing.T) {
+	params, _ := GetParams("rastrigin")
+	genes := make([]uint16, RastriginN)
+	for i := range genes {
+		genes[i] = 32768
+	}
+
+	s := params.Verify(genes, Rastrigin(genes))
+	assert.Equal(t, params.Decode(genes), s.Decoded, "Solution should be decoded")
+	assert.Equal(t, Rastrigin(genes), s.Fitness, "Solution should be re-evaluated")
+	assert.False(t, s.Mismatch, "Matching fitness should not be flagged")
+	assert.NotNil(t, s.OptimumDistance, "Rastrigin has a known global optimum")
+	assert.InDelta(t, 0, *s.OptimumDistance, 1e-3, "Solution should be at the global optimum")
+
+	s = params.Verify(genes, 5)
+	assert.True(t, s.Mismatch, "Differing fitness should be flagged")
+
+	s = Params{Function: TestFunc, ScaleMin: 0, ScaleMax: 1}.Verify([]uint16{0}, 0)
+	assert.Nil(t, s.OptimumDistance, "No distance without a known global optimum")
+	assert.Nil(t, s.Violation, "No violation without constraints")
+}
+
+func TestParams_VerifyReal(t *testing.T) {
+	params, _ := GetParams("rastrigin")
+	x := make([]float64, RastriginN)
+	for i := range x {
+		x[i] = 0.1234567
+	}
+
+	s := params.VerifyReal(x, RastriginReal(x))
+	assert.False(t, s.Mismatch, "Fitness of the real values should not be flagged, though their genes differ")
+	assert.Equal(t, x, s.Decoded, "Real values should be kept unquantised")
+	assert.Equal(t, params.GetBounds(Clamp).EncodeAll(x), s.Genes, "Genes should be those the real values encode to")
+
+	params = WeldedBeam.Params()
+	u := []float64{0.5, 0.5, 0.5, 0.5}
+	s = params.VerifyReal(u, params.RealFunction(u))
+	assert.Equal(t, params.DecodeReal(u), s.Decoded, "Problem variables should be decoded from the unit hypercube")
+	assert.Equal(t, params.RealViolation(u), *s.Violation, "Violation should be of the real values")
+}
+
+func TestParams_Verify_Constrained(t *testing.T) {
+	params := G06.Params()
+	s := params.Verify([]uint16{0, 0}, 1e6)
+	assert.NotNil(t, s.Violation, "Constrained solutions should have their violation")
+	assert.Greater(t, *s.Violation, 0.0, "Solution should be infeasible")
+	assert.False(t, s.Mismatch, "Penalised fitness of infeasible solutions should not be flagged")
+}
+
+func TestParams_Verify_Noisy(t *testing.T) {
+	params, _ := GetParams("rastrigin")
+	params = params.Noisy(NewNoise(AdditiveNoise, 1, 0))
+	genes := make([]uint16, RastriginN)
+	s := params.Verify(genes, params.Function(genes))
+	assert.Equal(t, Rastrigin(genes), s.Fitness, "Noisy solutions should be re-evaluated without noise")
+	assert.False(t, s.Mismatch, "Noisy fitness should not be flagged")
+}
+
+func TestParams_OptimumDistance(t *testing.T) {
+	params := Params{Optima: [][]float64{{0, 0}, {10, 10}}}
+	distance, ok := params.OptimumDistance([]float64{7, 6})
+	assert.True(t, ok, "Params has known optima")
+	assert.Equal(t, 5.0, distance, "Distance should be to the nearest optimum")
+}
+
+func TestGetParams_Optima(t *testing.T) {
+	for _, name := range []string{"rastrigin", "schwefel", "griewangk", "ackley", "rosenbrock", "geartrain"} {
+		params, _ := GetParams(name)
+		for _, optimum := range params.Optima {
+			var fitness float64
+			if params.Variables != nil {
+				fitness = params.Function(GearTrain.Encode(optimum))
+			} else {
+				fitness = params.RealFunction(optimum)
+			}
+			assert.InDelta(t, 0, fitness, 1e-3, "Known optimum of "+name+" should be optimal")
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package pso
+
+import (
+	"errors"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Topology sets which particles' personal bests a particle is attracted to
+type Topology int
+
+const (
+	GBest Topology = iota // Every particle is attracted to the best of the whole swarm
+	LBest                 // Particles are attracted to the best of their neighbours on a ring
+)
+
+// Config configures particle swarm optimisation
+type Config struct {
+	Topology Topology
+	Radius   int     // LBest: neighbours on each side of a particle on the ring
+	W        float64 // Inertia weight
+	C1       float64 // Acceleration towards the particle's personal best
+	C2       float64 // Acceleration towards the neighbourhood best
+	VMax     float64 // Largest velocity, as a fraction of the width of the bounds
+}
+
+// DefaultConfig returns the constriction coefficient parameters of Clerc & Kennedy (DOI 10.1109/4235.985692)
+func DefaultConfig(topology Topology) Config {
+	return Config{Topology: topology, Radius: 1, W: 0.7298, C1: 1.49618, C2: 1.49618, VMax: 0.5}
+}
+
+// GetTopology gets a Topology by name
+func GetTopology(name string) (Topology, error) {
+	switch name {
+	case "gbest":
+		return GBest, nil
+	case "lbest":
+		return LBest, nil
+	}
+
+	return GBest, errors.New("invalid topology passed to GetTopology")
+}
+
+// Swarm holds the particles of PSO. Each particle holds a value for every variable, but a move may only change some of
+// the variables (see Move), so swarms can optimise subcomponents against a context vector.
+type Swarm struct {
+	Positions    [][]float64
+	Velocities   [][]float64
+	Fitness      []float64
+	PBest        [][]float64 // Best position found by each particle
+	PBestFitness []float64
+	Direction    f.Direction // Direction personal bests are improved in
+}
+
+// Run runs particle swarm optimisation on the function's real-valued variables
+func Run(evaluations int, generations int, popSize int, N int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config Config) ([]chart.BestFitness, float64, []float64) {
+	var evals int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise swarm
+	swarm := InitSwarm(N, popSize, direction, bounds, config, r)
+	evals += swarm.EvalFitness(function)
+	best := swarm.Best()
+	bestFitness := swarm.PBestFitness[best]
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	dims := make([]int, N)
+	for i := 0; i < N; i++ {
+		dims[i] = i
+	}
+	doGeneration := func(gen int) {
+		swarm.Move(dims, config, bounds, r)
+		evals += swarm.EvalFitness(function)
+		best = swarm.Best()
+		if direction.Better(swarm.PBestFitness[best], bestFitness) {
+			bestFitness = swarm.PBestFitness[best]
+			if gen != 0 {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+			} else {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+			}
+		}
+	}
+
+	if evaluations != 0 {
+		// Run PSO for N function evaluations
+		for evals < evaluations {
+			doGeneration(0)
+		}
+	} else if generations != 0 {
+		// Run PSO for N generations
+		for gen := 0; gen < generations; gen++ {
+			doGeneration(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, append([]float64(nil), swarm.PBest[best]...)
+}
+
+// InitSwarm creates popSize particles of N variables at uniformly random positions within the bounds, with uniformly
+// random velocities up to the largest velocity
+func InitSwarm(N int, popSize int, direction f.Direction, bounds f.Bounds, config Config, r *rand.Rand) *Swarm {
+	vMax := config.VMax * bounds.Width()
+	swarm := &Swarm{
+		Positions:    make([][]float64, popSize),
+		Velocities:   make([][]float64, popSize),
+		Fitness:      make([]float64, popSize),
+		PBest:        make([][]float64, popSize),
+		PBestFitness: make([]float64, popSize),
+		Direction:    direction,
+	}
+	for i := 0; i < popSize; i++ {
+		swarm.Positions[i] = make([]float64, N)
+		swarm.Velocities[i] = make([]float64, N)
+		for n := 0; n < N; n++ {
+			swarm.Positions[i][n] = bounds.Min + r.Float64()*bounds.Width()
+			swarm.Velocities[i][n] = (2*r.Float64() - 1) * vMax
+		}
+		swarm.PBest[i] = append([]float64(nil), swarm.Positions[i]...)
+		swarm.PBestFitness[i] = direction.Worst()
+	}
+	return swarm
+}
+
+// EvalFitness evaluates the fitness of every particle's position, updating personal bests that are improved upon
+// Return number of fitness evaluations
+func (swarm *Swarm) EvalFitness(fitness f.RealFitness) int {
+	for i := 0; i < len(swarm.Positions); i++ {
+		swarm.Fitness[i] = fitness(swarm.Positions[i])
+		if swarm.Direction.Better(swarm.Fitness[i], swarm.PBestFitness[i]) {
+			swarm.PBestFitness[i] = swarm.Fitness[i]
+			swarm.PBest[i] = append([]float64(nil), swarm.Positions[i]...)
+		}
+	}
+	return len(swarm.Positions)
+}
+
+// EvalPBest re-evaluates the fitness of every particle's personal best, for when the fitness landscape has changed
+// (such as the context vector changing in cooperative PSO)
+// Return number of fitness evaluations
+func (swarm *Swarm) EvalPBest(fitness f.RealFitness) int {
+	for i := 0; i < len(swarm.PBest); i++ {
+		swarm.PBestFitness[i] = fitness(swarm.PBest[i])
+	}
+	return len(swarm.PBest)
+}
+
+// Best finds the index of the particle with the best personal best fitness
+func (swarm *Swarm) Best() int {
+	best := 0
+	for i := 1; i < len(swarm.PBestFitness); i++ {
+		if swarm.Direction.Better(swarm.PBestFitness[i], swarm.PBestFitness[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// NeighbourhoodBest finds the index of the best personal best among particle i and its neighbours on a ring
+func (swarm *Swarm) NeighbourhoodBest(i int, radius int) int {
+	n := len(swarm.PBestFitness)
+	best := i
+	for d := -radius; d <= radius; d++ {
+		j := ((i+d)%n + n) % n
+		if swarm.Direction.Better(swarm.PBestFitness[j], swarm.PBestFitness[best]) {
+			best = j
+		}
+	}
+	return best
+}
+
+// Move updates the velocity and position of every particle, changing only the variables in dims. Velocities are
+// clamped to the largest velocity and positions leaving the bounds are repaired.
+func (swarm *Swarm) Move(dims []int, config Config, bounds f.Bounds, r *rand.Rand) {
+	vMax := config.VMax * bounds.Width()
+	best := swarm.Best()
+
+	for i := 0; i < len(swarm.Positions); i++ {
+		attractor := best
+		if config.Topology == LBest {
+			attractor = swarm.NeighbourhoodBest(i, config.Radius)
+		}
+
+		x, v := swarm.Positions[i], swarm.Velocities[i]
+		for _, d := range dims {
+			v[d] = config.W*v[d] +
+				config.C1*r.Float64()*(swarm.PBest[i][d]-x[d]) +
+				config.C2*r.Float64()*(swarm.PBest[attractor][d]-x[d])
+			v[d] = math.Max(-vMax, math.Min(vMax, v[d]))
+			x[d] = bounds.Repair(x[d]+v[d], r)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package pso
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// sphere is minimised at the origin
+func sphere(x []float64) float64 {
+	var sum float64
+	for _, v := range x {
+		sum += v * v
+	}
+	return sum
+}
+
+func TestGetTopology(t *testing.T) {
+	topology, err := GetTopology("lbest")
+	assert.Nil(t, err, "GetTopology should find lbest")
+	assert.Equal(t, LBest, topology, "GetTopology returned wrong topology")
+
+	_, err = GetTopology("invalid")
+	assert.NotNil(t, err, "GetTopology should error for unknown topology")
+}
+
+func TestSwarm_EvalFitness(t *testing.T) {
+	swarm := InitSwarm(2, 2, f.Minimise, f.Bounds{Min: -5, Max: 5}, DefaultConfig(GBest), rand.New(rand.NewSource(0)))
+	swarm.Positions = [][]float64{{1, 1}, {2, 2}}
+	assert.Equal(t, 2, swarm.EvalFitness(sphere), "Each particle should be evaluated once")
+	assert.Equal(t, []float64{2, 8}, swarm.PBestFitness, "Personal bests should be set on first evaluation")
+
+	swarm.Positions = [][]float64{{0, 1}, {3, 3}}
+	swarm.EvalFitness(sphere)
+	assert.Equal(t, []float64{1, 8}, swarm.PBestFitness, "Personal bests should only be replaced by better positions")
+	assert.Equal(t, []float64{2, 2}, swarm.PBest[1], "Personal best position should be kept")
+	assert.Equal(t, 0, swarm.Best(), "Best should find the best personal best")
+}
+
+func TestSwarm_NeighbourhoodBest(t *testing.T) {
+	swarm := Swarm{PBestFitness: []float64{1, 5, 4, 3, 2}}
+	assert.Equal(t, 3, swarm.NeighbourhoodBest(2, 1), "Neighbourhood best should be among neighbours")
+	assert.Equal(t, 0, swarm.NeighbourhoodBest(4, 1), "Ring should wrap around")
+	assert.Equal(t, 0, swarm.NeighbourhoodBest(2, 2), "Larger radius should see more neighbours")
+}
+
+func TestSwarm_Move(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -5, Max: 5, Policy: f.Reflect}
+	config := DefaultConfig(GBest)
+	swarm := InitSwarm(3, 10, f.Minimise, bounds, config, r)
+	swarm.EvalFitness(sphere)
+	fixed := make([]float64, 10)
+	for i, x := range swarm.Positions {
+		fixed[i] = x[2]
+	}
+
+	for gen := 0; gen < 20; gen++ {
+		swarm.Move([]int{0, 1}, config, bounds, r)
+		swarm.EvalFitness(sphere)
+	}
+	for i, x := range swarm.Positions {
+		assert.Equal(t, fixed[i], x[2], "Variables not in dims should not move")
+		for d := 0; d < 2; d++ {
+			assert.True(t, x[d] >= -5 && x[d] <= 5, "Positions should stay within bounds")
+			assert.LessOrEqual(t, swarm.Velocities[i][d], config.VMax*bounds.Width(), "Velocity should be clamped")
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	for _, topology := range []Topology{GBest, LBest} {
+		history, best, x := Run(5000, 0, 20, f.RastriginN, f.RastriginReal, f.Minimise, bounds, DefaultConfig(topology))
+		assert.Equal(t, f.RastriginN, len(x), "Best solution should have N variables")
+		assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match best solution")
+		assert.Equal(t, best, history[len(history)-1].Fitness, "Last entry of history should be best fitness")
+		for i := 1; i < len(history); i++ {
+			assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package pso
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// CCPSO2Config configures CCPSO2
+type CCPSO2Config struct {
+	GroupSizes []int   // Group sizes to pick from when a cycle fails to improve the context vector
+	P          float64 // Probability of sampling around the personal best with a Cauchy rather than Gaussian distribution
+}
+
+// DefaultCCPSO2Config returns the group sizes from the paper that are no larger than N, and an even mix of Cauchy and
+// Gaussian sampling
+func DefaultCCPSO2Config(N int) CCPSO2Config {
+	config := CCPSO2Config{P: 0.5}
+	for _, size := range []int{1, 2, 5, 10, 50, 100, 250} {
+		if size <= N {
+			config.GroupSizes = append(config.GroupSizes, size)
+		}
+	}
+	return config
+}
+
+// RunCPSO runs van den Bergh & Engelbrecht's cooperative split PSO, CPSO-S_K (DOI 10.1109/TEVC.2004.826069). The
+// variables are split into K groups each optimised by its own swarm of popSize particles, evaluated in collaboration
+// with the context vector made from the best of every swarm, like ccga.Individual.Coevolution. With K = N this is
+// CPSO-S. A generation is one cycle through every swarm.
+func RunCPSO(evaluations int, generations int, popSize int, N int, K int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config Config) ([]chart.BestFitness, float64, []float64) {
+	var evals int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise a swarm for each group, and the context vector
+	groups := common.Groups(N, int(math.Ceil(float64(N)/float64(K))), false, r)
+	swarms := make([]*Swarm, len(groups))
+	for j := range groups {
+		swarms[j] = InitSwarm(N, popSize, direction, bounds, config, r)
+	}
+	context := append([]float64(nil), swarms[0].Positions[0]...)
+	bestFitness := function(context)
+	evals++
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	doCycle := func(gen int) {
+		for j, group := range groups {
+			// Personal bests are stale as the context has changed since they were evaluated
+			inContext := contextFitness(function, context, group)
+			evals += swarms[j].EvalPBest(inContext)
+			evals += swarms[j].EvalFitness(inContext)
+
+			// Update context vector with the swarm's best particle, if it improves on the context
+			improved := false
+			for i := 0; i < len(swarms[j].Positions); i++ {
+				if direction.Better(swarms[j].Fitness[i], bestFitness) {
+					bestFitness = swarms[j].Fitness[i]
+					for _, d := range group {
+						context[d] = swarms[j].Positions[i][d]
+					}
+					improved = true
+				}
+			}
+			if improved {
+				if gen != 0 {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+				} else {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+				}
+			}
+
+			swarms[j].Move(group, config, bounds, r)
+		}
+	}
+
+	if evaluations != 0 {
+		// Run CPSO for N function evaluations
+		for evals < evaluations {
+			doCycle(0)
+		}
+	} else if generations != 0 {
+		// Run CPSO for N generations
+		for gen := 0; gen < generations; gen++ {
+			doCycle(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, context
+}
+
+// RunCCPSO2 runs Li & Yao's CCPSO2 (DOI 10.1109/TEVC.2011.2112662). The variables are randomly regrouped every cycle,
+// with a new group size picked whenever a cycle fails to improve the context vector. Rather than using velocities,
+// particles are sampled around their personal best and the best of their ring neighbourhood. A generation is one cycle
+// through every group.
+func RunCCPSO2(evaluations int, generations int, popSize int, N int, function f.RealFitness, direction f.Direction, bounds f.Bounds, config CCPSO2Config) ([]chart.BestFitness, float64, []float64) {
+	var evals int
+	var bestFitnessHistory []chart.BestFitness
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+
+	// Initialise the swarm, particles keep a value for every variable but each group only varies its own
+	swarm := InitSwarm(N, popSize, direction, bounds, DefaultConfig(LBest), r)
+	evals += swarm.EvalFitness(function)
+	best := swarm.Best()
+	bestFitness := swarm.PBestFitness[best]
+	context := append([]float64(nil), swarm.PBest[best]...)
+	bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: 0, Fitness: bestFitness})
+
+	groupSize := config.GroupSizes[r.Intn(len(config.GroupSizes))]
+
+	doCycle := func(gen int) {
+		improved := false
+		for _, group := range common.Groups(N, groupSize, true, r) {
+			// Personal bests are stale as the groups and context have changed since they were evaluated
+			inContext := contextFitness(function, context, group)
+			evals += swarm.EvalPBest(inContext)
+			swarm.Sample(group, config.P, bounds, r)
+			evals += swarm.EvalFitness(inContext)
+
+			// Update context vector with the group's best variables
+			best := swarm.Best()
+			if direction.Better(swarm.PBestFitness[best], bestFitness) {
+				bestFitness = swarm.PBestFitness[best]
+				for _, d := range group {
+					context[d] = swarm.PBest[best][d]
+				}
+				improved = true
+				if gen != 0 {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: gen, Fitness: bestFitness})
+				} else {
+					bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: evals, Fitness: bestFitness})
+				}
+			}
+		}
+		if !improved {
+			groupSize = config.GroupSizes[r.Intn(len(config.GroupSizes))]
+		}
+	}
+
+	if evaluations != 0 {
+		// Run CCPSO2 for N function evaluations
+		for evals < evaluations {
+			doCycle(0)
+		}
+	} else if generations != 0 {
+		// Run CCPSO2 for N generations
+		for gen := 0; gen < generations; gen++ {
+			doCycle(gen)
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, context
+}
+
+// Sample moves every particle to a point sampled around its personal best, changing only the variables in dims. The
+// spread is the distance between the personal best and the best of its ring neighbourhood, using a Cauchy distribution
+// centred on the personal best with probability p, otherwise a Gaussian distribution centred on the neighbourhood best.
+func (swarm *Swarm) Sample(dims []int, p float64, bounds f.Bounds, r *rand.Rand) {
+	for i := 0; i < len(swarm.Positions); i++ {
+		lBest := swarm.NeighbourhoodBest(i, 1)
+		for _, d := range dims {
+			y, yl := swarm.PBest[i][d], swarm.PBest[lBest][d]
+			spread := math.Abs(y - yl)
+			if r.Float64() <= p {
+				swarm.Positions[i][d] = y + spread*math.Tan(math.Pi*(r.Float64()-0.5))
+			} else {
+				swarm.Positions[i][d] = yl + spread*r.NormFloat64()
+			}
+			swarm.Positions[i][d] = bounds.Repair(swarm.Positions[i][d], r)
+		}
+	}
+}
+
+// contextFitness evaluates the group's variables of a particle in collaboration with the context vector
+func contextFitness(fitness f.RealFitness, context []float64, group []int) f.RealFitness {
+	return func(x []float64) float64 {
+		candidate := append([]float64(nil), context...)
+		for _, d := range group {
+			candidate[d] = x[d]
+		}
+		return fitness(candidate)
+	}
+}
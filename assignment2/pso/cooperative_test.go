@@ -0,0 +1,74 @@
+package pso
+
+import (
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestDefaultCCPSO2Config(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 5, 10}, DefaultCCPSO2Config(20).GroupSizes, "Group sizes should be no larger than N")
+}
+
+func TestSwarm_Sample(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	bounds := f.Bounds{Min: -5, Max: 5, Policy: f.Clamp}
+	swarm := InitSwarm(3, 5, f.Minimise, bounds, DefaultConfig(LBest), r)
+	swarm.EvalFitness(sphere)
+	fixed := swarm.Positions[0][1]
+
+	swarm.Sample([]int{0, 2}, 0.5, bounds, r)
+	assert.Equal(t, fixed, swarm.Positions[0][1], "Variables not in dims should not move")
+	for _, x := range swarm.Positions {
+		for _, v := range x {
+			assert.True(t, v >= -5 && v <= 5, "Positions should stay within bounds")
+		}
+	}
+
+	// With every personal best equal there is no spread to sample from
+	for i := range swarm.PBest {
+		swarm.PBest[i] = []float64{1, 1, 1}
+	}
+	swarm.Sample([]int{0, 1, 2}, 0.5, bounds, r)
+	assert.Equal(t, []float64{1, 1, 1}, swarm.Positions[3], "Converged swarm should sample its personal best")
+}
+
+func TestContextFitness(t *testing.T) {
+	inContext := contextFitness(sphere, []float64{1, 2, 3}, []int{1})
+	assert.Equal(t, 1.0+0+9, inContext([]float64{5, 0, 5}), "Only the group's variables should replace the context")
+}
+
+func TestRunCPSO(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	for _, K := range []int{f.RastriginN, 4} {
+		history, best, x := RunCPSO(5000, 0, 10, f.RastriginN, K, f.RastriginReal, f.Minimise, bounds, DefaultConfig(GBest))
+		assert.Equal(t, f.RastriginN, len(x), "Context vector should have N variables")
+		assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match context vector")
+		for i := 1; i < len(history); i++ {
+			assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		}
+	}
+}
+
+// TestRunCPSO_EvalPBest ensures personal bests are re-evaluated in the current context every cycle
+func TestRunCPSO_EvalPBest(t *testing.T) {
+	var calls int
+	function := func(x []float64) float64 {
+		calls++
+		return f.RastriginReal(x)
+	}
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	RunCPSO(0, 1, 10, f.RastriginN, 4, function, f.Minimise, bounds, DefaultConfig(GBest))
+	assert.Equal(t, 1+4*(10+10), calls, "Each swarm should evaluate its personal bests and particles once per cycle")
+}
+
+func TestRunCCPSO2(t *testing.T) {
+	bounds := f.Params{ScaleMin: f.RastriginMin, ScaleMax: f.RastriginMax}.GetBounds(f.Clamp)
+	history, best, x := RunCCPSO2(5000, 0, 20, f.RastriginN, f.RastriginReal, f.Minimise, bounds, DefaultCCPSO2Config(f.RastriginN))
+	assert.Equal(t, f.RastriginN, len(x), "Context vector should have N variables")
+	assert.InDelta(t, f.RastriginReal(x), best, 0.0001, "Best fitness should match context vector")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+	}
+}
@@ -0,0 +1,77 @@
+package restart
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+)
+
+// Algorithm runs an algorithm with the given budget and population size, ending early once the stop condition is met
+type Algorithm func(evaluations int, generations int, popSize int, stop common.StopCondition) ([]chart.BestFitness, float64, []uint16)
+
+// Config configures when and how an algorithm is restarted
+type Config struct {
+	Stagnation int     // Generations without improvement before restarting
+	Tolerance  float64 // Smallest change in best fitness counted as an improvement
+	IPOP       bool    // Double the population size at every restart, as per Auger & Hansen (DOI 10.1109/CEC.2005.1554902)
+	MaxPopSize int     // Largest population size IPOP may grow to, 0 for no limit
+}
+
+// Run runs the algorithm, restarting it from a new random population whenever it stagnates until the budget is spent.
+// The best fitness history is continuous across restarts, only recording improvements on the best fitness of every
+// run so far, and the progress (function evaluations or generations) at which each restart happened is returned.
+func Run(evaluations int, generations int, popSize int, algorithm Algorithm, direction f.Direction, config Config) ([]chart.BestFitness, float64, []uint16, []int) {
+	bestFitness, traceFitness := direction.Worst(), direction.Worst()
+	var bestGenes []uint16
+	var bestFitnessHistory []chart.BestFitness
+	var restarts []int
+
+	budget := evaluations
+	if evaluations == 0 {
+		budget = generations
+	}
+
+	used := 0 // Function evaluations or generations used by previous runs
+	for used < budget {
+		var progress int
+		stagnation := common.Stagnation(config.Stagnation, config.Tolerance, direction)
+		stop := func(x int, fitness float64) bool {
+			progress = x
+			return stagnation(x, fitness)
+		}
+
+		var history []chart.BestFitness
+		var fitness float64
+		var genes []uint16
+		if evaluations != 0 {
+			history, fitness, genes = algorithm(budget-used, 0, popSize, stop)
+		} else {
+			history, fitness, genes = algorithm(0, budget-used, popSize, stop)
+		}
+
+		// Continue the best fitness history from the progress of previous runs
+		for _, h := range history {
+			if direction.Better(h.Fitness, traceFitness) {
+				bestFitnessHistory = append(bestFitnessHistory, chart.BestFitness{X: used + h.X, Fitness: h.Fitness})
+				traceFitness = h.Fitness
+			}
+		}
+		if direction.Better(fitness, bestFitness) {
+			bestFitness, bestGenes = fitness, genes
+		}
+
+		if progress == 0 {
+			// The algorithm made no progress, so would never spend the budget
+			break
+		}
+		used += progress
+		if used < budget {
+			restarts = append(restarts, used)
+			if config.IPOP && (config.MaxPopSize == 0 || 2*popSize <= config.MaxPopSize) {
+				popSize *= 2
+			}
+		}
+	}
+
+	return bestFitnessHistory, bestFitness, bestGenes, restarts
+}
@@ -0,0 +1,62 @@
+package restart
+
+import (
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/common"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/ga"
+	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/localsearch"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// stagnatingAlgorithm improves once in its first generation to the given fitness, then stagnates, using popSize
+// evaluations per generation
+func stagnatingAlgorithm(fitnesses []float64, popSizes *[]int) Algorithm {
+	run := 0
+	return func(evaluations int, generations int, popSize int, stop common.StopCondition) ([]chart.BestFitness, float64, []uint16) {
+		*popSizes = append(*popSizes, popSize)
+		fitness := fitnesses[run%len(fitnesses)]
+		run++
+		history := []chart.BestFitness{{X: 0, Fitness: fitness + 1}, {X: popSize, Fitness: fitness}}
+		for evals := popSize; evals < evaluations; evals += popSize {
+			if stop(evals, fitness) {
+				break
+			}
+		}
+		return history, fitness, []uint16{uint16(fitness)}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var popSizes []int
+	algorithm := stagnatingAlgorithm([]float64{5, 7, 3}, &popSizes)
+	history, best, genes, restarts := Run(100, 0, 10, algorithm, f.Minimise, Config{Stagnation: 2})
+
+	assert.Equal(t, 3.0, best, "Best fitness should be the best of every run")
+	assert.Equal(t, []uint16{3}, genes, "Best genes should be from the best run")
+	assert.Equal(t, []int{30, 60, 90}, restarts, "Restarts should happen after stagnating for two generations")
+	assert.Equal(t, []int{10, 10, 10, 10}, popSizes, "Population size should not change without IPOP")
+	assert.Equal(t, []chart.BestFitness{{X: 0, Fitness: 6}, {X: 10, Fitness: 5}, {X: 60, Fitness: 4}, {X: 70, Fitness: 3}}, history,
+		"History should only record improvements, offset by the progress of earlier runs")
+}
+
+func TestRun_IPOP(t *testing.T) {
+	var popSizes []int
+	algorithm := stagnatingAlgorithm([]float64{1}, &popSizes)
+	Run(1000, 0, 10, algorithm, f.Minimise, Config{Stagnation: 1, IPOP: true, MaxPopSize: 40})
+	assert.Equal(t, []int{10, 20, 40, 40}, popSizes[:4], "IPOP should double population size up to the limit")
+}
+
+func TestRun_GA(t *testing.T) {
+	algorithm := func(evaluations int, generations int, popSize int, stop common.StopCondition) ([]chart.BestFitness, float64, []uint16) {
+		return ga.RunMemeticUntil(localsearch.Config{}, stop, evaluations, generations, popSize, f.RastriginN, f.Rastrigin, f.Minimise, f.RastriginMutationP)
+	}
+	history, best, genes, restarts := Run(20000, 0, 10, algorithm, f.Minimise, Config{Stagnation: 3})
+	assert.Equal(t, f.Rastrigin(genes), best, "Best fitness should match best solution")
+	assert.NotEmpty(t, restarts, "GA should stagnate within 20000 evaluations")
+	for i := 1; i < len(history); i++ {
+		assert.Less(t, history[i].Fitness, history[i-1].Fitness, "Best fitness history should improve")
+		assert.Greater(t, history[i].X, history[i-1].X, "Best fitness history should be continuous across restarts")
+	}
+}
@@ -3,26 +3,43 @@ package result
 import (
 	"encoding/json"
 	"github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/chart"
+	f "github.com/OscarVanL/COMP6026-Evolution-of-Complexity/assignment2/optimisation"
 	"io/ioutil"
 	"log"
 	"math"
 )
 
 type FunctionResults struct {
-	Function string
-	GA       Result
-	CCGA1    Result
-	CCGAHC   Result
+	Function  string
+	Encoding  string  `json:",omitempty"` // Encoding genes were decoded with
+	Maximised bool    `json:",omitempty"` // Whether the function was maximised, so larger fitness is better
+	GA        *Result `json:",omitempty"` // Results for the standard GA, nil if it was not run
+	CCGA1     *Result `json:",omitempty"` // Results for CCGA-1, nil if it was not run
+	CCGAHC    *Result `json:",omitempty"` // Results for CCGA-HC, nil if it was not run
+
+	Algorithms map[string]Result `json:",omitempty"` // Results for any further algorithms, keyed by name
 }
 
 type Result struct {
 	Fitnesses []float64
 	Mean      float64
 	StdDev    float64
+
+	OptimaFound     []int   `json:",omitempty"` // Distinct optima located in each run (niching GA only)
+	MeanOptimaFound float64 `json:",omitempty"`
+
+	Solutions []f.Solution `json:",omitempty"` // Best solution of each run, decoded and re-evaluated
+
+	FeasibilityRates []float64 `json:",omitempty"` // Fraction of each run's evaluations that were feasible (constrained functions only)
+	FeasibleRuns     *float64  `json:",omitempty"` // Fraction of runs whose best solution is feasible (constrained functions only)
+
+	TrueFitnesses []float64 `json:",omitempty"` // Fitness of each run's best solution without noise, Fitnesses being observed with noise (noisy functions only)
+	TrueMean      *float64  `json:",omitempty"`
 }
 
 // WriteResults is used to write the experiment results to a JSON file, including mean and standard deviation calculations.
-func WriteResults(output string, res [][]chart.EvolutionResults) {
+// params are the parameters of each function the results are for, used to verify the best solutions.
+func WriteResults(output string, res [][]chart.EvolutionResults, params []f.Params) {
 	var allResults []FunctionResults
 
 	// Create results JSON for each function's runs
@@ -30,26 +47,18 @@ func WriteResults(output string, res [][]chart.EvolutionResults) {
 		currResult := res[i]
 
 		GAFitnesses, CCGAFitnesses, CCGAHCFitnesses := getFinalFitnesses(currResult)
-		GAMean, CCGAMean, CCGAHCMean := getMean(GAFitnesses, CCGAFitnesses, CCGAHCFitnesses)
-		GAStdDev, CCGAStdDev, CCGAHCStdDev := getStdDev(GAFitnesses, GAMean, CCGAFitnesses, CCGAMean, CCGAHCFitnesses, CCGAHCMean)
+		GASolutions, CCGASolutions, CCGAHCSolutions := getSolutions(params[i], currResult)
+		GARates, CCGARates, CCGAHCRates := getFeasibilityRates(currResult)
 
 		result := FunctionResults{
-			Function: currResult[0].Title,
-			GA: Result{
-				Fitnesses: GAFitnesses,
-				Mean:      GAMean,
-				StdDev:    GAStdDev,
-			},
-			CCGA1: Result{
-				Fitnesses: CCGAFitnesses,
-				Mean:      CCGAMean,
-				StdDev:    CCGAStdDev,
-			},
-			CCGAHC: Result{
-				Fitnesses: CCGAHCFitnesses,
-				Mean:      CCGAHCMean,
-				StdDev:    CCGAHCStdDev,
-			}}
+			Function:   currResult[0].Title,
+			Encoding:   currResult[0].Encoding,
+			Maximised:  currResult[0].Maximised,
+			GA:         newResult(params[i], GAFitnesses, GASolutions, GARates),
+			CCGA1:      newResult(params[i], CCGAFitnesses, CCGASolutions, CCGARates),
+			CCGAHC:     newResult(params[i], CCGAHCFitnesses, CCGAHCSolutions, CCGAHCRates),
+			Algorithms: getAlgorithmResults(params[i], currResult),
+		}
 
 		allResults = append(allResults, result)
 	}
@@ -64,42 +73,168 @@ func WriteResults(output string, res [][]chart.EvolutionResults) {
 	}
 }
 
-func getFinalFitnesses(result []chart.EvolutionResults) ([]float64, []float64, []float64) {
-	var GAFitnesses, CCGAFitnesses, CCGAHCFitnesses []float64
+// getAlgorithmResults calculates the final fitnesses, mean and standard deviation for each further algorithm
+func getAlgorithmResults(params f.Params, result []chart.EvolutionResults) map[string]Result {
+	if len(result[0].Algorithms) == 0 {
+		return nil
+	}
+
+	algorithmResults := make(map[string]Result)
+	for a := 0; a < len(result[0].Algorithms); a++ {
+		var fitnesses []float64
+		var optimaFound []int
+		var optimaSum int
+		var solutions []f.Solution
+		var rates []float64
+		for i := 0; i < len(result); i++ {
+			rates = append(rates, result[i].Algorithms[a].Feasibility)
+			solutions = append(solutions, verifyAlgorithm(params, result[i].Algorithms[a]))
+			if hist := result[i].Algorithms[a].FitnessHistory; len(hist) > 0 {
+				fitnesses = append(fitnesses, hist[len(hist)-1].Fitness)
+			}
+			optimaFound = append(optimaFound, result[i].Algorithms[a].OptimaFound)
+			optimaSum += result[i].Algorithms[a].OptimaFound
+		}
+
+		res := newResult(params, fitnesses, solutions, rates)
+		if res == nil {
+			continue
+		}
+		if optimaSum > 0 {
+			res.OptimaFound = optimaFound
+			res.MeanOptimaFound = float64(optimaSum) / float64(len(optimaFound))
+		}
+		algorithmResults[result[0].Algorithms[a].Name] = *res
+	}
+	return algorithmResults
+}
+
+// newResult calculates the mean and standard deviation of an algorithm's final fitnesses, along with the feasibility
+// and true fitness of its runs' best solutions. Return nil if the algorithm was not run, so has no fitnesses.
+func newResult(params f.Params, fitnesses []float64, solutions []f.Solution, rates []float64) *Result {
+	if len(fitnesses) == 0 {
+		return nil
+	}
+	mean, stdDev := getStats(fitnesses)
+	res := &Result{
+		Fitnesses: fitnesses,
+		Mean:      mean,
+		StdDev:    stdDev,
+		Solutions: solutions,
+	}
+	res.setFeasibility(params, rates)
+	res.setTrueFitness(params)
+	return res
+}
+
+// getSolutions verifies the best solution of each run of the standard GA, CCGA-1 and CCGA-HC, if they were run
+func getSolutions(params f.Params, result []chart.EvolutionResults) ([]f.Solution, []f.Solution, []f.Solution) {
+	var GASolutions, CCGASolutions, CCGAHCSolutions []f.Solution
 	for i := 0; i < len(result); i++ {
-		hist := result[i].GAFitnessHistory
-		GAFitnesses = append(GAFitnesses, hist[len(hist)-1].Fitness)
-		hist = result[i].CCGAFitnessHistory
-		CCGAFitnesses = append(CCGAFitnesses, hist[len(hist)-1].Fitness)
-		hist = result[i].CCGAHCFitnessHistory
-		CCGAHCFitnesses = append(CCGAHCFitnesses, hist[len(hist)-1].Fitness)
+		if result[i].BestAssignmentGA != nil {
+			GASolutions = append(GASolutions, verify(params, "GA", result[i].BestAssignmentGA, result[i].BestFitnessGA))
+		}
+		if result[i].BestAssignmentCCGA != nil {
+			CCGASolutions = append(CCGASolutions, verify(params, "CCGA-1", result[i].BestAssignmentCCGA, result[i].BestFitnessCCGA))
+		}
+		if result[i].BestAssignmentCCGAHC != nil {
+			CCGAHCSolutions = append(CCGAHCSolutions, verify(params, "CCGA-HC", result[i].BestAssignmentCCGAHC, result[i].BestFitnessCCGAHC))
+		}
 	}
-	return GAFitnesses, CCGAFitnesses, CCGAHCFitnesses
+	return GASolutions, CCGASolutions, CCGAHCSolutions
+}
+
+// verify decodes and re-evaluates a best solution, warning when its fitness differs from the one the algorithm recorded
+func verify(params f.Params, algorithm string, genes []uint16, fitness float64) f.Solution {
+	return warnMismatch(params, algorithm, params.Verify(genes, fitness))
+}
+
+// verifyAlgorithm verifies the best solution of a run of an algorithm, re-evaluating the real values found by
+// real-valued algorithms rather than the genes they encode to
+func verifyAlgorithm(params f.Params, res chart.AlgorithmResults) f.Solution {
+	if res.BestValues != nil {
+		return warnMismatch(params, res.Name, params.VerifyReal(res.BestValues, res.BestFitness))
+	}
+	return verify(params, res.Name, res.BestAssignment, res.BestFitness)
 }
 
-func getMean(GAFitnesses []float64, CCGAFitnesses []float64, CCGAHCFitnesses []float64) (float64, float64, float64) {
-	var GASum, CCGASum, CCGAHCSum float64
-	for i := 0; i < len(GAFitnesses); i++ {
-		GASum += GAFitnesses[i]
-		CCGASum += CCGAFitnesses[i]
-		CCGAHCSum += CCGAHCFitnesses[i]
+// warnMismatch warns when the fitness of a verified solution differs from the one the algorithm recorded
+func warnMismatch(params f.Params, algorithm string, solution f.Solution) f.Solution {
+	if solution.Mismatch {
+		log.Printf("Warning: %s recorded fitness %g for its best solution on %s, which re-evaluates to %g\n",
+			algorithm, solution.RecordedFitness, params.Label, solution.Fitness)
 	}
+	return solution
+}
 
-	return GASum / float64(len(GAFitnesses)), CCGASum / float64(len(CCGAFitnesses)), CCGAHCSum / float64(len(CCGAHCFitnesses))
+// getFeasibilityRates gets the fraction of each run's evaluations that were feasible for the standard GA, CCGA-1 and
+// CCGA-HC
+func getFeasibilityRates(result []chart.EvolutionResults) ([]float64, []float64, []float64) {
+	var GARates, CCGARates, CCGAHCRates []float64
+	for i := 0; i < len(result); i++ {
+		GARates = append(GARates, result[i].FeasibilityGA)
+		CCGARates = append(CCGARates, result[i].FeasibilityCCGA)
+		CCGAHCRates = append(CCGAHCRates, result[i].FeasibilityCCGAHC)
+	}
+	return GARates, CCGARates, CCGAHCRates
 }
 
-func getStdDev(GAFitnesses []float64, GAMean float64, CCGAFitnesses []float64, CCGAMean float64, CCGAHCFitnesses []float64, CCGAHCMean float64) (float64, float64, float64) {
-	var GAStdDev, CCGAStdDev, CCGAHCStdDev float64
+// setFeasibility records the feasibility of the runs on a constrained function, the fraction of each run's evaluations
+// that were feasible and the fraction of runs whose best solution is feasible
+func (r *Result) setFeasibility(params f.Params, rates []float64) {
+	if params.Constraints == nil || len(r.Solutions) == 0 {
+		return
+	}
+	var feasible int
+	for _, solution := range r.Solutions {
+		if *solution.Violation == 0 {
+			feasible++
+		}
+	}
+	feasibleRuns := float64(feasible) / float64(len(r.Solutions))
+	r.FeasibilityRates, r.FeasibleRuns = rates, &feasibleRuns
+}
 
-	for i := 0; i < len(GAFitnesses); i++ {
-		GAStdDev += math.Pow(GAFitnesses[i]-GAMean, 2)
-		CCGAStdDev += math.Pow(CCGAFitnesses[i]-CCGAMean, 2)
-		CCGAHCStdDev += math.Pow(CCGAHCFitnesses[i]-CCGAHCMean, 2)
+// setTrueFitness records the true fitness of each run's best solution to a noisy function, re-evaluated without noise
+func (r *Result) setTrueFitness(params f.Params) {
+	if params.NoiseFree == nil || len(r.Solutions) == 0 {
+		return
 	}
+	var sum float64
+	for _, solution := range r.Solutions {
+		r.TrueFitnesses = append(r.TrueFitnesses, solution.Fitness)
+		sum += solution.Fitness
+	}
+	mean := sum / float64(len(r.TrueFitnesses))
+	r.TrueMean = &mean
+}
 
-	GAStdDev /= float64(len(GAFitnesses))
-	CCGAStdDev /= float64(len(CCGAFitnesses))
-	CCGAHCStdDev /= float64(len(CCGAHCFitnesses))
+func getFinalFitnesses(result []chart.EvolutionResults) ([]float64, []float64, []float64) {
+	var GAFitnesses, CCGAFitnesses, CCGAHCFitnesses []float64
+	// Algorithms that were not run have no fitness history, and so no fitnesses
+	finalFitness := func(fitnesses []float64, hist []chart.BestFitness) []float64 {
+		if len(hist) == 0 {
+			return fitnesses
+		}
+		return append(fitnesses, hist[len(hist)-1].Fitness)
+	}
+	for i := 0; i < len(result); i++ {
+		GAFitnesses = finalFitness(GAFitnesses, result[i].GAFitnessHistory)
+		CCGAFitnesses = finalFitness(CCGAFitnesses, result[i].CCGAFitnessHistory)
+		CCGAHCFitnesses = finalFitness(CCGAHCFitnesses, result[i].CCGAHCFitnessHistory)
+	}
+	return GAFitnesses, CCGAFitnesses, CCGAHCFitnesses
+}
 
-	return GAStdDev, CCGAStdDev, CCGAHCStdDev
+// getStats calculates the mean and (population) standard deviation of the final fitnesses of each run
+func getStats(fitnesses []float64) (float64, float64) {
+	var sum, variance float64
+	for i := 0; i < len(fitnesses); i++ {
+		sum += fitnesses[i]
+	}
+	mean := sum / float64(len(fitnesses))
+	for i := 0; i < len(fitnesses); i++ {
+		variance += math.Pow(fitnesses[i]-mean, 2)
+	}
+	return mean, math.Sqrt(variance / float64(len(fitnesses)))
 }